@@ -0,0 +1,141 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/hyprmcp/jetski/internal/analytics"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/db/queryable"
+	"github.com/hyprmcp/jetski/internal/mailsending"
+	"github.com/hyprmcp/jetski/internal/notifications"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+)
+
+// Evaluator periodically evaluates all enabled alert rules, fires and resolves incidents and sends notifications.
+type Evaluator struct {
+	logger   *zap.Logger
+	db       queryable.Queryable
+	interval time.Duration
+}
+
+func NewEvaluator(logger *zap.Logger, db queryable.Queryable, interval time.Duration) *Evaluator {
+	return &Evaluator{logger: logger, db: db, interval: interval}
+}
+
+// Run evaluates all rules that are due every interval until ctx is canceled
+func (e *Evaluator) Run(ctx context.Context) {
+	ctx = internalctx.WithDb(ctx, e.db)
+	ctx = internalctx.WithLogger(ctx, e.logger)
+
+	e.logger.Info("starting alert evaluator", zap.Duration("interval", e.interval))
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.evaluateDueRules(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.logger.Info("stopping alert evaluator")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Evaluator) evaluateDueRules(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := db.RunTx(ctx, e.evaluateNextRule); errors.Is(err, apierrors.ErrNotFound) {
+			return
+		} else if err != nil {
+			e.logger.Error("alert rule evaluation failed", zap.Error(err))
+			sentry.CaptureException(err)
+			return
+		}
+	}
+}
+
+// evaluateNextRule locks and evaluates the next rule that is due. It returns apierrors.ErrNotFound if there is no such
+// rule.
+func (e *Evaluator) evaluateNextRule(ctx context.Context) error {
+	now := time.Now()
+	rule, err := db.LockNextAlertRuleDueForEvaluation(ctx, now.Add(-e.interval))
+	if err != nil {
+		return err
+	}
+
+	log := e.logger.With(zap.Stringer("alertRule", rule.ID))
+
+	if evaluation, err := analytics.EvaluateAlertRule(ctx, *rule, now); err != nil {
+		// a rule that can not be evaluated is skipped until the next interval so that it doesn't block other rules
+		log.Warn("could not evaluate alert rule", zap.Error(err))
+	} else if err := e.updateIncident(ctx, *rule, *evaluation, now); err != nil {
+		return err
+	}
+
+	return db.SetAlertRuleEvaluated(ctx, rule.ID, now)
+}
+
+// updateIncident fires a new incident or resolves the open incident of the rule depending on the evaluation
+func (e *Evaluator) updateIncident(
+	ctx context.Context,
+	rule types.AlertRule,
+	evaluation types.AlertEvaluation,
+	now time.Time,
+) error {
+	incident, err := db.GetFiringAlertIncident(ctx, rule.ID)
+	if errors.Is(err, apierrors.ErrNotFound) {
+		incident = nil
+	} else if err != nil {
+		return err
+	}
+
+	switch {
+	case evaluation.Firing && incident == nil:
+		incident = &types.AlertIncident{
+			CreatedAt:   now,
+			AlertRuleID: &rule.ID,
+			ProjectID:   rule.ProjectID,
+			RuleName:    rule.Name,
+			Metric:      rule.Metric,
+			Threshold:   rule.Threshold,
+			Value:       evaluation.Value,
+			Message:     evaluation.Message,
+		}
+		if err := db.CreateAlertIncident(ctx, incident); err != nil {
+			return err
+		}
+		e.logger.Info("alert incident fired", zap.Stringer("incident", incident.ID), zap.String("message", incident.Message))
+	case !evaluation.Firing && incident != nil:
+		if err := db.ResolveAlertIncident(ctx, incident, now); err != nil {
+			return err
+		}
+		e.logger.Info("alert incident resolved", zap.Stringer("incident", incident.ID))
+	default:
+		return nil
+	}
+
+	return e.notify(ctx, *incident, evaluation.Message)
+}
+
+// notify enqueues notifications for the notification channels of the organization and alert mails for its members
+func (e *Evaluator) notify(ctx context.Context, incident types.AlertIncident, message string) error {
+	project, err := db.GetProjectSummary(ctx, incident.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	event := notifications.AlertIncidentChanged(project.Organization, project.Project, incident, message)
+	if err := notifications.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	return mailsending.SendAlertMail(ctx, incident, message, *project)
+}
@@ -0,0 +1,159 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+const (
+	// newErrorGroupLookback is the period before the evaluation window that is used to determine whether an error
+	// group is new
+	newErrorGroupLookback = 7 * 24 * time.Hour
+	// maxErrorGroupMessageLength limits the part of an error message that is used to group errors
+	maxErrorGroupMessageLength = 120
+	maxListedErrorGroups       = 3
+)
+
+// EvaluateAlertRule computes the metric of an alert rule for the evaluation window ending at now
+func EvaluateAlertRule(ctx context.Context, rule types.AlertRule, now time.Time) (*types.AlertEvaluation, error) {
+	windowStart := now.Add(-rule.EvaluationWindow)
+
+	var logs []types.MCPServerLog
+	var err error
+	switch rule.Metric {
+	case types.AlertMetricErrorRate, types.AlertMetricP95Latency:
+		logs, err = db.GetLogsForProjectInRange(ctx, rule.ProjectID, windowStart, now, false)
+	case types.AlertMetricRequestVolumeDrop:
+		logs, err = db.GetLogsForProjectInRange(ctx, rule.ProjectID, windowStart.Add(-rule.EvaluationWindow), now, false)
+	case types.AlertMetricNewErrorGroup:
+		logs, err = db.GetLogsForProjectInRange(ctx, rule.ProjectID, windowStart.Add(-newErrorGroupLookback), now, true)
+	default:
+		return nil, fmt.Errorf("unknown alert metric: %v", rule.Metric)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for project: %w", err)
+	}
+
+	current, previous := partitionLogsAt(logs, windowStart)
+	evaluation := calculateAlertEvaluation(rule, current, previous)
+	return &evaluation, nil
+}
+
+// partitionLogsAt splits logs into those started at or after t and those started before t
+func partitionLogsAt(logs []types.MCPServerLog, t time.Time) (after []types.MCPServerLog, before []types.MCPServerLog) {
+	for _, log := range logs {
+		if log.StartedAt.Before(t) {
+			before = append(before, log)
+		} else {
+			after = append(after, log)
+		}
+	}
+	return
+}
+
+// calculateAlertEvaluation evaluates the rule for the logs of the current window. previousLogs contains the logs of
+// the previous window for request_volume_drop and the error logs of the lookback period for new_error_group.
+func calculateAlertEvaluation(
+	rule types.AlertRule,
+	currentLogs []types.MCPServerLog,
+	previousLogs []types.MCPServerLog,
+) types.AlertEvaluation {
+	evaluation := types.AlertEvaluation{RequestCount: len(currentLogs)}
+	hasMinRequests := len(currentLogs) > 0 && len(currentLogs) >= rule.MinRequestCount
+
+	switch rule.Metric {
+	case types.AlertMetricErrorRate:
+		_, evaluation.Value = calculateLatencyAndErrorRate(currentLogs)
+		evaluation.Firing = hasMinRequests && evaluation.Value > rule.Threshold
+		evaluation.Message = fmt.Sprintf(
+			"Error rate is %.1f%% (%v requests) with a threshold of %.1f%%",
+			100*evaluation.Value, len(currentLogs), 100*rule.Threshold,
+		)
+	case types.AlertMetricP95Latency:
+		evaluation.Value = float64(calculateLatencyPercentile(currentLogs, 0.95).Milliseconds())
+		evaluation.Firing = hasMinRequests && evaluation.Value > rule.Threshold
+		evaluation.Message = fmt.Sprintf(
+			"95th percentile latency is %.0f ms (%v requests) with a threshold of %.0f ms",
+			evaluation.Value, len(currentLogs), rule.Threshold,
+		)
+	case types.AlertMetricRequestVolumeDrop:
+		if len(previousLogs) > 0 {
+			evaluation.Value = 1 - float64(len(currentLogs))/float64(len(previousLogs))
+		}
+		evaluation.Firing = len(previousLogs) > 0 && len(previousLogs) >= rule.MinRequestCount &&
+			evaluation.Value >= rule.Threshold
+		evaluation.Message = fmt.Sprintf(
+			"Request volume changed by %.1f%% (%v requests compared to %v in the previous window)",
+			-100*evaluation.Value, len(currentLogs), len(previousLogs),
+		)
+	case types.AlertMetricNewErrorGroup:
+		newGroups := findNewErrorGroups(currentLogs, previousLogs)
+		evaluation.Value = float64(len(newGroups))
+		evaluation.Firing = evaluation.Value >= rule.Threshold
+		if len(newGroups) == 0 {
+			evaluation.Message = "No new errors"
+		} else {
+			evaluation.Message = fmt.Sprintf("%v new error(s): %v", len(newGroups),
+				strings.Join(newGroups[:min(maxListedErrorGroups, len(newGroups))], "; "))
+		}
+	}
+
+	return evaluation
+}
+
+// calculateLatencyPercentile returns the p-th percentile (0 < p <= 1) of the request durations using the nearest-rank
+// method
+func calculateLatencyPercentile(logs []types.MCPServerLog, p float64) time.Duration {
+	if len(logs) == 0 {
+		return 0
+	}
+
+	durations := make([]time.Duration, len(logs))
+	for i, log := range logs {
+		durations[i] = log.Duration
+	}
+	slices.Sort(durations)
+
+	rank := int(math.Ceil(p * float64(len(durations))))
+	return durations[max(rank, 1)-1]
+}
+
+// findNewErrorGroups returns the error groups of the current logs that don't occur in the previous logs in the order
+// of their first occurrence
+func findNewErrorGroups(currentLogs []types.MCPServerLog, previousLogs []types.MCPServerLog) []string {
+	known := make(map[string]struct{})
+	for _, log := range previousLogs {
+		if log.IsError() {
+			known[errorGroupKey(log)] = struct{}{}
+		}
+	}
+
+	var result []string
+	for _, log := range currentLogs {
+		if !log.IsError() {
+			continue
+		}
+		key := errorGroupKey(log)
+		if _, exists := known[key]; !exists {
+			known[key] = struct{}{}
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// errorGroupKey groups errors by tool name and the first line of the error message
+func errorGroupKey(log types.MCPServerLog) string {
+	message, _, _ := strings.Cut(extractErrorMessage(log), "\n")
+	if runes := []rune(message); len(runes) > maxErrorGroupMessageLength {
+		message = string(runes[:maxErrorGroupMessageLength])
+	}
+	return extractToolName(log.MCPRequest) + ": " + message
+}
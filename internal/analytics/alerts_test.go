@@ -0,0 +1,95 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+)
+
+func TestCalculateAlertEvaluationErrorRate(t *testing.T) {
+	rule := types.AlertRule{Metric: types.AlertMetricErrorRate, Threshold: 0.2, MinRequestCount: 5}
+
+	logs := []types.MCPServerLog{
+		toolCallLog(t, "search", nil),
+		toolCallLog(t, "search", nil),
+		toolCallLog(t, "search", nil),
+	}
+	logs[0].HttpStatusCode = util.PtrTo(500)
+
+	if evaluation := calculateAlertEvaluation(rule, logs, nil); evaluation.Firing {
+		t.Errorf("expected rule not to fire below the minimum request count: %+v", evaluation)
+	}
+
+	logs = append(logs, toolCallLog(t, "search", nil), toolCallLog(t, "search", nil))
+	if evaluation := calculateAlertEvaluation(rule, logs, nil); evaluation.Firing || evaluation.Value != 0.2 {
+		t.Errorf("expected rule not to fire at the threshold: %+v", evaluation)
+	}
+
+	logs[1].HttpStatusCode = util.PtrTo(502)
+	if evaluation := calculateAlertEvaluation(rule, logs, nil); !evaluation.Firing || evaluation.Value != 0.4 {
+		t.Errorf("expected rule to fire with error rate 0.4: %+v", evaluation)
+	}
+}
+
+func TestCalculateAlertEvaluationP95Latency(t *testing.T) {
+	rule := types.AlertRule{Metric: types.AlertMetricP95Latency, Threshold: 1000}
+
+	var logs []types.MCPServerLog
+	for i := range 20 {
+		log := toolCallLog(t, "search", nil)
+		log.Duration = time.Duration(i+1) * 100 * time.Millisecond
+		logs = append(logs, log)
+	}
+
+	if evaluation := calculateAlertEvaluation(rule, logs, nil); !evaluation.Firing || evaluation.Value != 1900 {
+		t.Errorf("expected rule to fire with p95 latency 1900: %+v", evaluation)
+	}
+
+	if evaluation := calculateAlertEvaluation(rule, nil, nil); evaluation.Firing {
+		t.Errorf("expected rule not to fire without requests: %+v", evaluation)
+	}
+}
+
+func TestCalculateAlertEvaluationRequestVolumeDrop(t *testing.T) {
+	rule := types.AlertRule{Metric: types.AlertMetricRequestVolumeDrop, Threshold: 0.5}
+
+	previous := make([]types.MCPServerLog, 10)
+	current := make([]types.MCPServerLog, 4)
+
+	if evaluation := calculateAlertEvaluation(rule, current, previous); !evaluation.Firing || evaluation.Value != 0.6 {
+		t.Errorf("expected rule to fire with a drop of 0.6: %+v", evaluation)
+	}
+
+	if evaluation := calculateAlertEvaluation(rule, current, nil); evaluation.Firing {
+		t.Errorf("expected rule not to fire without previous requests: %+v", evaluation)
+	}
+}
+
+func TestCalculateAlertEvaluationNewErrorGroup(t *testing.T) {
+	rule := types.AlertRule{Metric: types.AlertMetricNewErrorGroup, Threshold: 1}
+
+	failed := func(tool, message string) types.MCPServerLog {
+		log := toolCallLog(t, tool, nil)
+		log.HttpStatusCode = util.PtrTo(500)
+		log.HttpError = util.PtrTo(message)
+		return log
+	}
+
+	previous := []types.MCPServerLog{failed("search", "timeout")}
+	current := []types.MCPServerLog{failed("search", "timeout"), toolCallLog(t, "fetch", nil)}
+
+	if evaluation := calculateAlertEvaluation(rule, current, previous); evaluation.Firing {
+		t.Errorf("expected rule not to fire for known errors: %+v", evaluation)
+	}
+
+	current = append(current, failed("fetch", "connection refused\ndetails"), failed("fetch", "connection refused"))
+	evaluation := calculateAlertEvaluation(rule, current, previous)
+	if !evaluation.Firing || evaluation.Value != 1 {
+		t.Errorf("expected rule to fire for one new error group: %+v", evaluation)
+	}
+	if evaluation.Message != "1 new error(s): fetch: connection refused" {
+		t.Errorf("unexpected message: %v", evaluation.Message)
+	}
+}
@@ -0,0 +1,61 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+const maxDigestFailingTools = 3
+
+// GetOrganizationDigest summarizes the usage of all projects of an organization in the given period compared to the
+// previous period of the same duration
+func GetOrganizationDigest(
+	ctx context.Context,
+	orgID uuid.UUID,
+	periodStart, periodEnd time.Time,
+) (*types.OrganizationDigest, error) {
+	projects, err := db.GetProjectSummaries(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects for organization: %w", err)
+	}
+
+	startedAfter := previousPeriodStart(periodStart, periodEnd)
+	logs, err := db.GetLogsForOrganization(ctx, orgID, &startedAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for organization: %w", err)
+	}
+
+	currentLogs, previousLogs := splitLogsByPeriod(logs, periodStart, periodEnd)
+	digest := calculateOrganizationDigest(projects, currentLogs, previousLogs)
+	digest.PeriodStart = periodStart
+	digest.PeriodEnd = periodEnd
+	return digest, nil
+}
+
+func calculateOrganizationDigest(
+	projects []types.ProjectSummary,
+	currentLogs []types.MCPServerLog,
+	previousLogs []types.MCPServerLog,
+) *types.OrganizationDigest {
+	currentByProject := groupLogsByProject(currentLogs)
+	previousByProject := groupLogsByProject(previousLogs)
+
+	digest := types.OrganizationDigest{Projects: make([]types.ProjectDigest, 0, len(projects))}
+	for _, project := range projects {
+		logs := currentByProject[project.ID]
+		failingTools := calculateErrorHotspots(map[uuid.UUID]string{project.ID: project.Name}, logs)
+		digest.Projects = append(digest.Projects, types.ProjectDigest{
+			ProjectID:       project.ID,
+			ProjectName:     project.Name,
+			Overview:        calculateOverviewWithComparison(logs, previousByProject[project.ID]),
+			TopFailingTools: failingTools[:min(maxDigestFailingTools, len(failingTools))],
+		})
+	}
+
+	return &digest
+}
@@ -0,0 +1,54 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+)
+
+func TestCalculateOrganizationDigest(t *testing.T) {
+	projectA := types.ProjectSummary{Project: types.Project{ID: uuid.New(), Name: "a"}}
+	projectB := types.ProjectSummary{Project: types.Project{ID: uuid.New(), Name: "b"}}
+
+	now := time.Now()
+	log := func(project types.ProjectSummary, tool string, failed bool) types.MCPServerLog {
+		l := toolCallLog(t, tool, nil)
+		l.ProjectID = project.ID
+		l.StartedAt = now
+		if failed {
+			l.HttpStatusCode = util.PtrTo(500)
+		}
+		return l
+	}
+
+	current := []types.MCPServerLog{
+		log(projectA, "search", true),
+		log(projectA, "fetch", true),
+		log(projectA, "list", true),
+		log(projectA, "read", true),
+		log(projectA, "write", false),
+	}
+	previous := []types.MCPServerLog{log(projectA, "search", false), log(projectB, "search", false)}
+
+	digest := calculateOrganizationDigest([]types.ProjectSummary{projectA, projectB}, current, previous)
+
+	if len(digest.Projects) != 2 {
+		t.Fatalf("expected 2 projects, got %v", len(digest.Projects))
+	}
+
+	a := digest.Projects[0]
+	if a.ProjectID != projectA.ID || a.Overview.TotalToolCallsCount != 5 || a.Overview.TotalToolCallsChange != 4 {
+		t.Errorf("unexpected digest for project a: %+v", a)
+	}
+	if len(a.TopFailingTools) != maxDigestFailingTools {
+		t.Errorf("expected %v failing tools, got %v", maxDigestFailingTools, len(a.TopFailingTools))
+	}
+
+	b := digest.Projects[1]
+	if b.Overview.TotalToolCallsCount != 0 || b.Overview.TotalToolCallsChange != -1 || len(b.TopFailingTools) != 0 {
+		t.Errorf("unexpected digest for project b: %+v", b)
+	}
+}
@@ -0,0 +1,240 @@
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	maxOrganizationTopTools      = 10
+	maxOrganizationErrorHotspots = 10
+)
+
+// GetOrganizationAnalytics retrieves and aggregates analytics data across all projects of an organization
+func GetOrganizationAnalytics(ctx context.Context, orgID uuid.UUID, startAt time.Time) (*types.OrganizationAnalytics, error) {
+	projects, err := db.GetProjectSummaries(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects for organization: %w", err)
+	}
+
+	now := time.Now()
+	var startedAfter *time.Time
+	if !startAt.IsZero() {
+		t := previousPeriodStart(startAt, now)
+		startedAfter = &t
+	}
+
+	logs, err := db.GetLogsForOrganization(ctx, orgID, startedAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for organization: %w", err)
+	}
+
+	currentLogs, previousLogs := splitLogsByPeriod(logs, startAt, now)
+	return aggregateOrganizationAnalytics(projects, currentLogs, previousLogs), nil
+}
+
+func aggregateOrganizationAnalytics(
+	projects []types.ProjectSummary,
+	currentLogs []types.MCPServerLog,
+	previousLogs []types.MCPServerLog,
+) *types.OrganizationAnalytics {
+	projectNames := make(map[uuid.UUID]string, len(projects))
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+	}
+
+	return &types.OrganizationAnalytics{
+		Overview:      calculateOverviewWithComparison(currentLogs, previousLogs),
+		Projects:      calculateProjectRanking(projects, currentLogs, previousLogs),
+		TopTools:      calculateOrganizationTopTools(projectNames, currentLogs),
+		ClientUsage:   calculateClientUsage(currentLogs),
+		ErrorHotspots: calculateErrorHotspots(projectNames, currentLogs),
+	}
+}
+
+// calculateProjectRanking computes the key metrics of every project, sorted by the number of tool calls
+func calculateProjectRanking(
+	projects []types.ProjectSummary,
+	currentLogs []types.MCPServerLog,
+	previousLogs []types.MCPServerLog,
+) []types.ProjectRanking {
+	currentByProject := groupLogsByProject(currentLogs)
+	previousByProject := groupLogsByProject(previousLogs)
+
+	result := make([]types.ProjectRanking, 0, len(projects))
+	for _, project := range projects {
+		logs := currentByProject[project.ID]
+		avgLatency, errorRate := calculateLatencyAndErrorRate(logs)
+		result = append(result, types.ProjectRanking{
+			ProjectID:            project.ID,
+			ProjectName:          project.Name,
+			TotalSessionCount:    countUniqueSessions(logs),
+			TotalToolCallsCount:  len(logs),
+			TotalToolCallsChange: calculatePercentageChange(len(previousByProject[project.ID]), len(logs)),
+			UsersCount:           countUniqueUsers(logs),
+			AvgLatencyValue:      avgLatency,
+			ErrorRateValue:       errorRate,
+		})
+	}
+
+	slices.SortStableFunc(result, func(a, b types.ProjectRanking) int {
+		return cmp.Or(b.TotalToolCallsCount-a.TotalToolCallsCount, strings.Compare(a.ProjectName, b.ProjectName))
+	})
+
+	return result
+}
+
+func groupLogsByProject(logs []types.MCPServerLog) map[uuid.UUID][]types.MCPServerLog {
+	result := make(map[uuid.UUID][]types.MCPServerLog)
+	for _, log := range logs {
+		result[log.ProjectID] = append(result[log.ProjectID], log)
+	}
+	return result
+}
+
+// projectTool identifies a tool across projects, because different projects can have tools with the same name
+type projectTool struct {
+	projectID uuid.UUID
+	name      string
+}
+
+type organizationToolStats struct {
+	toolPerformanceStats
+	lastErrorAt time.Time
+	lastError   string
+}
+
+func collectOrganizationToolStats(logs []types.MCPServerLog) map[projectTool]*organizationToolStats {
+	toolStats := make(map[projectTool]*organizationToolStats)
+
+	for _, log := range logs {
+		toolName := extractToolName(log.MCPRequest)
+		if toolName == "" {
+			continue
+		}
+
+		key := projectTool{projectID: log.ProjectID, name: toolName}
+		if _, exists := toolStats[key]; !exists {
+			toolStats[key] = &organizationToolStats{}
+		}
+
+		stats := toolStats[key]
+		stats.totalCalls++
+		stats.totalDuration += log.Duration
+
+		if log.IsError() {
+			stats.errorCalls++
+			if !log.StartedAt.Before(stats.lastErrorAt) {
+				stats.lastErrorAt = log.StartedAt
+				stats.lastError = extractErrorMessage(log)
+			}
+		}
+	}
+
+	return toolStats
+}
+
+// calculateOrganizationTopTools returns the most called tools across all projects
+func calculateOrganizationTopTools(
+	projectNames map[uuid.UUID]string,
+	logs []types.MCPServerLog,
+) []types.OrganizationTool {
+	toolStats := collectOrganizationToolStats(logs)
+
+	result := make([]types.OrganizationTool, 0, len(toolStats))
+	for key, stats := range toolStats {
+		tool := types.OrganizationTool{
+			ProjectID:      key.projectID,
+			ProjectName:    projectNames[key.projectID],
+			PerformingTool: types.PerformingTool{Name: key.name, TotalCalls: stats.totalCalls},
+		}
+		if stats.totalCalls > 0 {
+			tool.ErrorRate = float64(stats.errorCalls) / float64(stats.totalCalls)
+			tool.AvgLatency = stats.totalDuration.Milliseconds() / stats.totalCalls
+		}
+		result = append(result, tool)
+	}
+
+	slices.SortFunc(result, func(a, b types.OrganizationTool) int {
+		return cmp.Or(
+			cmp.Compare(b.TotalCalls, a.TotalCalls),
+			strings.Compare(a.ProjectName, b.ProjectName),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+
+	return result[:min(maxOrganizationTopTools, len(result))]
+}
+
+// calculateErrorHotspots returns the tools with the most errors across all projects
+func calculateErrorHotspots(projectNames map[uuid.UUID]string, logs []types.MCPServerLog) []types.ErrorHotspot {
+	toolStats := collectOrganizationToolStats(logs)
+
+	result := make([]types.ErrorHotspot, 0)
+	for key, stats := range toolStats {
+		if stats.errorCalls == 0 {
+			continue
+		}
+		result = append(result, types.ErrorHotspot{
+			ProjectID:   key.projectID,
+			ProjectName: projectNames[key.projectID],
+			ToolName:    key.name,
+			ErrorCount:  int(stats.errorCalls),
+			TotalCalls:  int(stats.totalCalls),
+			ErrorRate:   float64(stats.errorCalls) / float64(stats.totalCalls),
+			LastErrorAt: stats.lastErrorAt,
+			LastError:   stats.lastError,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b types.ErrorHotspot) int {
+		return cmp.Or(
+			b.ErrorCount-a.ErrorCount,
+			cmp.Compare(b.ErrorRate, a.ErrorRate),
+			strings.Compare(a.ProjectName, b.ProjectName),
+			strings.Compare(a.ToolName, b.ToolName),
+		)
+	})
+
+	return result[:min(maxOrganizationErrorHotspots, len(result))]
+}
+
+// extractErrorMessage returns a human-readable description of the error of a failed request
+func extractErrorMessage(log types.MCPServerLog) string {
+	if log.HttpError != nil && *log.HttpError != "" {
+		return *log.HttpError
+	}
+
+	if log.MCPResponse != nil {
+		if log.MCPResponse.Error != nil {
+			return log.MCPResponse.Error.Message
+		}
+
+		if log.MCPResponse.Result != nil {
+			var result mcp.CallToolResult
+			if err := json.Unmarshal(*log.MCPResponse.Result, &result); err == nil {
+				for _, content := range result.Content {
+					if text, ok := content.(*mcp.TextContent); ok && text.Text != "" {
+						return text.Text
+					}
+				}
+			}
+		}
+	}
+
+	if log.HttpStatusCode != nil {
+		return fmt.Sprintf("HTTP status %d", *log.HttpStatusCode)
+	}
+
+	return ""
+}
@@ -0,0 +1,72 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+)
+
+func TestAggregateOrganizationAnalytics(t *testing.T) {
+	projectA := types.ProjectSummary{Project: types.Project{ID: uuid.New(), Name: "a"}}
+	projectB := types.ProjectSummary{Project: types.Project{ID: uuid.New(), Name: "b"}}
+	projectC := types.ProjectSummary{Project: types.Project{ID: uuid.New(), Name: "c"}}
+
+	now := time.Now()
+	log := func(project types.ProjectSummary, tool string, failed bool) types.MCPServerLog {
+		l := toolCallLog(t, tool, nil)
+		l.ProjectID = project.ID
+		l.StartedAt = now
+		if failed {
+			l.HttpStatusCode = util.PtrTo(500)
+			l.HttpError = util.PtrTo("upstream unavailable")
+		}
+		return l
+	}
+
+	current := []types.MCPServerLog{
+		log(projectA, "search", false),
+		log(projectB, "search", false),
+		log(projectB, "search", true),
+		log(projectB, "fetch", false),
+	}
+	previous := []types.MCPServerLog{log(projectB, "search", false)}
+
+	result := aggregateOrganizationAnalytics([]types.ProjectSummary{projectA, projectB, projectC}, current, previous)
+
+	if result.Overview.TotalToolCallsCount != 4 {
+		t.Errorf("expected 4 tool calls, got %v", result.Overview.TotalToolCallsCount)
+	}
+
+	if len(result.Projects) != 3 {
+		t.Fatalf("expected 3 projects, got %v", len(result.Projects))
+	}
+	for i, name := range []string{"b", "a", "c"} {
+		if result.Projects[i].ProjectName != name {
+			t.Errorf("expected project %v at position %v, got %v", name, i, result.Projects[i].ProjectName)
+		}
+	}
+	if result.Projects[0].TotalToolCallsChange != 2 {
+		t.Errorf("expected tool calls change 2, got %v", result.Projects[0].TotalToolCallsChange)
+	}
+
+	if len(result.TopTools) != 3 {
+		t.Fatalf("expected 3 top tools, got %v", len(result.TopTools))
+	}
+	if tool := result.TopTools[0]; tool.ProjectID != projectB.ID || tool.Name != "search" || tool.TotalCalls != 2 {
+		t.Errorf("unexpected top tool: %+v", tool)
+	}
+
+	if len(result.ErrorHotspots) != 1 {
+		t.Fatalf("expected 1 error hotspot, got %v", len(result.ErrorHotspots))
+	}
+	hotspot := result.ErrorHotspots[0]
+	if hotspot.ProjectName != "b" || hotspot.ToolName != "search" || hotspot.ErrorRate != 0.5 {
+		t.Errorf("unexpected error hotspot: %+v", hotspot)
+	}
+	if hotspot.LastError != "upstream unavailable" {
+		t.Errorf("unexpected last error: %v", hotspot.LastError)
+	}
+}
@@ -13,6 +13,11 @@ import (
 
 // GetProjectAnalytics retrieves and aggregates analytics data for a project from the database
 func GetProjectAnalytics(ctx context.Context, projectID uuid.UUID, startAt time.Time) (*types.ProjectAnalytics, error) {
+	project, err := db.GetProjectSummary(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
 	// Get current period logs and previous period logs for comparison
 	currentLogs, previousLogs, err := getAllLogsWithComparison(ctx, projectID, startAt)
 	if err != nil {
@@ -20,7 +25,7 @@ func GetProjectAnalytics(ctx context.Context, projectID uuid.UUID, startAt time.
 	}
 
 	// Aggregate the logs into analytics data
-	analytics := aggregateLogsToAnalyticsWithComparison(currentLogs, previousLogs)
+	analytics := aggregateLogsToAnalyticsWithComparison(currentLogs, previousLogs, project.Settings)
 	return analytics, nil
 }
 
@@ -39,10 +44,23 @@ func getAllLogsWithComparison(ctx context.Context, projectID uuid.UUID, startAt
 		return nil, nil, err
 	}
 
-	curentPeriodEnd := time.Now()
-	currentPeriodStart := startAt
-	periodDuration := curentPeriodEnd.Sub(currentPeriodStart)
-	previousPeriodStart := currentPeriodStart.Add(-periodDuration) // Double the period backwards
+	currentLogs, previousLogs := splitLogsByPeriod(logs, startAt, time.Now())
+	return currentLogs, previousLogs, nil
+}
+
+// previousPeriodStart returns the start of the period of the same duration directly before the current period
+func previousPeriodStart(currentPeriodStart, currentPeriodEnd time.Time) time.Time {
+	periodDuration := currentPeriodEnd.Sub(currentPeriodStart)
+	return currentPeriodStart.Add(-periodDuration) // Double the period backwards
+}
+
+// splitLogsByPeriod splits logs into logs of the current period (from currentPeriodStart to now) and logs of the
+// previous period of the same duration
+func splitLogsByPeriod(
+	logs []types.MCPServerLog,
+	currentPeriodStart, currentPeriodEnd time.Time,
+) ([]types.MCPServerLog, []types.MCPServerLog) {
+	previousPeriodStart := previousPeriodStart(currentPeriodStart, currentPeriodEnd)
 	previousPeriodEnd := currentPeriodStart
 
 	currentLogs := make([]types.MCPServerLog, 0)
@@ -58,17 +76,21 @@ func getAllLogsWithComparison(ctx context.Context, projectID uuid.UUID, startAt
 		}
 	}
 
-	return currentLogs, previousLogs, nil
+	return currentLogs, previousLogs
 }
 
 // aggregateLogsToAnalyticsWithComparison converts raw MCP server logs into aggregated analytics data with period comparison
-func aggregateLogsToAnalyticsWithComparison(currentLogs []types.MCPServerLog, previousLogs []types.MCPServerLog) *types.ProjectAnalytics {
+func aggregateLogsToAnalyticsWithComparison(
+	currentLogs []types.MCPServerLog,
+	previousLogs []types.MCPServerLog,
+	settings types.ProjectSettings,
+) *types.ProjectAnalytics {
 	// Initialize analytics structure
 	analytics := &types.ProjectAnalytics{
 		Overview:         calculateOverviewWithComparison(currentLogs, previousLogs),
 		ToolsPerformance: calculateToolsPerformance(currentLogs),
-		ToolAnalytics:    calculateToolAnalytics(currentLogs),
-		PromptAnalytics:  calculatePromptAnalytics(currentLogs),
+		ToolAnalytics:    calculateToolAnalytics(currentLogs, settings.Analytics),
+		PromptAnalytics:  calculatePromptAnalytics(currentLogs, settings.Analytics),
 		ClientUsage:      calculateClientUsage(currentLogs),
 		RecentSessions:   calculateRecentSessions(currentLogs),
 	}
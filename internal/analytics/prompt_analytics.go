@@ -8,7 +8,9 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-func calculatePromptAnalytics(logs []types.MCPServerLog) types.PromptAnalytics {
+// calculatePromptAnalytics collects the most recent prompts captured via the prompt argument of the project.
+// Use db.GetPromptsForProject to search through all prompts.
+func calculatePromptAnalytics(logs []types.MCPServerLog, settings types.ProjectAnalyticsSettings) types.PromptAnalytics {
 	const maxResults = 25
 	result := make([]types.MCPPrompt, 0, maxResults)
 
@@ -20,7 +22,7 @@ func calculatePromptAnalytics(logs []types.MCPServerLog) types.PromptAnalytics {
 			}
 
 			if argMap, ok := toolParams.Arguments.(map[string]any); ok {
-				if prompt, ok := argMap["hyprmcpPromptAnalytics"].(string); ok {
+				if prompt, ok := argMap[settings.PromptArgumentName].(string); ok {
 					result = append(result, types.MCPPrompt{
 						ID:       log.ID,
 						ToolName: toolParams.Name,
@@ -0,0 +1,260 @@
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+const (
+	// maxAnalyzedPrompts limits the number of (most recent) prompts that are analyzed per request
+	maxAnalyzedPrompts = 2000
+	// maxIntentClusters limits the number of clusters. Prompts that don't fit any existing cluster after this limit
+	// is reached are counted as unclustered.
+	maxIntentClusters = 200
+	// intentSimilarityThreshold is the minimum cosine similarity between a prompt and a cluster centroid for the
+	// prompt to join the cluster
+	intentSimilarityThreshold = 0.35
+	maxIntents                = 10
+	maxIntentKeywords         = 5
+	maxIntentSamplePrompts    = 3
+	maxToolKeywords           = 10
+)
+
+// GetPromptIntents extracts keywords and clusters the captured prompts of a project into intents
+func GetPromptIntents(
+	ctx context.Context,
+	projectID uuid.UUID,
+	startedAfter, startedBefore *time.Time,
+) (*types.PromptIntents, error) {
+	filter := db.PromptFilter{StartedAfter: startedAfter, StartedBefore: startedBefore}
+	count, err := db.CountPromptsForProject(ctx, projectID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count prompts for project: %w", err)
+	}
+
+	prompts, err := db.GetPromptsForProject(
+		ctx,
+		projectID,
+		lists.Pagination{Count: maxAnalyzedPrompts},
+		lists.Sorting{SortBy: "started_at", SortOrder: lists.SortOrderDesc},
+		filter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompts for project: %w", err)
+	}
+
+	return calculatePromptIntents(prompts, max(count, len(prompts))), nil
+}
+
+type analyzedPrompt struct {
+	types.MCPServerLogPromptData
+	vector termVector
+}
+
+type intentCluster struct {
+	prompts  []*analyzedPrompt
+	sum      termVector
+	centroid termVector
+}
+
+func (c *intentCluster) add(p *analyzedPrompt) {
+	c.prompts = append(c.prompts, p)
+	c.sum.add(p.vector)
+	c.centroid = c.sum.normalized()
+}
+
+// calculatePromptIntents expects prompts sorted by started_at descending (most recent first). The prompts are
+// truncated to maxAnalyzedPrompts, totalCount is the number of prompts before they were limited by the query.
+func calculatePromptIntents(prompts []types.MCPServerLogPromptData, totalCount int) *types.PromptIntents {
+	result := types.PromptIntents{
+		PromptCount:  totalCount,
+		Intents:      make([]types.PromptIntent, 0),
+		ToolKeywords: make([]types.ToolKeywords, 0),
+	}
+
+	if len(prompts) > maxAnalyzedPrompts {
+		prompts = prompts[:maxAnalyzedPrompts]
+	}
+	result.AnalyzedPromptCount = len(prompts)
+
+	analyzed := make([]*analyzedPrompt, 0, len(prompts))
+	documents := make([]termVector, 0, len(prompts))
+	// process prompts in chronological order so that clusters are seeded by the oldest prompts
+	for _, p := range slices.Backward(prompts) {
+		terms := extractTerms(tokenize(p.Prompt))
+		if len(terms) == 0 {
+			continue
+		}
+		analyzed = append(analyzed, &analyzedPrompt{MCPServerLogPromptData: p})
+		documents = append(documents, termFrequencies(terms))
+	}
+
+	idf := inverseDocumentFrequencies(documents)
+	for i, p := range analyzed {
+		p.vector = tfidf(documents[i], idf)
+	}
+
+	result.ToolKeywords = calculateToolKeywords(analyzed)
+
+	clusters, unclustered := clusterPrompts(analyzed)
+	result.UnclusteredCount = unclustered + len(prompts) - len(analyzed)
+	for _, cluster := range clusters {
+		if len(cluster.prompts) < 2 {
+			result.UnclusteredCount += len(cluster.prompts)
+			continue
+		}
+		result.Intents = append(result.Intents, cluster.toIntent(len(prompts)))
+	}
+
+	slices.SortStableFunc(result.Intents, func(a, b types.PromptIntent) int {
+		return cmp.Or(b.PromptCount-a.PromptCount, b.LastSeenAt.Compare(a.LastSeenAt))
+	})
+
+	if len(result.Intents) > maxIntents {
+		for _, intent := range result.Intents[maxIntents:] {
+			result.UnclusteredCount += intent.PromptCount
+		}
+		result.Intents = result.Intents[:maxIntents]
+	}
+
+	return &result
+}
+
+// clusterPrompts performs a single pass "leader" clustering: every prompt joins the most similar cluster if the
+// similarity to its centroid is above the threshold or starts a new cluster otherwise.
+func clusterPrompts(prompts []*analyzedPrompt) (clusters []*intentCluster, unclustered int) {
+	for _, p := range prompts {
+		var best *intentCluster
+		var bestSimilarity float64
+		for _, c := range clusters {
+			if similarity := cosineSimilarity(p.vector, c.centroid); similarity > bestSimilarity {
+				best = c
+				bestSimilarity = similarity
+			}
+		}
+
+		if best != nil && bestSimilarity >= intentSimilarityThreshold {
+			best.add(p)
+		} else if len(clusters) < maxIntentClusters {
+			c := &intentCluster{sum: make(termVector)}
+			c.add(p)
+			clusters = append(clusters, c)
+		} else {
+			unclustered++
+		}
+	}
+	return
+}
+
+func (c *intentCluster) toIntent(totalPrompts int) types.PromptIntent {
+	intent := types.PromptIntent{
+		Keywords:    topTerms(c.centroid, maxIntentKeywords),
+		PromptCount: len(c.prompts),
+		Share:       float64(len(c.prompts)) / float64(totalPrompts),
+		FirstSeenAt: c.prompts[0].StartedAt,
+		LastSeenAt:  c.prompts[0].StartedAt,
+	}
+
+	intent.Label = strings.Join(intent.Keywords[:min(3, len(intent.Keywords))], ", ")
+
+	toolCounts := make(map[string]int)
+	for _, p := range c.prompts {
+		toolCounts[p.ToolName]++
+		if p.StartedAt.Before(intent.FirstSeenAt) {
+			intent.FirstSeenAt = p.StartedAt
+		}
+		if p.StartedAt.After(intent.LastSeenAt) {
+			intent.LastSeenAt = p.StartedAt
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(toolCounts)) {
+		intent.Tools = append(intent.Tools, types.IntentTool{Name: name, PromptCount: toolCounts[name]})
+	}
+	slices.SortStableFunc(intent.Tools, func(a, b types.IntentTool) int { return b.PromptCount - a.PromptCount })
+
+	// the most representative prompts are those closest to the centroid
+	samples := slices.Clone(c.prompts)
+	slices.SortStableFunc(samples, func(a, b *analyzedPrompt) int {
+		return cmp.Compare(cosineSimilarity(b.vector, c.centroid), cosineSimilarity(a.vector, c.centroid))
+	})
+	for _, p := range samples[:min(maxIntentSamplePrompts, len(samples))] {
+		intent.SamplePrompts = append(intent.SamplePrompts, types.MCPPrompt{ID: p.ID, ToolName: p.ToolName, Prompt: p.Prompt})
+	}
+
+	return intent
+}
+
+// calculateToolKeywords returns the terms with the highest accumulated tf-idf score for each tool
+func calculateToolKeywords(prompts []*analyzedPrompt) []types.ToolKeywords {
+	type toolData struct {
+		promptCount int
+		scores      termVector
+		counts      map[string]int
+	}
+
+	tools := make(map[string]*toolData)
+	for _, p := range prompts {
+		data, exists := tools[p.ToolName]
+		if !exists {
+			data = &toolData{scores: make(termVector), counts: make(map[string]int)}
+			tools[p.ToolName] = data
+		}
+		data.promptCount++
+		data.scores.add(p.vector)
+		for term := range p.vector {
+			data.counts[term]++
+		}
+	}
+
+	result := make([]types.ToolKeywords, 0, len(tools))
+	for _, name := range slices.Sorted(maps.Keys(tools)) {
+		data := tools[name]
+		tk := types.ToolKeywords{ToolName: name, PromptCount: data.promptCount, Keywords: make([]types.Keyword, 0)}
+		for _, term := range topTerms(data.scores, maxToolKeywords) {
+			tk.Keywords = append(tk.Keywords, types.Keyword{Term: term, Count: data.counts[term], Score: data.scores[term]})
+		}
+		result = append(result, tk)
+	}
+
+	slices.SortStableFunc(result, func(a, b types.ToolKeywords) int { return b.PromptCount - a.PromptCount })
+
+	return result
+}
+
+// topTerms returns the n terms with the highest weight. Bigrams are preferred over unigrams of similar weight
+// because they are more descriptive, and terms overlapping with an already selected term are skipped to avoid
+// redundant keywords.
+func topTerms(v termVector, n int) []string {
+	rank := func(term string) float64 {
+		return v[term] * (1 + 0.5*float64(strings.Count(term, " ")))
+	}
+	terms := slices.SortedFunc(maps.Keys(v), func(a, b string) int {
+		return cmp.Or(cmp.Compare(rank(b), rank(a)), strings.Compare(a, b))
+	})
+
+	result := make([]string, 0, n)
+	for _, term := range terms {
+		if len(result) >= n {
+			break
+		}
+		if !slices.ContainsFunc(result, func(selected string) bool { return containsWord(selected, term) || containsWord(term, selected) }) {
+			result = append(result, term)
+		}
+	}
+	return result
+}
+
+func containsWord(term, word string) bool {
+	return slices.Contains(strings.Fields(term), word)
+}
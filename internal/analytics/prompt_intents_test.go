@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestTokenize(t *testing.T) {
+	actual := tokenize("Please show me the open GitHub issues of repo jetski-42 in 2025!")
+	expected := []string{"open", "github", "issues", "repo", "jetski"}
+	if !slices.Equal(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestCalculatePromptIntents(t *testing.T) {
+	now := time.Now()
+	var prompts []types.MCPServerLogPromptData
+	add := func(toolName, prompt string) {
+		prompts = append(prompts, types.MCPServerLogPromptData{
+			ID:        uuid.New(),
+			StartedAt: now.Add(-time.Duration(len(prompts)) * time.Minute),
+			ToolName:  toolName,
+			Prompt:    prompt,
+		})
+	}
+
+	add("list_issues", "list open issues in the jetski repository")
+	add("list_issues", "show me all open issues for jetski repository")
+	add("list_issues", "which open issues exist in the jetski repository")
+	add("get_weather", "what is the weather forecast in Vienna")
+	add("get_weather", "weather forecast for Vienna tomorrow")
+	add("get_weather", "how is the weather forecast in Vienna")
+	add("get_weather", "translate this sentence to french")
+
+	result := calculatePromptIntents(prompts, len(prompts))
+
+	if result.PromptCount != 7 || result.AnalyzedPromptCount != 7 {
+		t.Errorf("unexpected prompt counts %v/%v", result.PromptCount, result.AnalyzedPromptCount)
+	}
+	if len(result.Intents) != 2 {
+		t.Fatalf("expected 2 intents, got %v", result.Intents)
+	}
+	if result.UnclusteredCount != 1 {
+		t.Errorf("expected 1 unclustered prompt, got %v", result.UnclusteredCount)
+	}
+
+	for _, intent := range result.Intents {
+		if intent.PromptCount != 3 || len(intent.Tools) != 1 || len(intent.SamplePrompts) != 3 {
+			t.Errorf("unexpected intent %v", intent)
+		}
+		if tool := intent.Tools[0].Name; tool == "list_issues" && !slices.Contains(intent.Keywords, "open issues") {
+			t.Errorf("expected keyword \"open issues\" in %v", intent.Keywords)
+		} else if tool == "get_weather" && !slices.Contains(intent.Keywords, "weather forecast") {
+			t.Errorf("expected keyword \"weather forecast\" in %v", intent.Keywords)
+		}
+	}
+
+	if len(result.ToolKeywords) != 2 {
+		t.Fatalf("expected keywords for 2 tools, got %v", result.ToolKeywords)
+	}
+	if tk := result.ToolKeywords[0]; tk.ToolName != "get_weather" || tk.PromptCount != 4 || len(tk.Keywords) == 0 {
+		t.Errorf("unexpected tool keywords %v", tk)
+	}
+}
+
+func TestCalculatePromptIntentsEmpty(t *testing.T) {
+	result := calculatePromptIntents(nil, 0)
+	if result.PromptCount != 0 || len(result.Intents) != 0 || result.UnclusteredCount != 0 {
+		t.Errorf("unexpected result %v", result)
+	}
+}
@@ -0,0 +1,142 @@
+package analytics
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// stopWords contains common english words that carry no meaning on their own and are ignored during keyword
+// extraction
+var stopWords = toSet(
+	"a", "about", "above", "after", "again", "all", "also", "am", "an", "and", "any", "are", "as", "at", "be",
+	"because", "been", "before", "being", "below", "between", "both", "but", "by", "can", "could", "did", "do",
+	"does", "doing", "down", "during", "each", "few", "for", "from", "further", "get", "give", "had", "has",
+	"have", "having", "he", "her", "here", "hers", "him", "his", "how", "i", "if", "in", "into", "is", "it",
+	"its", "just", "let", "like", "me", "more", "most", "my", "need", "no", "nor", "not", "now", "of", "off",
+	"on", "once", "only", "or", "other", "our", "out", "over", "own", "please", "same", "she", "should", "show",
+	"so", "some", "such", "tell", "than", "that", "the", "their", "them", "then", "there", "these", "they",
+	"this", "those", "through", "to", "too", "under", "until", "up", "us", "use", "very", "want", "was", "we",
+	"were", "what", "when", "where", "which", "while", "who", "whom", "why", "will", "with", "would", "you",
+	"your",
+)
+
+func toSet(values ...string) map[string]struct{} {
+	result := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		result[v] = struct{}{}
+	}
+	return result
+}
+
+// tokenize splits a text into lower case words and drops stop words, numbers and single characters
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 2 || isNumber(field) {
+			continue
+		}
+		if _, isStopWord := stopWords[field]; isStopWord {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// extractTerms returns all unigrams and bigrams of the given tokens
+func extractTerms(tokens []string) []string {
+	terms := make([]string, 0, 2*len(tokens))
+	terms = append(terms, tokens...)
+	for i := 1; i < len(tokens); i++ {
+		terms = append(terms, tokens[i-1]+" "+tokens[i])
+	}
+	return terms
+}
+
+// termVector is a sparse vector of term weights
+type termVector map[string]float64
+
+func termFrequencies(terms []string) termVector {
+	tf := make(termVector, len(terms))
+	for _, term := range terms {
+		tf[term]++
+	}
+	return tf
+}
+
+// inverseDocumentFrequencies computes the smoothed idf of every term in the given documents
+func inverseDocumentFrequencies(documents []termVector) map[string]float64 {
+	df := make(map[string]int)
+	for _, doc := range documents {
+		for term := range doc {
+			df[term]++
+		}
+	}
+
+	idf := make(map[string]float64, len(df))
+	for term, count := range df {
+		idf[term] = math.Log(float64(1+len(documents))/float64(1+count)) + 1
+	}
+	return idf
+}
+
+// tfidf weights the term frequencies with the idf and normalizes the result to unit length
+func tfidf(tf termVector, idf map[string]float64) termVector {
+	v := make(termVector, len(tf))
+	for term, freq := range tf {
+		v[term] = freq * idf[term]
+	}
+	return v.normalized()
+}
+
+func (v termVector) norm() float64 {
+	var sum float64
+	for _, w := range v {
+		sum += w * w
+	}
+	return math.Sqrt(sum)
+}
+
+func (v termVector) normalized() termVector {
+	norm := v.norm()
+	if norm == 0 {
+		return v
+	}
+	result := make(termVector, len(v))
+	for term, w := range v {
+		result[term] = w / norm
+	}
+	return result
+}
+
+func (v termVector) add(other termVector) {
+	for term, w := range other {
+		v[term] += w
+	}
+}
+
+// cosineSimilarity of two vectors. Both vectors are expected to be normalized.
+func cosineSimilarity(a, b termVector) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return dot
+}
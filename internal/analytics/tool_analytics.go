@@ -1,14 +1,29 @@
 package analytics
 
 import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"path"
 	"slices"
 	"strings"
 
 	"github.com/hyprmcp/jetski/internal/types"
 )
 
+const (
+	// maxArgumentValues is the number of values listed per argument. All remaining values are collapsed into
+	// a single "other" entry.
+	maxArgumentValues = 10
+	// maxTrackedArgumentValues limits the number of distinct values that are counted per argument, so that
+	// free-text or ID-like arguments don't produce unbounded maps.
+	maxTrackedArgumentValues = 1000
+	// numericDistributionBuckets is the number of equal-width buckets used for numeric argument distributions.
+	numericDistributionBuckets = 5
+)
+
 // calculateToolAnalytics computes detailed tool usage analytics
-func calculateToolAnalytics(logs []types.MCPServerLog) types.ToolAnalytics {
+func calculateToolAnalytics(logs []types.MCPServerLog, settings types.ProjectAnalyticsSettings) types.ToolAnalytics {
 	toolData := make(map[string]*toolAnalyticsData)
 
 	for _, log := range logs {
@@ -20,7 +35,7 @@ func calculateToolAnalytics(logs []types.MCPServerLog) types.ToolAnalytics {
 		if _, exists := toolData[toolName]; !exists {
 			toolData[toolName] = &toolAnalyticsData{
 				calls:     0,
-				arguments: make(map[string]map[string]int),
+				arguments: make(map[string]*argumentStats),
 			}
 		}
 
@@ -29,10 +44,13 @@ func calculateToolAnalytics(logs []types.MCPServerLog) types.ToolAnalytics {
 
 		// Extract arguments from the MCP request
 		for argName, argValue := range extractArguments(log.MCPRequest) {
+			if isExcludedArgument(argName, settings.ExcludedArguments) {
+				continue
+			}
 			if _, exists := data.arguments[argName]; !exists {
-				data.arguments[argName] = make(map[string]int)
+				data.arguments[argName] = newArgumentStats()
 			}
-			data.arguments[argName][argValue]++
+			data.arguments[argName].add(argValue)
 		}
 	}
 
@@ -40,23 +58,12 @@ func calculateToolAnalytics(logs []types.MCPServerLog) types.ToolAnalytics {
 	tools := make([]types.McpTool, 0)
 	for toolName, data := range toolData {
 		arguments := make([]types.ToolArgument, 0)
-		for argName, values := range data.arguments {
-			argValues := make([]types.ArgumentValue, 0)
-			totalUsage := 0
-			for valueName, count := range values {
-				argValues = append(argValues, types.ArgumentValue{
-					Name:  valueName,
-					Count: count,
-				})
-				totalUsage += count
-			}
-			arguments = append(arguments, types.ToolArgument{
-				Name:       argName,
-				UsageCount: totalUsage,
-				Values:     argValues,
-			})
+		for argName, stats := range data.arguments {
+			arguments = append(arguments, stats.toToolArgument(argName))
 		}
 
+		slices.SortFunc(arguments, func(a, b types.ToolArgument) int { return strings.Compare(a.Name, b.Name) })
+
 		tools = append(tools, types.McpTool{
 			Name:      toolName,
 			Calls:     data.calls,
@@ -70,3 +77,168 @@ func calculateToolAnalytics(logs []types.MCPServerLog) types.ToolAnalytics {
 		Tools: tools,
 	}
 }
+
+// isExcludedArgument checks whether the argument name matches one of the (case-insensitive) glob patterns
+func isExcludedArgument(name string, patterns []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if matched, err := path.Match(strings.ToLower(pattern), name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+type argumentStats struct {
+	usageCount int
+	typeCounts map[types.ArgumentType]int
+	values     map[string]int
+	// untrackedCount is the number of values that were not counted in values because maxTrackedArgumentValues
+	// was reached
+	untrackedCount int
+	numbers        []float64
+	trueCount      int
+	falseCount     int
+}
+
+func newArgumentStats() *argumentStats {
+	return &argumentStats{
+		typeCounts: make(map[types.ArgumentType]int),
+		values:     make(map[string]int),
+	}
+}
+
+func (s *argumentStats) add(value any) {
+	s.usageCount++
+
+	switch v := value.(type) {
+	case string:
+		s.typeCounts[types.ArgumentTypeString]++
+	case float64:
+		s.typeCounts[types.ArgumentTypeNumber]++
+		s.numbers = append(s.numbers, v)
+	case bool:
+		s.typeCounts[types.ArgumentTypeBoolean]++
+		if v {
+			s.trueCount++
+		} else {
+			s.falseCount++
+		}
+	case nil:
+		// null values don't contribute to the argument type
+	default:
+		s.typeCounts[types.ArgumentTypeObject]++
+	}
+
+	valueName := argumentValueName(value)
+	if _, exists := s.values[valueName]; exists || len(s.values) < maxTrackedArgumentValues {
+		s.values[valueName]++
+	} else {
+		s.untrackedCount++
+	}
+}
+
+func (s *argumentStats) argumentType() types.ArgumentType {
+	switch len(s.typeCounts) {
+	case 0:
+		return types.ArgumentTypeObject
+	case 1:
+		for t := range s.typeCounts {
+			return t
+		}
+	}
+	return types.ArgumentTypeMixed
+}
+
+func (s *argumentStats) toToolArgument(name string) types.ToolArgument {
+	values := make([]types.ArgumentValue, 0, len(s.values))
+	for valueName, count := range s.values {
+		values = append(values, types.ArgumentValue{Name: valueName, Count: count})
+	}
+
+	slices.SortFunc(values, func(a, b types.ArgumentValue) int {
+		return cmp.Or(b.Count-a.Count, strings.Compare(a.Name, b.Name))
+	})
+
+	highCardinality := len(values) > maxArgumentValues || s.untrackedCount > 0
+	if highCardinality {
+		otherCount := s.untrackedCount
+		if len(values) > maxArgumentValues {
+			for _, value := range values[maxArgumentValues:] {
+				otherCount += value.Count
+			}
+			values = values[:maxArgumentValues]
+		}
+		values = append(values, types.ArgumentValue{Name: "other", Count: otherCount, Other: true})
+	}
+
+	argument := types.ToolArgument{
+		Name:            name,
+		Type:            s.argumentType(),
+		UsageCount:      s.usageCount,
+		DistinctCount:   len(s.values),
+		HighCardinality: highCardinality,
+		Values:          values,
+		NumericStats:    calculateNumericStats(s.numbers),
+	}
+
+	if booleanCount := s.trueCount + s.falseCount; booleanCount > 0 {
+		argument.BooleanStats = &types.BooleanArgumentStats{
+			TrueCount:  s.trueCount,
+			FalseCount: s.falseCount,
+			TrueRatio:  float64(s.trueCount) / float64(booleanCount),
+		}
+	}
+
+	return argument
+}
+
+// calculateNumericStats computes min, max, average and an equal-width distribution of the given numbers
+func calculateNumericStats(numbers []float64) *types.NumericArgumentStats {
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	stats := types.NumericArgumentStats{
+		Min: slices.Min(numbers),
+		Max: slices.Max(numbers),
+	}
+
+	var sum float64
+	for _, n := range numbers {
+		sum += n
+	}
+	stats.Avg = sum / float64(len(numbers))
+
+	if stats.Min == stats.Max {
+		stats.Distribution = []types.NumericBucket{{From: stats.Min, To: stats.Max, Count: len(numbers)}}
+		return &stats
+	}
+
+	width := (stats.Max - stats.Min) / numericDistributionBuckets
+	stats.Distribution = make([]types.NumericBucket, numericDistributionBuckets)
+	for i := range stats.Distribution {
+		stats.Distribution[i].From = stats.Min + float64(i)*width
+		stats.Distribution[i].To = stats.Min + float64(i+1)*width
+	}
+	// avoid floating point errors for the upper bound of the last bucket
+	stats.Distribution[numericDistributionBuckets-1].To = stats.Max
+
+	for _, n := range numbers {
+		i := min(int((n-stats.Min)/width), numericDistributionBuckets-1)
+		stats.Distribution[i].Count++
+	}
+
+	return &stats
+}
+
+// argumentValueName returns the string representation of an argument value that is used for counting
+func argumentValueName(value any) string {
+	if strVal, ok := value.(string); ok {
+		return strVal
+	} else if data, err := json.Marshal(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	} else {
+		return string(data)
+	}
+}
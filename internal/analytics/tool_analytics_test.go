@@ -0,0 +1,123 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func toolCallLog(t *testing.T, name string, args map[string]any) types.MCPServerLog {
+	params, err := json.Marshal(map[string]any{"name": name, "arguments": args})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := json.RawMessage(params)
+	return types.MCPServerLog{MCPRequest: &jsonrpc2.Request{Method: "tools/call", Params: &raw}}
+}
+
+func findArgument(analytics types.ToolAnalytics, tool, argument string) *types.ToolArgument {
+	for _, mcpTool := range analytics.Tools {
+		if mcpTool.Name == tool {
+			for _, arg := range mcpTool.Arguments {
+				if arg.Name == argument {
+					return &arg
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func TestCalculateToolAnalyticsHighCardinality(t *testing.T) {
+	var logs []types.MCPServerLog
+	for i := range 50 {
+		logs = append(logs, toolCallLog(t, "search", map[string]any{"query": fmt.Sprintf("query %v", i), "lang": "en"}))
+	}
+	logs = append(logs, toolCallLog(t, "search", map[string]any{"query": "query 0", "lang": "de"}))
+
+	result := calculateToolAnalytics(logs, types.ProjectAnalyticsSettings{})
+
+	query := findArgument(result, "search", "query")
+	if query == nil {
+		t.Fatal("expected argument query")
+	}
+	if !query.HighCardinality {
+		t.Error("expected query to have high cardinality")
+	}
+	if query.DistinctCount != 50 {
+		t.Errorf("expected 50 distinct values, got %v", query.DistinctCount)
+	}
+	if len(query.Values) != maxArgumentValues+1 {
+		t.Fatalf("expected %v values, got %v", maxArgumentValues+1, len(query.Values))
+	}
+	if first := query.Values[0]; first.Name != "query 0" || first.Count != 2 {
+		t.Errorf("expected most used value to be \"query 0\" with count 2, got %v", first)
+	}
+	if other := query.Values[maxArgumentValues]; !other.Other || other.Count != 51-2-(maxArgumentValues-1) {
+		t.Errorf("unexpected other value %v", other)
+	}
+
+	lang := findArgument(result, "search", "lang")
+	if lang == nil {
+		t.Fatal("expected argument lang")
+	}
+	if lang.HighCardinality || len(lang.Values) != 2 || lang.Type != types.ArgumentTypeString {
+		t.Errorf("unexpected argument lang %v", lang)
+	}
+}
+
+func TestCalculateToolAnalyticsNumericAndBoolean(t *testing.T) {
+	var logs []types.MCPServerLog
+	for i := range 10 {
+		logs = append(logs, toolCallLog(t, "list", map[string]any{"limit": float64(i), "recursive": i%5 == 0}))
+	}
+
+	result := calculateToolAnalytics(logs, types.ProjectAnalyticsSettings{})
+
+	limit := findArgument(result, "list", "limit")
+	if limit == nil || limit.NumericStats == nil {
+		t.Fatal("expected numeric stats for argument limit")
+	}
+	if limit.Type != types.ArgumentTypeNumber {
+		t.Errorf("expected type number, got %v", limit.Type)
+	}
+	if s := limit.NumericStats; s.Min != 0 || s.Max != 9 || s.Avg != 4.5 {
+		t.Errorf("unexpected numeric stats %v", s)
+	}
+	total := 0
+	for _, bucket := range limit.NumericStats.Distribution {
+		total += bucket.Count
+	}
+	if len(limit.NumericStats.Distribution) != numericDistributionBuckets || total != 10 {
+		t.Errorf("unexpected distribution %v", limit.NumericStats.Distribution)
+	}
+
+	recursive := findArgument(result, "list", "recursive")
+	if recursive == nil || recursive.BooleanStats == nil {
+		t.Fatal("expected boolean stats for argument recursive")
+	}
+	if s := recursive.BooleanStats; s.TrueCount != 2 || s.FalseCount != 8 || s.TrueRatio != 0.2 {
+		t.Errorf("unexpected boolean stats %v", s)
+	}
+}
+
+func TestCalculateToolAnalyticsExcludedArguments(t *testing.T) {
+	logs := []types.MCPServerLog{
+		toolCallLog(t, "fetch", map[string]any{"url": "https://example.com", "apiToken": "secret", "password": "secret"}),
+	}
+
+	result := calculateToolAnalytics(logs, types.ProjectAnalyticsSettings{ExcludedArguments: []string{"*token*", "Password"}})
+
+	if findArgument(result, "fetch", "url") == nil {
+		t.Error("expected argument url")
+	}
+	if findArgument(result, "fetch", "apiToken") != nil {
+		t.Error("expected argument apiToken to be excluded")
+	}
+	if findArgument(result, "fetch", "password") != nil {
+		t.Error("expected argument password to be excluded")
+	}
+}
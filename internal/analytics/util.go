@@ -2,7 +2,6 @@ package analytics
 
 import (
 	"encoding/json"
-	"fmt"
 	"strings"
 	"time"
 
@@ -22,7 +21,7 @@ type toolPerformanceStats struct {
 
 type toolAnalyticsData struct {
 	calls     int
-	arguments map[string]map[string]int
+	arguments map[string]*argumentStats
 }
 
 type sessionInfo struct {
@@ -53,23 +52,13 @@ func extractToolName(mcpRequest *jsonrpc2.Request) string {
 	return mcpRequest.Method
 }
 
-// extractArguments extracts arguments from an MCP request
-func extractArguments(mcpRequest *jsonrpc2.Request) map[string]string {
+// extractArguments extracts the raw (JSON decoded) arguments from an MCP request
+func extractArguments(mcpRequest *jsonrpc2.Request) map[string]any {
 	if mcpRequest != nil && mcpRequest.Method == "tools/call" && mcpRequest.Params != nil {
 		var params mcp.CallToolParams
 		if err := json.Unmarshal(*mcpRequest.Params, &params); err == nil {
 			if args, ok := params.Arguments.(map[string]any); ok {
-				strArgs := make(map[string]string, len(args))
-				for key, val := range args {
-					if strVal, ok := val.(string); ok {
-						strArgs[key] = strVal
-					} else if data, err := json.Marshal(val); err != nil {
-						strArgs[key] = fmt.Sprintf("%v", val)
-					} else {
-						strArgs[key] = string(data)
-					}
-				}
-				return strArgs
+				return args
 			}
 		}
 	}
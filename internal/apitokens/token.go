@@ -0,0 +1,31 @@
+package apitokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// Prefix is prepended to all API tokens, so that they can be told apart from JWTs and found by secret scanners
+const Prefix = "jsk_"
+
+// NewToken returns a new random plaintext token and its hash. Only the hash must be stored.
+func NewToken() (string, []byte) {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	token := Prefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, Hash(token)
+}
+
+// IsToken returns true if the bearer token looks like an API token rather than a JWT
+func IsToken(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// Hash returns the hash of the plaintext token that is used to look the token up. API tokens contain 256 bits of
+// randomness, so a fast unsalted hash is sufficient.
+func Hash(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
@@ -0,0 +1,22 @@
+package apitokens
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewToken(t *testing.T) {
+	token, hash := NewToken()
+	if !IsToken(token) {
+		t.Errorf("expected token to have prefix %v, got %v", Prefix, token)
+	}
+	if !bytes.Equal(hash, Hash(token)) {
+		t.Error("expected hash to match the hash of the token")
+	}
+	if other, _ := NewToken(); other == token {
+		t.Error("expected tokens to be unique")
+	}
+	if IsToken("eyJhbGciOiJSUzI1NiJ9.e30.c2ln") {
+		t.Error("expected JWT not to be detected as API token")
+	}
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// Event describes a mutation of a resource. Before and After are the states of the target before and after the
+// mutation. Before is nil for creations, After is nil for deletions.
+type Event struct {
+	OrganizationID *uuid.UUID
+	Action         types.AuditAction
+	TargetType     types.AuditTargetType
+	TargetID       uuid.UUID
+	Before         any
+	After          any
+}
+
+// Record appends the event to the audit log. The actor, API token, request ID and IP address are taken from the
+// request context. Record should be called in the same transaction as the mutation, so that no mutation is committed
+// without an audit log entry.
+func Record(ctx context.Context, event Event) error {
+	changes, err := Diff(event.Before, event.After)
+	if err != nil {
+		return err
+	}
+
+	user := internalctx.GetUser(ctx)
+	entry := types.AuditLogEntry{
+		OrganizationID: event.OrganizationID,
+		ActorID:        &user.ID,
+		ActorEmail:     user.Email,
+		Action:         event.Action,
+		TargetType:     event.TargetType,
+		TargetID:       event.TargetID,
+		Changes:        changes,
+		RequestID:      middleware.GetReqID(ctx),
+		IPAddress:      internalctx.GetRequestIPAddress(ctx),
+	}
+	if token := internalctx.GetAPIToken(ctx); token != nil {
+		entry.APITokenID = &token.ID
+	}
+	return db.CreateAuditLogEntry(ctx, &entry)
+}
+
+// RecordForProject appends the event to the audit log of the organization the project belongs to
+func RecordForProject(ctx context.Context, projectID uuid.UUID, event Event) error {
+	if orgID, err := db.GetOrganizationIDOfProject(ctx, projectID); err != nil {
+		return err
+	} else {
+		event.OrganizationID = &orgID
+		return Record(ctx, event)
+	}
+}
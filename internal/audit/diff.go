@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// Diff returns the changes between the JSON representations of before and after. Nested objects are flattened into
+// dotted paths, arrays are compared as a whole. Either side may be nil to record a creation or a deletion.
+func Diff(before, after any) (map[string]types.AuditChange, error) {
+	beforeFields, err := flatten(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := flatten(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]types.AuditChange)
+	for path, value := range beforeFields {
+		if other, ok := afterFields[path]; !ok || !reflect.DeepEqual(value, other) {
+			changes[path] = types.AuditChange{Before: value, After: other}
+		}
+	}
+	for path, value := range afterFields {
+		if _, ok := beforeFields[path]; !ok {
+			changes[path] = types.AuditChange{After: value}
+		}
+	}
+	return changes, nil
+}
+
+func flatten(value any) (map[string]any, error) {
+	result := make(map[string]any)
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Pointer && reflect.ValueOf(value).IsNil()) {
+		return result, nil
+	}
+
+	var decoded any
+	if data, err := json.Marshal(value); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	var walk func(prefix string, value any)
+	walk = func(prefix string, value any) {
+		if obj, ok := value.(map[string]any); ok && (len(obj) > 0 || prefix == "") {
+			for key, child := range obj {
+				if prefix != "" {
+					key = prefix + "." + key
+				}
+				walk(key, child)
+			}
+		} else {
+			result[prefix] = value
+		}
+	}
+	walk("", decoded)
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestDiff(t *testing.T) {
+	type settings struct {
+		Domain  *string  `json:"domain"`
+		Exclude []string `json:"exclude"`
+	}
+	type resource struct {
+		Name     string   `json:"name"`
+		Settings settings `json:"settings"`
+	}
+
+	domain := "example.com"
+	before := resource{Name: "a", Settings: settings{Exclude: []string{"x"}}}
+	after := resource{Name: "a", Settings: settings{Domain: &domain, Exclude: []string{"x", "y"}}}
+
+	expectDiff := func(before, after any, expected map[string]types.AuditChange) {
+		if actual, err := Diff(before, after); err != nil {
+			t.Errorf("expected nil but found error: %v", err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %v but found %v", expected, actual)
+		}
+	}
+
+	expectDiff(before, before, map[string]types.AuditChange{})
+	expectDiff(before, after, map[string]types.AuditChange{
+		"settings.domain":  {Before: nil, After: "example.com"},
+		"settings.exclude": {Before: []any{"x"}, After: []any{"x", "y"}},
+	})
+	expectDiff(nil, &before, map[string]types.AuditChange{
+		"name":             {After: "a"},
+		"settings.domain":  {After: nil},
+		"settings.exclude": {After: []any{"x"}},
+	})
+	expectDiff(&before, (*resource)(nil), map[string]types.AuditChange{
+		"name":             {Before: "a"},
+		"settings.domain":  {Before: nil},
+		"settings.exclude": {Before: []any{"x"}},
+	})
+}
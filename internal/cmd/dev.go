@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/oidc"
+	"github.com/spf13/cobra"
+)
+
+type devTokenOptions struct {
+	Email string
+	TTL   time.Duration
+}
+
+// NewDevCommand contains helpers for local development. It is only registered in development builds.
+func NewDevCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "helpers for local development",
+	}
+
+	cmd.AddCommand(newDevTokenCommand())
+
+	return cmd
+}
+
+func newDevTokenCommand() *cobra.Command {
+	opts := devTokenOptions{TTL: 24 * time.Hour}
+
+	cmd := &cobra.Command{
+		Use:    "token",
+		Short:  "create an access token for the offline dev auth mode",
+		Args:   cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) { env.Initialize() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDevToken(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Email, "email", opts.Email, "email address of the user")
+	cmd.Flags().DurationVar(&opts.TTL, "ttl", opts.TTL, "validity of the token")
+	_ = cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
+func runDevToken(cmd *cobra.Command, opts devTokenOptions) error {
+	secret := env.DevAuthSecret()
+	if secret == nil {
+		return errors.New("dev auth mode is disabled, set DEV_AUTH_SECRET to enable it")
+	}
+	audiences := env.OIDCAllowedAudiences()
+	if len(audiences) == 0 {
+		return errors.New("no allowed audience configured")
+	}
+	token, err := oidc.NewDevToken(secret, opts.Email, audiences[0], time.Now(), opts.TTL)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), token)
+	return err
+}
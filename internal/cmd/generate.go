@@ -155,7 +155,7 @@ func runGenerate(ctx context.Context, opts generateOptions) {
 				if err != nil {
 					return fmt.Errorf("failed to create org: %w", err)
 				}
-				if err := db.AddUserToOrganization(ctx, user.ID, org.ID); err != nil {
+				if err := db.AddUserToOrganization(ctx, user.ID, org.ID, types.OrganizationRoleOwner); err != nil {
 					return fmt.Errorf("failed to add user to org: %w", err)
 				}
 				fmt.Printf("Created organization: %s\n", org.Name)
@@ -208,7 +208,7 @@ func runGenerate(ctx context.Context, opts generateOptions) {
 							return fmt.Errorf("failed to parse duration: %w", err)
 						}
 						ts := time.Now().UTC().Add(ago * -1)
-						err = db.AddDeploymentRevisionEvent(ctx, dr.ID, types.DeploymentRevisionEventType(eventData.Type), &ts)
+						_, err = db.AddDeploymentRevisionEvent(ctx, dr.ID, types.DeploymentRevisionEventType(eventData.Type), &ts)
 						if err != nil {
 							return fmt.Errorf("failed to add deployment revision event: %w", err)
 						}
@@ -17,5 +17,9 @@ func NewRoot() *cobra.Command {
 		NewGenerateCommand(),
 	)
 
+	if buildconfig.IsDevelopment() {
+		cmd.AddCommand(NewDevCommand())
+	}
+
 	return cmd
 }
@@ -80,6 +80,13 @@ func runServe(ctx context.Context, opts serveOptions) {
 
 	go func() { util.Must(server.Start(":8080")) }()
 	go func() { util.Must(webhookServer.Start(":8085")) }()
+	go registry.GetAlertEvaluator().Run(sigCtx)
+	go registry.GetNotificationDispatcher().Run(sigCtx)
+	go registry.GetDigestSender().Run(sigCtx)
+	go registry.GetDomainVerifier().Run(sigCtx)
+	go registry.GetGatewayReconciler().Run(sigCtx)
+	go registry.GetMailDispatcher().Run(sigCtx)
+	go registry.GetPromptReindexer().Run(sigCtx)
 	server.WaitForShutdown()
 	webhookServer.WaitForShutdown()
 }
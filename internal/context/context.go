@@ -20,6 +20,7 @@ const (
 	ctxKeyAccessToken
 	ctxKeyUser
 	ctxKeyMailer
+	ctxKeyAPIToken
 )
 
 func GetDb(ctx context.Context) queryable.Queryable {
@@ -76,6 +77,19 @@ func WithAccessToken(ctx context.Context, token jwt.Token) context.Context {
 	return context.WithValue(ctx, ctxKeyAccessToken, token)
 }
 
+// GetAPIToken returns the API token that has been used to authenticate the request or nil if the request has been
+// authenticated with an access token of the identity provider
+func GetAPIToken(ctx context.Context) *types.APIToken {
+	if val, ok := ctx.Value(ctxKeyAPIToken).(*types.APIToken); ok {
+		return val
+	}
+	return nil
+}
+
+func WithAPIToken(ctx context.Context, token *types.APIToken) context.Context {
+	return context.WithValue(ctx, ctxKeyAPIToken, token)
+}
+
 func GetUser(ctx context.Context) *types.UserAccount {
 	if val, ok := ctx.Value(ctxKeyUser).(*types.UserAccount); ok {
 		if val != nil {
@@ -0,0 +1,268 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	alertRuleOutExpr = ` ar.id, ar.created_at, ar.created_by, ar.project_id, ar.name, ar.metric, ar.threshold,
+		ar.evaluation_window, ar.min_request_count, ar.enabled, ar.last_evaluated_at `
+	alertIncidentOutExpr = ` ai.id, ai.created_at, ai.alert_rule_id, ai.project_id, ai.status, ai.rule_name, ai.metric,
+		ai.threshold, ai.value, ai.message, ai.resolved_at `
+)
+
+type AlertIncidentFilter struct {
+	AlertRuleID *uuid.UUID
+	Status      *types.AlertIncidentStatus
+}
+
+func GetAlertRulesForProject(ctx context.Context, projectID uuid.UUID) ([]types.AlertRule, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+alertRuleOutExpr+` FROM AlertRule ar WHERE ar.project_id = @projectId ORDER BY ar.created_at`,
+		pgx.NamedArgs{"projectId": projectID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.AlertRule])
+}
+
+func GetAlertRule(ctx context.Context, id uuid.UUID) (*types.AlertRule, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT `+alertRuleOutExpr+` FROM AlertRule ar WHERE ar.id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.AlertRule])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func CreateAlertRule(ctx context.Context, rule *types.AlertRule) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO AlertRule AS ar
+			(created_by, project_id, name, metric, threshold, evaluation_window, min_request_count, enabled)
+		VALUES (@createdBy, @projectId, @name, @metric, @threshold, @evaluationWindow, @minRequestCount, @enabled)
+		RETURNING `+alertRuleOutExpr,
+		pgx.NamedArgs{
+			"createdBy":        rule.CreatedBy,
+			"projectId":        rule.ProjectID,
+			"name":             rule.Name,
+			"metric":           rule.Metric,
+			"threshold":        rule.Threshold,
+			"evaluationWindow": rule.EvaluationWindow,
+			"minRequestCount":  rule.MinRequestCount,
+			"enabled":          rule.Enabled,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query AlertRule: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.AlertRule]); err != nil {
+		return fmt.Errorf("failed to scan AlertRule: %w", err)
+	} else {
+		*rule = result
+		return nil
+	}
+}
+
+func UpdateAlertRule(ctx context.Context, rule *types.AlertRule) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE AlertRule AS ar
+			SET name = @name, metric = @metric, threshold = @threshold, evaluation_window = @evaluationWindow,
+				min_request_count = @minRequestCount, enabled = @enabled
+		WHERE id = @id
+		RETURNING `+alertRuleOutExpr,
+		pgx.NamedArgs{
+			"id":               rule.ID,
+			"name":             rule.Name,
+			"metric":           rule.Metric,
+			"threshold":        rule.Threshold,
+			"evaluationWindow": rule.EvaluationWindow,
+			"minRequestCount":  rule.MinRequestCount,
+			"enabled":          rule.Enabled,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query AlertRule: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.AlertRule]); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = apierrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to scan AlertRule: %w", err)
+	} else {
+		*rule = result
+		return nil
+	}
+}
+
+func DeleteAlertRule(ctx context.Context, id uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	if res, err := db.Exec(ctx, `DELETE FROM AlertRule WHERE id = @id`, pgx.NamedArgs{"id": id}); err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	} else {
+		return nil
+	}
+}
+
+// LockNextAlertRuleDueForEvaluation returns an enabled rule that was not evaluated since evaluatedBefore and locks it
+// until the end of the current transaction. Rules that are locked by another transaction are skipped, so that
+// multiple instances can evaluate rules concurrently.
+func LockNextAlertRuleDueForEvaluation(ctx context.Context, evaluatedBefore time.Time) (*types.AlertRule, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+alertRuleOutExpr+`
+		FROM AlertRule ar
+		WHERE ar.enabled AND (ar.last_evaluated_at IS NULL OR ar.last_evaluated_at < @evaluatedBefore)
+		ORDER BY ar.last_evaluated_at NULLS FIRST
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		pgx.NamedArgs{"evaluatedBefore": evaluatedBefore.UTC()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.AlertRule])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func SetAlertRuleEvaluated(ctx context.Context, id uuid.UUID, evaluatedAt time.Time) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`UPDATE AlertRule SET last_evaluated_at = @evaluatedAt WHERE id = @id`,
+		pgx.NamedArgs{"id": id, "evaluatedAt": evaluatedAt.UTC()},
+	)
+	return err
+}
+
+func GetAlertIncidentsForProject(
+	ctx context.Context,
+	projectID uuid.UUID,
+	pagination lists.Pagination,
+	filter AlertIncidentFilter,
+) ([]types.AlertIncident, error) {
+	db := internalctx.GetDb(ctx)
+	filters := []string{"ai.project_id = @projectId"}
+	if filter.AlertRuleID != nil {
+		filters = append(filters, "ai.alert_rule_id = @alertRuleId")
+	}
+	if filter.Status != nil {
+		filters = append(filters, "ai.status = @status")
+	}
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+alertIncidentOutExpr+`
+		FROM AlertIncident ai
+		WHERE `+strings.Join(filters, " AND ")+`
+		ORDER BY ai.created_at DESC
+		LIMIT @count OFFSET @offset`,
+		pgx.NamedArgs{
+			"projectId":   projectID,
+			"alertRuleId": filter.AlertRuleID,
+			"status":      filter.Status,
+			"count":       pagination.Count,
+			"offset":      pagination.Count * pagination.Page,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.AlertIncident])
+}
+
+func GetFiringAlertIncident(ctx context.Context, alertRuleID uuid.UUID) (*types.AlertIncident, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+alertIncidentOutExpr+` FROM AlertIncident ai WHERE ai.alert_rule_id = @alertRuleId AND ai.status = 'firing'`,
+		pgx.NamedArgs{"alertRuleId": alertRuleID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.AlertIncident])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func CreateAlertIncident(ctx context.Context, incident *types.AlertIncident) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO AlertIncident AS ai
+			(created_at, alert_rule_id, project_id, rule_name, metric, threshold, value, message)
+		VALUES (@createdAt, @alertRuleId, @projectId, @ruleName, @metric, @threshold, @value, @message)
+		RETURNING `+alertIncidentOutExpr,
+		pgx.NamedArgs{
+			"createdAt":   incident.CreatedAt.UTC(),
+			"alertRuleId": incident.AlertRuleID,
+			"projectId":   incident.ProjectID,
+			"ruleName":    incident.RuleName,
+			"metric":      incident.Metric,
+			"threshold":   incident.Threshold,
+			"value":       incident.Value,
+			"message":     incident.Message,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query AlertIncident: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.AlertIncident]); err != nil {
+		return fmt.Errorf("failed to scan AlertIncident: %w", err)
+	} else {
+		*incident = result
+		return nil
+	}
+}
+
+func ResolveAlertIncident(ctx context.Context, incident *types.AlertIncident, resolvedAt time.Time) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE AlertIncident AS ai SET status = 'resolved', resolved_at = @resolvedAt
+		WHERE id = @id
+		RETURNING `+alertIncidentOutExpr,
+		pgx.NamedArgs{"id": incident.ID, "resolvedAt": resolvedAt.UTC()},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query AlertIncident: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.AlertIncident]); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = apierrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to scan AlertIncident: %w", err)
+	} else {
+		*incident = result
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const apiTokenOutExpr = ` t.id, t.created_at, t.created_by, t.user_account_id, t.name, t.scopes, t.expires_at,
+	t.last_used_at, t.revoked_at `
+
+// GetAPITokensForUser returns all tokens of the user account that have not been revoked, including expired ones
+func GetAPITokensForUser(ctx context.Context, userID uuid.UUID) ([]types.APIToken, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+apiTokenOutExpr+`
+		FROM ApiToken t
+		WHERE t.user_account_id = @userId AND t.revoked_at IS NULL
+		ORDER BY t.created_at`,
+		pgx.NamedArgs{"userId": userID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.APIToken])
+}
+
+func CreateAPIToken(ctx context.Context, token *types.APIToken, tokenHash []byte) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO ApiToken AS t (created_by, user_account_id, name, token_hash, scopes, expires_at)
+		VALUES (@createdBy, @userAccountId, @name, @tokenHash, @scopes, @expiresAt)
+		RETURNING `+apiTokenOutExpr,
+		pgx.NamedArgs{
+			"createdBy":     token.CreatedBy,
+			"userAccountId": token.UserAccountID,
+			"name":          token.Name,
+			"tokenHash":     tokenHash,
+			"scopes":        token.Scopes,
+			"expiresAt":     token.ExpiresAt.UTC(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.APIToken]); err != nil {
+		return err
+	} else {
+		*token = result
+		return nil
+	}
+}
+
+// RevokeAPIToken revokes a token of the user account and returns it. It returns apierrors.ErrNotFound if the user
+// account has no such token or the token has already been revoked.
+func RevokeAPIToken(ctx context.Context, userID, tokenID uuid.UUID, revokedAt time.Time) (*types.APIToken, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE ApiToken AS t SET revoked_at = @revokedAt
+		WHERE t.id = @id AND t.user_account_id = @userId AND t.revoked_at IS NULL
+		RETURNING `+apiTokenOutExpr,
+		pgx.NamedArgs{"id": tokenID, "userId": userID, "revokedAt": revokedAt.UTC()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.APIToken])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// UseAPIToken returns the valid token with the given hash and records that it has been used. It returns
+// apierrors.ErrNotFound if there is no such token or if it has been revoked or is expired.
+func UseAPIToken(ctx context.Context, tokenHash []byte, now time.Time) (*types.APIToken, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE ApiToken AS t SET last_used_at = @now
+		WHERE t.token_hash = @tokenHash AND t.revoked_at IS NULL AND t.expires_at > @now
+		RETURNING `+apiTokenOutExpr,
+		pgx.NamedArgs{"tokenHash": tokenHash, "now": now.UTC()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.APIToken])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
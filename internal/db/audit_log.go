@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const auditLogEntryOutExpr = ` a.id, a.created_at, a.organization_id, a.actor_id, a.actor_email, a.api_token_id,
+	a.action, a.target_type, a.target_id, a.changes, a.request_id, a.ip_address `
+
+type AuditLogFilter struct {
+	Action     *types.AuditAction
+	TargetType *types.AuditTargetType
+	TargetID   *uuid.UUID
+	ActorID    *uuid.UUID
+	After      *time.Time
+	Before     *time.Time
+	// Cursor restricts the result to the entries that come after the cursor entry in the sort order. It is used to
+	// page through large results with keyset pagination.
+	Cursor *AuditLogCursor
+}
+
+// AuditLogCursor identifies the position of an entry in the audit log
+type AuditLogCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func CreateAuditLogEntry(ctx context.Context, entry *types.AuditLogEntry) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO AuditLogEntry AS a (organization_id, actor_id, actor_email, api_token_id, action, target_type,
+			target_id, changes, request_id, ip_address)
+		VALUES (@organizationId, @actorId, @actorEmail, @apiTokenId, @action, @targetType, @targetId, @changes,
+			@requestId, @ipAddress)
+		RETURNING `+auditLogEntryOutExpr,
+		pgx.NamedArgs{
+			"organizationId": entry.OrganizationID,
+			"actorId":        entry.ActorID,
+			"actorEmail":     entry.ActorEmail,
+			"apiTokenId":     entry.APITokenID,
+			"action":         entry.Action,
+			"targetType":     entry.TargetType,
+			"targetId":       entry.TargetID,
+			"changes":        entry.Changes,
+			"requestId":      entry.RequestID,
+			"ipAddress":      entry.IPAddress,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.AuditLogEntry]); err != nil {
+		return err
+	} else {
+		*entry = result
+		return nil
+	}
+}
+
+// GetAuditLogForOrganization returns the audit log entries of the organization, newest first
+func GetAuditLogForOrganization(
+	ctx context.Context,
+	orgID uuid.UUID,
+	pagination lists.Pagination,
+	filter AuditLogFilter,
+) ([]types.AuditLogEntry, error) {
+	db := internalctx.GetDb(ctx)
+	filters := []string{"a.organization_id = @orgId"}
+	if filter.Action != nil {
+		filters = append(filters, "a.action = @action")
+	}
+	if filter.TargetType != nil {
+		filters = append(filters, "a.target_type = @targetType")
+	}
+	if filter.TargetID != nil {
+		filters = append(filters, "a.target_id = @targetId")
+	}
+	if filter.ActorID != nil {
+		filters = append(filters, "a.actor_id = @actorId")
+	}
+	if filter.After != nil {
+		filters = append(filters, "a.created_at >= @after")
+	}
+	if filter.Before != nil {
+		filters = append(filters, "a.created_at < @before")
+	}
+	var cursorCreatedAt *time.Time
+	var cursorID *uuid.UUID
+	if filter.Cursor != nil {
+		filters = append(filters, "(a.created_at, a.id) < (@cursorCreatedAt, @cursorId)")
+		cursorCreatedAt = &filter.Cursor.CreatedAt
+		cursorID = &filter.Cursor.ID
+	}
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+auditLogEntryOutExpr+`
+		FROM AuditLogEntry a
+		WHERE `+strings.Join(filters, " AND ")+`
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT @count OFFSET @offset`,
+		pgx.NamedArgs{
+			"orgId":           orgID,
+			"action":          filter.Action,
+			"targetType":      filter.TargetType,
+			"targetId":        filter.TargetID,
+			"actorId":         filter.ActorID,
+			"after":           filter.After,
+			"before":          filter.Before,
+			"cursorCreatedAt": cursorCreatedAt,
+			"cursorId":        cursorID,
+			"count":           pagination.Count,
+			"offset":          pagination.Count * pagination.Page,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.AuditLogEntry])
+}
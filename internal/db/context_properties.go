@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	contextPropertyOutExpr = ` cp.id, cp.created_at, cp.project_id, cp.type, cp.name, cp.required `
+	contextOutExpr         = ` c.id, c.created_at, c.auth_token_digest, c.user_account_id, c.context_property_id,
+		c.context_property_value `
+)
+
+func GetContextPropertiesForProject(ctx context.Context, projectID uuid.UUID) ([]types.ContextProperty, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+contextPropertyOutExpr+` FROM ContextProperty cp WHERE cp.project_id = @projectId ORDER BY cp.name`,
+		pgx.NamedArgs{"projectId": projectID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.ContextProperty])
+}
+
+func GetContextProperty(ctx context.Context, id uuid.UUID) (*types.ContextProperty, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+contextPropertyOutExpr+` FROM ContextProperty cp WHERE cp.id = @id`,
+		pgx.NamedArgs{"id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.ContextProperty])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func CreateContextProperty(ctx context.Context, property *types.ContextProperty) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO ContextProperty AS cp (project_id, type, name, required)
+		VALUES (@projectId, @type, @name, @required)
+		RETURNING `+contextPropertyOutExpr,
+		pgx.NamedArgs{
+			"projectId": property.ProjectID,
+			"type":      property.Type,
+			"name":      property.Name,
+			"required":  property.Required,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query ContextProperty: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.ContextProperty]); err != nil {
+		if pgerr := (*pgconn.PgError)(nil); errors.As(err, &pgerr) && pgerr.Code == pgerrcode.UniqueViolation {
+			return apierrors.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to scan ContextProperty: %w", err)
+	} else {
+		*property = result
+		return nil
+	}
+}
+
+func UpdateContextProperty(ctx context.Context, property *types.ContextProperty) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE ContextProperty AS cp
+			SET type = @type, name = @name, required = @required
+		WHERE id = @id
+		RETURNING `+contextPropertyOutExpr,
+		pgx.NamedArgs{
+			"id":       property.ID,
+			"type":     property.Type,
+			"name":     property.Name,
+			"required": property.Required,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query ContextProperty: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.ContextProperty]); err != nil {
+		if pgerr := (*pgconn.PgError)(nil); errors.As(err, &pgerr) && pgerr.Code == pgerrcode.UniqueViolation {
+			return apierrors.ErrAlreadyExists
+		} else if errors.Is(err, pgx.ErrNoRows) {
+			err = apierrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to scan ContextProperty: %w", err)
+	} else {
+		*property = result
+		return nil
+	}
+}
+
+// DeleteContextProperty deletes the property together with all values that have been set for it
+func DeleteContextProperty(ctx context.Context, id uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	if res, err := db.Exec(ctx, `DELETE FROM ContextProperty WHERE id = @id`, pgx.NamedArgs{"id": id}); err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	} else {
+		return nil
+	}
+}
+
+// DeleteContextValuesForProperty deletes the values of all users for the property. This is needed when the type of
+// the property changes, because existing values might not be valid anymore.
+func DeleteContextValuesForProperty(ctx context.Context, propertyID uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`DELETE FROM Context WHERE context_property_id = @propertyId`,
+		pgx.NamedArgs{"propertyId": propertyID},
+	)
+	return err
+}
+
+// GetContextValues returns the values the user has set for properties of the project. If authTokenDigest is not nil,
+// only values for this auth token are returned.
+func GetContextValues(
+	ctx context.Context,
+	projectID, userID uuid.UUID,
+	authTokenDigest *string,
+) ([]types.Context, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+contextOutExpr+`
+		FROM Context c
+		INNER JOIN ContextProperty cp ON cp.id = c.context_property_id
+		WHERE cp.project_id = @projectId
+			AND c.user_account_id = @userId
+			AND (@authTokenDigest::TEXT IS NULL OR c.auth_token_digest = @authTokenDigest)
+		ORDER BY c.auth_token_digest, cp.name`,
+		pgx.NamedArgs{"projectId": projectID, "userId": userID, "authTokenDigest": authTokenDigest},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.Context])
+}
+
+// SetContextValues replaces all values the user has set for properties of the project with the given auth token.
+// This function must be called inside a transaction.
+func SetContextValues(
+	ctx context.Context,
+	projectID, userID uuid.UUID,
+	authTokenDigest string,
+	values []types.Context,
+) ([]types.Context, error) {
+	if err := DeleteContextValues(ctx, projectID, userID, authTokenDigest); err != nil &&
+		!errors.Is(err, apierrors.ErrNotFound) {
+		return nil, err
+	}
+
+	db := internalctx.GetDb(ctx)
+	result := make([]types.Context, 0, len(values))
+	for _, value := range values {
+		// the JSON codec of pgx would write strings verbatim, so values must be encoded beforehand
+		encoded, err := json.Marshal(value.ContextPropertyValue)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := db.Query(
+			ctx,
+			`INSERT INTO Context AS c (auth_token_digest, user_account_id, context_property_id, context_property_value)
+			VALUES (@authTokenDigest, @userId, @propertyId, @value)
+			RETURNING `+contextOutExpr,
+			pgx.NamedArgs{
+				"authTokenDigest": authTokenDigest,
+				"userId":          userID,
+				"propertyId":      value.ContextPropertyID,
+				"value":           json.RawMessage(encoded),
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query Context: %w", err)
+		}
+		if created, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.Context]); err != nil {
+			return nil, fmt.Errorf("failed to scan Context: %w", err)
+		} else {
+			result = append(result, created)
+		}
+	}
+	return result, nil
+}
+
+// DeleteContextValues deletes all values the user has set for properties of the project with the given auth token
+func DeleteContextValues(ctx context.Context, projectID, userID uuid.UUID, authTokenDigest string) error {
+	db := internalctx.GetDb(ctx)
+	if res, err := db.Exec(
+		ctx,
+		`DELETE FROM Context c
+		USING ContextProperty cp
+		WHERE cp.id = c.context_property_id
+			AND cp.project_id = @projectId
+			AND c.user_account_id = @userId
+			AND c.auth_token_digest = @authTokenDigest`,
+		pgx.NamedArgs{"projectId": projectID, "userId": userID, "authTokenDigest": authTokenDigest},
+	); err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	} else {
+		return nil
+	}
+}
@@ -2,9 +2,11 @@ package db
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
 	internalctx "github.com/hyprmcp/jetski/internal/context"
 	"github.com/hyprmcp/jetski/internal/types"
 	"github.com/jackc/pgx/v5"
@@ -89,28 +91,76 @@ func CreateDeploymentRevision(ctx context.Context, dr *types.DeploymentRevision)
 	return err
 }
 
-func AddDeploymentRevisionEvent(ctx context.Context, deploymentRevisionID uuid.UUID, eventType types.DeploymentRevisionEventType, timestamp *time.Time) error {
+// LockLatestDeploymentRevision returns the latest deployment revision of the project and locks the project until the
+// end of the transaction, so that no other revision can be created concurrently. It returns apierrors.ErrNotFound if
+// the project has no deployment revision yet.
+func LockLatestDeploymentRevision(ctx context.Context, projectID uuid.UUID) (*types.DeploymentRevision, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT`+deploymentRevisionWithoutBuildNrOutExpr+`
+		FROM Project p
+		JOIN DeploymentRevision dr ON dr.id = p.latest_deployment_revision_id
+		WHERE p.id = @projectID
+		FOR UPDATE OF p`,
+		pgx.NamedArgs{"projectID": projectID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByNameLax[types.DeploymentRevision])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func AddDeploymentRevisionEvent(ctx context.Context, deploymentRevisionID uuid.UUID, eventType types.DeploymentRevisionEventType, timestamp *time.Time) (*types.DeploymentRevisionEvent, error) {
 	db := internalctx.GetDb(ctx)
 	createdAt := time.Now().UTC()
 	if timestamp != nil {
 		createdAt = *timestamp
 	}
-	var eventID uuid.UUID
-	err := db.QueryRow(ctx, `
-		INSERT INTO DeploymentRevisionEvent (deployment_revision_id, type, created_at)
+	rows, err := db.Query(ctx, `
+		INSERT INTO DeploymentRevisionEvent AS dre (deployment_revision_id, type, created_at)
 		VALUES (@drid, @type, @createdAt)
-		RETURNING id
-	`, pgx.NamedArgs{"drid": deploymentRevisionID, "type": eventType, "createdAt": createdAt}).Scan(&eventID)
+		RETURNING `+deploymentRevisionEventOutExpr,
+		pgx.NamedArgs{"drid": deploymentRevisionID, "type": eventType, "createdAt": createdAt})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	event, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.DeploymentRevisionEvent])
+	if err != nil {
+		return nil, err
 	}
 	_, err = db.Exec(ctx, `
 		UPDATE Project SET latest_deployment_revision_event_id = @eventID
 		WHERE id = (
 			SELECT project_id FROM DeploymentRevision WHERE id = @drid
 		)
-	`, pgx.NamedArgs{"eventID": eventID, "drid": deploymentRevisionID})
-	return err
+	`, pgx.NamedArgs{"eventID": event.ID, "drid": deploymentRevisionID})
+	return event, err
+}
+
+// GetLatestDeploymentRevisionEvent returns the most recent event of the deployment revision or
+// apierrors.ErrNotFound if there are no events
+func GetLatestDeploymentRevisionEvent(ctx context.Context, deploymentRevisionID uuid.UUID) (*types.DeploymentRevisionEvent, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `
+		SELECT `+deploymentRevisionEventOutExpr+`
+		FROM DeploymentRevisionEvent dre
+		WHERE dre.deployment_revision_id = @drid
+		ORDER BY dre.created_at DESC
+		LIMIT 1
+	`, pgx.NamedArgs{"drid": deploymentRevisionID})
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.DeploymentRevisionEvent])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
 }
 
 func GetDeploymentRevisionsForProject(ctx context.Context, projectID uuid.UUID) ([]types.DeploymentRevisionSummary, error) {
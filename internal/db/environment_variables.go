@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/secrets"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const environmentVariableOutExpr = ` ev.id, ev.created_at, ev.deployment_revision_id, ev.name, ev.secret, ev.value_encrypted `
+
+type environmentVariableRow struct {
+	types.EnvironmentVariable
+	ValueEncrypted []byte `db:"value_encrypted"`
+}
+
+// GetEnvironmentVariables returns the variables of the deployment revision with decrypted values
+func GetEnvironmentVariables(ctx context.Context, deploymentRevisionID uuid.UUID) ([]types.EnvironmentVariable, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+environmentVariableOutExpr+`
+		FROM EnvironmentVariable ev
+		WHERE ev.deployment_revision_id = @deploymentRevisionId
+		ORDER BY ev.name`,
+		pgx.NamedArgs{"deploymentRevisionId": deploymentRevisionID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[environmentVariableRow])
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make([]types.EnvironmentVariable, len(result))
+	for i, row := range result {
+		if value, err := secrets.Decrypt(row.ValueEncrypted); err != nil {
+			return nil, fmt.Errorf("failed to decrypt EnvironmentVariable %v: %w", row.ID, err)
+		} else {
+			variables[i] = row.EnvironmentVariable
+			variables[i].Value = &value
+		}
+	}
+	return variables, nil
+}
+
+// CreateEnvironmentVariables encrypts and stores the variables for the deployment revision. Value must be set for all
+// variables.
+func CreateEnvironmentVariables(
+	ctx context.Context,
+	deploymentRevisionID uuid.UUID,
+	variables []types.EnvironmentVariable,
+) ([]types.EnvironmentVariable, error) {
+	db := internalctx.GetDb(ctx)
+	result := make([]types.EnvironmentVariable, len(variables))
+	for i, variable := range variables {
+		if variable.Value == nil {
+			return nil, fmt.Errorf("EnvironmentVariable %v has no value", variable.Name)
+		}
+		valueEncrypted, err := secrets.Encrypt(*variable.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt EnvironmentVariable %v: %w", variable.Name, err)
+		}
+		rows, err := db.Query(
+			ctx,
+			`INSERT INTO EnvironmentVariable AS ev (deployment_revision_id, name, secret, value_encrypted)
+			VALUES (@deploymentRevisionId, @name, @secret, @valueEncrypted)
+			RETURNING `+environmentVariableOutExpr,
+			pgx.NamedArgs{
+				"deploymentRevisionId": deploymentRevisionID,
+				"name":                 variable.Name,
+				"secret":               variable.Secret,
+				"valueEncrypted":       valueEncrypted,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query EnvironmentVariable: %w", err)
+		}
+		if row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[environmentVariableRow]); err != nil {
+			return nil, fmt.Errorf("failed to scan EnvironmentVariable: %w", err)
+		} else {
+			result[i] = row.EnvironmentVariable
+			result[i].Value = variable.Value
+		}
+	}
+	return result, nil
+}
+
+// CopyEnvironmentVariables copies all variables of a deployment revision to another deployment revision without
+// decrypting them
+func CopyEnvironmentVariables(ctx context.Context, fromDeploymentRevisionID, toDeploymentRevisionID uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`INSERT INTO EnvironmentVariable (deployment_revision_id, name, secret, value_encrypted)
+		SELECT @to, name, secret, value_encrypted FROM EnvironmentVariable WHERE deployment_revision_id = @from`,
+		pgx.NamedArgs{"from": fromDeploymentRevisionID, "to": toDeploymentRevisionID},
+	)
+	return err
+}
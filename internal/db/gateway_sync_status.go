@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const gatewaySyncStatusOutExpr = ` gs.organization_id, gs.attempted_at, gs.synced_at, gs.error `
+
+// GetGatewaySyncStatus returns the sync status of the gateway of the organization or apierrors.ErrNotFound if the
+// gateway has never been applied
+func GetGatewaySyncStatus(ctx context.Context, orgID uuid.UUID) (*types.GatewaySyncStatus, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+gatewaySyncStatusOutExpr+` FROM GatewaySyncStatus gs WHERE gs.organization_id = @orgId`,
+		pgx.NamedArgs{"orgId": orgID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.GatewaySyncStatus])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// SetGatewaySyncStatus records an attempt to apply the gateway of the organization. syncErr is the error of the attempt
+// or nil if it was successful.
+func SetGatewaySyncStatus(ctx context.Context, orgID uuid.UUID, attemptedAt time.Time, syncErr error) error {
+	var errStr *string
+	if syncErr != nil {
+		s := syncErr.Error()
+		errStr = &s
+	}
+
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`INSERT INTO GatewaySyncStatus AS gs (organization_id, attempted_at, synced_at, error)
+		VALUES (@orgId, @attemptedAt, CASE WHEN @error::TEXT IS NULL THEN @attemptedAt::TIMESTAMP END, @error)
+		ON CONFLICT (organization_id) DO UPDATE
+			SET attempted_at = excluded.attempted_at,
+				synced_at = COALESCE(excluded.synced_at, gs.synced_at),
+				error = excluded.error`,
+		pgx.NamedArgs{"orgId": orgID, "attemptedAt": attemptedAt.UTC(), "error": errStr},
+	)
+	return err
+}
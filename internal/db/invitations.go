@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const invitationOutExpr = ` i.id, i.created_at, i.created_by, i.organization_id, i.email, i.role, i.status, i.expires_at,
+	i.last_sent_at, i.accepted_at, i.accepted_by `
+
+func GetPendingInvitationsForOrganization(ctx context.Context, orgID uuid.UUID) ([]types.Invitation, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+invitationOutExpr+`
+		FROM Invitation i
+		WHERE i.organization_id = @orgId AND i.status = 'pending'
+		ORDER BY i.created_at`,
+		pgx.NamedArgs{"orgId": orgID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.Invitation])
+}
+
+func GetInvitation(ctx context.Context, id uuid.UUID) (*types.Invitation, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT `+invitationOutExpr+` FROM Invitation i WHERE i.id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.Invitation])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// LockInvitation returns the invitation and locks it until the end of the current transaction
+func LockInvitation(ctx context.Context, id uuid.UUID) (*types.Invitation, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+invitationOutExpr+` FROM Invitation i WHERE i.id = @id FOR UPDATE`,
+		pgx.NamedArgs{"id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.Invitation])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// CreateInvitation creates a pending invitation. A pending invitation for the same email address in the organization
+// that has expired is set to expired. It returns apierrors.ErrAlreadyExists if there already is a pending invitation
+// that has not expired.
+func CreateInvitation(ctx context.Context, invitation *types.Invitation) error {
+	db := internalctx.GetDb(ctx)
+	if _, err := db.Exec(
+		ctx,
+		`UPDATE Invitation SET status = 'expired'
+		WHERE organization_id = @orgId AND email = @email AND status = 'pending' AND expires_at <= @now`,
+		pgx.NamedArgs{
+			"orgId": invitation.OrganizationID,
+			"email": strings.ToLower(invitation.Email),
+			"now":   time.Now().UTC(),
+		},
+	); err != nil {
+		return fmt.Errorf("failed to expire Invitation: %w", err)
+	}
+
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO Invitation AS i (created_by, organization_id, email, role, expires_at)
+		VALUES (@createdBy, @orgId, @email, @role, @expiresAt)
+		RETURNING `+invitationOutExpr,
+		pgx.NamedArgs{
+			"createdBy": invitation.CreatedBy,
+			"orgId":     invitation.OrganizationID,
+			"email":     strings.ToLower(invitation.Email),
+			"role":      invitation.Role,
+			"expiresAt": invitation.ExpiresAt.UTC(),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query Invitation: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.Invitation]); err != nil {
+		if pgerr := (*pgconn.PgError)(nil); errors.As(err, &pgerr) && pgerr.Code == pgerrcode.UniqueViolation {
+			return apierrors.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to scan Invitation: %w", err)
+	} else {
+		*invitation = result
+		return nil
+	}
+}
+
+// RenewInvitation extends the expiry of a pending invitation that is sent again
+func RenewInvitation(ctx context.Context, invitation *types.Invitation, sentAt, expiresAt time.Time) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE Invitation AS i SET last_sent_at = @sentAt, expires_at = @expiresAt
+		WHERE i.id = @id AND i.status = 'pending'
+		RETURNING `+invitationOutExpr,
+		pgx.NamedArgs{"id": invitation.ID, "sentAt": sentAt.UTC(), "expiresAt": expiresAt.UTC()},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query Invitation: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.Invitation]); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = apierrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to scan Invitation: %w", err)
+	} else {
+		*invitation = result
+		return nil
+	}
+}
+
+func RevokeInvitation(ctx context.Context, id uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	if res, err := db.Exec(
+		ctx,
+		`UPDATE Invitation SET status = 'revoked' WHERE id = @id AND status = 'pending'`,
+		pgx.NamedArgs{"id": id},
+	); err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	} else {
+		return nil
+	}
+}
+
+func AcceptInvitation(ctx context.Context, id, userID uuid.UUID, acceptedAt time.Time) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`UPDATE Invitation SET status = 'accepted', accepted_at = @acceptedAt, accepted_by = @userId WHERE id = @id`,
+		pgx.NamedArgs{"id": id, "userId": userID, "acceptedAt": acceptedAt.UTC()},
+	)
+	return err
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const mailOutExpr = ` m.id, m.created_at, m.organization_id, m.recipients, m.subject, m.html_body, m.text_body, m.status,
+	m.attempts, m.next_attempt_at, m.last_attempt_at, m.last_error, m.sent_at `
+
+func CreateOutboxMail(ctx context.Context, mail *types.OutboxMail) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO Mail AS m (organization_id, recipients, subject, html_body, text_body)
+		VALUES (@orgId, @recipients, @subject, @htmlBody, @textBody)
+		RETURNING `+mailOutExpr,
+		pgx.NamedArgs{
+			"orgId":      mail.OrganizationID,
+			"recipients": mail.Recipients,
+			"subject":    mail.Subject,
+			"htmlBody":   mail.HtmlBody,
+			"textBody":   mail.TextBody,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query Mail: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.OutboxMail]); err != nil {
+		return fmt.Errorf("failed to scan Mail: %w", err)
+	} else {
+		*mail = result
+		return nil
+	}
+}
+
+type OutboxMailFilter struct {
+	Status *types.MailStatus
+}
+
+func GetOutboxMailsForOrganization(
+	ctx context.Context,
+	orgID uuid.UUID,
+	pagination lists.Pagination,
+	filter OutboxMailFilter,
+) ([]types.OutboxMail, error) {
+	db := internalctx.GetDb(ctx)
+	filters := []string{"m.organization_id = @orgId"}
+	if filter.Status != nil {
+		filters = append(filters, "m.status = @status")
+	}
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+mailOutExpr+`
+		FROM Mail m
+		WHERE `+strings.Join(filters, " AND ")+`
+		ORDER BY m.created_at DESC
+		LIMIT @count OFFSET @offset`,
+		pgx.NamedArgs{
+			"orgId":  orgID,
+			"status": filter.Status,
+			"count":  pagination.Count,
+			"offset": pagination.Count * pagination.Page,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.OutboxMail])
+}
+
+// LockNextPendingOutboxMail returns the pending mail with the earliest next attempt before now and locks it until the
+// end of the current transaction. Mails that are locked by another transaction are skipped.
+func LockNextPendingOutboxMail(ctx context.Context, now time.Time) (*types.OutboxMail, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+mailOutExpr+`
+		FROM Mail m
+		WHERE m.status = 'pending' AND m.next_attempt_at <= @now
+		ORDER BY m.next_attempt_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		pgx.NamedArgs{"now": now.UTC()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.OutboxMail])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// UpdateOutboxMailAttempt stores the result of a send attempt
+func UpdateOutboxMailAttempt(ctx context.Context, mail *types.OutboxMail) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`UPDATE Mail
+		SET status = @status, attempts = @attempts, next_attempt_at = @nextAttemptAt, last_attempt_at = @lastAttemptAt,
+			last_error = @lastError, sent_at = @sentAt
+		WHERE id = @id`,
+		pgx.NamedArgs{
+			"id":            mail.ID,
+			"status":        mail.Status,
+			"attempts":      mail.Attempts,
+			"nextAttemptAt": mail.NextAttemptAt.UTC(),
+			"lastAttemptAt": mail.LastAttemptAt,
+			"lastError":     mail.LastError,
+			"sentAt":        mail.SentAt,
+		},
+	)
+	return err
+}
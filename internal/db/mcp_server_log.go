@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hyprmcp/jetski/internal/lists"
 
@@ -17,19 +18,39 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const (
+	mcpServerLogOutExpr = ` l.id, l.user_account_id, l.mcp_session_id, l.started_at, l.duration, l.deployment_revision_id,
+		l.project_id, l.auth_token_digest, l.mcp_request, l.mcp_response, l.user_agent, l.http_status_code, l.http_error `
+)
+
+type PromptFilter struct {
+	MCPSessionID  *string
+	Query         *string
+	ToolName      *string
+	UserAccountID *uuid.UUID
+	StartedAfter  *time.Time
+	StartedBefore *time.Time
+}
+
 func CreateMCPServerLog(ctx context.Context, data *types.MCPServerLog) error {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(
 		ctx,
 		`WITH inserted AS (
-			INSERT INTO MCPServerLog
+			INSERT INTO MCPServerLog AS l
 			(user_account_id, mcp_session_id, started_at, duration, deployment_revision_id, project_id, auth_token_digest, mcp_request,
-				mcp_response, user_agent, http_status_code, http_error)
+				mcp_response, user_agent, http_status_code, http_error, prompt)
 			VALUES
 			(@userAccountId, @mcpSessionId, @startedAt, @duration, @deploymentRevisionId,
 			(SELECT project_id FROM DeploymentRevision WHERE id = @deploymentRevisionId),
-			@authTokenDigest, @mcpRequest, @mcpResponse, @userAgent, @httpStatusCode, @httpError)
-			RETURNING *
+			@authTokenDigest, @mcpRequest, @mcpResponse, @userAgent, @httpStatusCode, @httpError,
+			@mcpRequest::JSONB -> 'params' -> 'arguments' ->> (
+				SELECT p.settings_analytics_prompt_argument_name
+				FROM Project p
+				INNER JOIN DeploymentRevision dr ON dr.project_id = p.id
+				WHERE dr.id = @deploymentRevisionId
+			))
+			RETURNING `+mcpServerLogOutExpr+`
 		)
 		SELECT * FROM inserted`,
 		pgx.NamedArgs{
@@ -84,7 +105,7 @@ func GetLogsForProject(
 		filters = append(filters, "mcp_session_id = @mcpSessionId")
 	}
 	query := fmt.Sprintf(`
-		SELECT * FROM MCPServerLog
+		SELECT `+mcpServerLogOutExpr+` FROM MCPServerLog l
 		WHERE %s
 		ORDER BY %s %s
 		LIMIT @count OFFSET @offset
@@ -105,33 +126,30 @@ func GetPromptsForProject(
 	projectId uuid.UUID,
 	pagination lists.Pagination,
 	sorting lists.Sorting,
-	mcpSessionID *string,
+	filter PromptFilter,
 ) ([]types.MCPServerLogPromptData, error) {
 	db := internalctx.GetDb(ctx)
-	offset := pagination.Count * pagination.Page
-	filters := []string{"project_id = @projectId"}
-	if mcpSessionID != nil {
-		filters = append(filters, "mcp_session_id = @mcpSessionId")
-	}
+	where, args := promptFilterExpr(projectId, filter)
+	args["count"] = pagination.Count
+	args["offset"] = pagination.Count * pagination.Page
 	query := fmt.Sprintf(
-		`SELECT * FROM (
-			SELECT
-				id,
-				started_at,
-				jsonb_path_query_first(mcp_request, '$.method') #>> '{}' AS method,
-				jsonb_path_query_first(mcp_request, '$.params.name') #>> '{}' AS tool_name,
-				jsonb_path_query_first(mcp_request, '$.params.arguments.hyprmcpPromptAnalytics') #>> '{}' AS prompt
-			FROM MCPServerLog
-			WHERE %s
-		)
-		WHERE prompt IS NOT NULL
+		`SELECT
+			id,
+			started_at,
+			mcp_request ->> 'method' AS method,
+			tool_name,
+			prompt,
+			user_account_id,
+			mcp_session_id
+		FROM MCPServerLog
+		WHERE %s
 		ORDER BY %s %s
 		LIMIT @count OFFSET @offset`,
-		strings.Join(filters, " AND "),
+		where,
 		sorting.SortBy,
 		sorting.SortOrder,
 	)
-	rows, err := db.Query(ctx, query, pgx.NamedArgs{"projectId": projectId, "count": pagination.Count, "offset": offset, "mcpSessionId": mcpSessionID})
+	rows, err := db.Query(ctx, query, args)
 	if err != nil {
 		return nil, err
 	}
@@ -141,3 +159,187 @@ func GetPromptsForProject(
 	}
 	return logs, nil
 }
+
+// CountPromptsForProject returns the number of prompts of a project that match the filter
+func CountPromptsForProject(ctx context.Context, projectId uuid.UUID, filter PromptFilter) (int, error) {
+	db := internalctx.GetDb(ctx)
+	where, args := promptFilterExpr(projectId, filter)
+	var count int
+	if err := db.QueryRow(ctx, `SELECT count(*) FROM MCPServerLog WHERE `+where, args).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func promptFilterExpr(projectId uuid.UUID, filter PromptFilter) (string, pgx.NamedArgs) {
+	filters := []string{"project_id = @projectId", "prompt IS NOT NULL", "tool_name IS NOT NULL"}
+	if filter.MCPSessionID != nil {
+		filters = append(filters, "mcp_session_id = @mcpSessionId")
+	}
+	if filter.Query != nil {
+		filters = append(filters, "prompt_tsv @@ websearch_to_tsquery('simple', @query)")
+	}
+	if filter.ToolName != nil {
+		filters = append(filters, "tool_name = @toolName")
+	}
+	if filter.UserAccountID != nil {
+		filters = append(filters, "user_account_id = @userAccountId")
+	}
+	if filter.StartedAfter != nil {
+		filters = append(filters, "started_at >= @startedAfter")
+	}
+	if filter.StartedBefore != nil {
+		filters = append(filters, "started_at < @startedBefore")
+	}
+	return strings.Join(filters, " AND "), pgx.NamedArgs{
+		"projectId":     projectId,
+		"mcpSessionId":  filter.MCPSessionID,
+		"query":         filter.Query,
+		"toolName":      filter.ToolName,
+		"userAccountId": filter.UserAccountID,
+		"startedAfter":  filter.StartedAfter,
+		"startedBefore": filter.StartedBefore,
+	}
+}
+
+// reindexPromptsBatchSize is the maximum number of logs that are updated by a single statement in
+// ReindexPromptsForProject
+const reindexPromptsBatchSize = 1000
+
+// ReindexPromptsForProject extracts the prompts of all logs of a project again, using the prompt argument name that is
+// currently stored in the project. This is needed after the prompt argument name of the project was changed.
+//
+// The logs are updated in batches, each in its own statement, so that a large project does not hold the row locks of
+// all its logs at once. It must therefore not be called inside a transaction.
+func ReindexPromptsForProject(ctx context.Context, projectID uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	for {
+		tag, err := db.Exec(
+			ctx,
+			`WITH p AS (SELECT settings_analytics_prompt_argument_name AS name FROM Project WHERE id = @projectId)
+			UPDATE MCPServerLog
+			SET prompt = mcp_request -> 'params' -> 'arguments' ->> (SELECT name FROM p)
+			WHERE id IN (
+				SELECT l.id FROM MCPServerLog l
+				WHERE l.project_id = @projectId
+					AND l.prompt IS DISTINCT FROM l.mcp_request -> 'params' -> 'arguments' ->> (SELECT name FROM p)
+				LIMIT @batchSize
+			)`,
+			pgx.NamedArgs{"projectId": projectID, "batchSize": reindexPromptsBatchSize},
+		)
+		if err != nil {
+			return err
+		} else if tag.RowsAffected() < reindexPromptsBatchSize {
+			return nil
+		}
+	}
+}
+
+// RequestPromptReindex queues a reindex of the prompts of the project (see ReindexPromptsForProject)
+func RequestPromptReindex(ctx context.Context, projectID uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`UPDATE Project SET prompts_reindex_requested_at = current_timestamp WHERE id = @projectId`,
+		pgx.NamedArgs{"projectId": projectID},
+	)
+	return err
+}
+
+// ClaimNextPromptReindex claims the project whose reindex has been requested first and returns its ID. Projects whose
+// reindex has been started after startedBefore are skipped, so that at most one reindex runs per project. It returns
+// apierrors.ErrNotFound if there is no such project.
+func ClaimNextPromptReindex(ctx context.Context, startedBefore time.Time) (uuid.UUID, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE Project
+		SET prompts_reindex_started_at = current_timestamp, prompts_reindex_requested_at = NULL
+		WHERE id = (
+			SELECT id FROM Project
+			WHERE prompts_reindex_requested_at IS NOT NULL
+				AND (prompts_reindex_started_at IS NULL OR prompts_reindex_started_at < @startedBefore)
+			ORDER BY prompts_reindex_requested_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		pgx.NamedArgs{"startedBefore": startedBefore.UTC()},
+	)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[uuid.UUID])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// FinishPromptReindex releases the claim of ClaimNextPromptReindex. If the reindex has failed, it is requested again.
+func FinishPromptReindex(ctx context.Context, projectID uuid.UUID, failed bool) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`UPDATE Project
+		SET prompts_reindex_started_at = NULL,
+			prompts_reindex_requested_at = CASE WHEN @failed
+				THEN COALESCE(prompts_reindex_requested_at, current_timestamp)
+				ELSE prompts_reindex_requested_at END
+		WHERE id = @projectId`,
+		pgx.NamedArgs{"projectId": projectID, "failed": failed},
+	)
+	return err
+}
+
+// GetLogsForOrganization returns all logs of all projects of an organization, optionally only those started after
+// the given time, sorted by started_at ascending.
+func GetLogsForOrganization(ctx context.Context, orgID uuid.UUID, startedAfter *time.Time) ([]types.MCPServerLog, error) {
+	db := internalctx.GetDb(ctx)
+	filters := []string{"p.organization_id = @orgId"}
+	if startedAfter != nil {
+		filters = append(filters, "l.started_at >= @startedAfter")
+	}
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+mcpServerLogOutExpr+`
+		FROM MCPServerLog l
+		INNER JOIN Project p ON p.id = l.project_id
+		WHERE `+strings.Join(filters, " AND ")+`
+		ORDER BY l.started_at`,
+		pgx.NamedArgs{"orgId": orgID, "startedAfter": startedAfter},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.MCPServerLog])
+}
+
+// GetLogsForProjectInRange returns all logs of a project started in [startedAfter, startedBefore) sorted by
+// started_at ascending. If errorsOnly is set, only logs of failed requests are returned.
+func GetLogsForProjectInRange(
+	ctx context.Context,
+	projectID uuid.UUID,
+	startedAfter, startedBefore time.Time,
+	errorsOnly bool,
+) ([]types.MCPServerLog, error) {
+	db := internalctx.GetDb(ctx)
+	filters := []string{"l.project_id = @projectId", "l.started_at >= @startedAfter", "l.started_at < @startedBefore"}
+	if errorsOnly {
+		filters = append(filters, `(l.http_status_code >= 400
+			OR coalesce(l.mcp_response -> 'error', 'null') != 'null'::JSONB
+			OR coalesce(l.mcp_response -> 'result' -> 'isError', 'false') = 'true'::JSONB)`)
+	}
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+mcpServerLogOutExpr+`
+		FROM MCPServerLog l
+		WHERE `+strings.Join(filters, " AND ")+`
+		ORDER BY l.started_at`,
+		pgx.NamedArgs{"projectId": projectID, "startedAfter": startedAfter.UTC(), "startedBefore": startedBefore.UTC()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.MCPServerLog])
+}
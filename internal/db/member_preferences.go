@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+func GetMemberPreferences(ctx context.Context, orgID, userID uuid.UUID) (*types.MemberPreferences, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT j.digest_frequency
+		FROM Organization_UserAccount j
+		WHERE j.organization_id = @orgId AND j.user_account_id = @userId`,
+		pgx.NamedArgs{"orgId": orgID, "userId": userID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.MemberPreferences])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func UpdateMemberPreferences(ctx context.Context, orgID, userID uuid.UUID, preferences *types.MemberPreferences) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE Organization_UserAccount AS j SET digest_frequency = @digestFrequency
+		WHERE j.organization_id = @orgId AND j.user_account_id = @userId
+		RETURNING j.digest_frequency`,
+		pgx.NamedArgs{"orgId": orgID, "userId": userID, "digestFrequency": preferences.DigestFrequency},
+	)
+	if err != nil {
+		return err
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.MemberPreferences]); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = apierrors.ErrNotFound
+		}
+		return err
+	} else {
+		*preferences = result
+		return nil
+	}
+}
+
+// LockNextDigestRecipient returns a member whose last digest (or membership if no digest was sent yet) is older than
+// the period of the configured digest frequency and locks the membership until the end of the current transaction.
+// Memberships that are locked by another transaction are skipped.
+func LockNextDigestRecipient(ctx context.Context, now time.Time) (*types.DigestRecipient, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT (`+organizationOutputExpr+`), (`+userOutExpr+`), j.digest_frequency
+		FROM Organization_UserAccount j
+		INNER JOIN Organization o ON o.id = j.organization_id
+		INNER JOIN UserAccount u ON u.id = j.user_account_id
+		WHERE (j.digest_frequency = 'daily' AND COALESCE(j.last_digest_sent_at, j.created_at) <= @dailyBefore)
+			OR (j.digest_frequency = 'weekly' AND COALESCE(j.last_digest_sent_at, j.created_at) <= @weeklyBefore)
+		ORDER BY COALESCE(j.last_digest_sent_at, j.created_at)
+		LIMIT 1
+		FOR UPDATE OF j SKIP LOCKED`,
+		pgx.NamedArgs{
+			"dailyBefore":  now.Add(-types.DigestFrequencyDaily.Period()).UTC(),
+			"weeklyBefore": now.Add(-types.DigestFrequencyWeekly.Period()).UTC(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[types.DigestRecipient])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func SetDigestSent(ctx context.Context, orgID, userID uuid.UUID, sentAt time.Time) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`UPDATE Organization_UserAccount SET last_digest_sent_at = @sentAt
+		WHERE organization_id = @orgId AND user_account_id = @userId`,
+		pgx.NamedArgs{"orgId": orgID, "userId": userID, "sentAt": sentAt.UTC()},
+	)
+	return err
+}
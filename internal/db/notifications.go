@@ -0,0 +1,307 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/secrets"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	notificationChannelOutExpr = ` nc.id, nc.created_at, nc.organization_id, nc.name, nc.type, nc.url, nc.secret,
+		nc.secret_encrypted, nc.event_types, nc.enabled `
+	notificationDeliveryOutExpr = ` nd.id, nd.created_at, nd.notification_channel_id, nd.event_type, nd.payload, nd.status,
+		nd.attempts, nd.next_attempt_at, nd.last_attempt_at, nd.last_status_code, nd.last_error, nd.delivered_at `
+)
+
+type notificationChannelRow struct {
+	types.NotificationChannel
+	// SecretPlaintext is only set for channels that have been created before secrets were encrypted and have not been
+	// encrypted by EncryptNotificationChannelSecrets yet
+	SecretPlaintext *string `db:"secret"`
+	SecretEncrypted []byte  `db:"secret_encrypted"`
+}
+
+func (row *notificationChannelRow) decrypt() (*types.NotificationChannel, error) {
+	result := row.NotificationChannel
+	if row.SecretEncrypted == nil {
+		if row.SecretPlaintext == nil {
+			return nil, fmt.Errorf("NotificationChannel %v has no secret", row.ID)
+		}
+		result.Secret = *row.SecretPlaintext
+	} else if secret, err := secrets.Decrypt(row.SecretEncrypted); err != nil {
+		return nil, fmt.Errorf("failed to decrypt NotificationChannel %v: %w", row.ID, err)
+	} else {
+		result.Secret = secret
+	}
+	return &result, nil
+}
+
+func collectNotificationChannels(rows pgx.Rows) ([]types.NotificationChannel, error) {
+	channelRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[notificationChannelRow])
+	if err != nil {
+		return nil, err
+	}
+	result := make([]types.NotificationChannel, len(channelRows))
+	for i := range channelRows {
+		if channel, err := channelRows[i].decrypt(); err != nil {
+			return nil, err
+		} else {
+			result[i] = *channel
+		}
+	}
+	return result, nil
+}
+
+func collectExactlyOneNotificationChannel(rows pgx.Rows) (*types.NotificationChannel, error) {
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[notificationChannelRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return row.decrypt()
+}
+
+func GetNotificationChannelsForOrganization(ctx context.Context, orgID uuid.UUID) ([]types.NotificationChannel, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+notificationChannelOutExpr+`
+		FROM NotificationChannel nc
+		WHERE nc.organization_id = @orgId
+		ORDER BY nc.created_at`,
+		pgx.NamedArgs{"orgId": orgID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectNotificationChannels(rows)
+}
+
+func GetNotificationChannel(ctx context.Context, id uuid.UUID) (*types.NotificationChannel, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+notificationChannelOutExpr+` FROM NotificationChannel nc WHERE nc.id = @id`,
+		pgx.NamedArgs{"id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectExactlyOneNotificationChannel(rows)
+}
+
+func CreateNotificationChannel(ctx context.Context, channel *types.NotificationChannel) error {
+	secretEncrypted, err := secrets.Encrypt(channel.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt NotificationChannel: %w", err)
+	}
+
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO NotificationChannel AS nc (organization_id, name, type, url, secret_encrypted, event_types, enabled)
+		VALUES (@orgId, @name, @type, @url, @secretEncrypted, COALESCE(@eventTypes::TEXT[], '{}'), @enabled)
+		RETURNING `+notificationChannelOutExpr,
+		pgx.NamedArgs{
+			"orgId":           channel.OrganizationID,
+			"name":            channel.Name,
+			"type":            channel.Type,
+			"url":             channel.URL,
+			"secretEncrypted": secretEncrypted,
+			"eventTypes":      channel.EventTypes,
+			"enabled":         channel.Enabled,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query NotificationChannel: %w", err)
+	}
+	if result, err := collectExactlyOneNotificationChannel(rows); err != nil {
+		return fmt.Errorf("failed to scan NotificationChannel: %w", err)
+	} else {
+		*channel = *result
+		return nil
+	}
+}
+
+func UpdateNotificationChannel(ctx context.Context, channel *types.NotificationChannel) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE NotificationChannel AS nc
+			SET name = @name, url = @url, event_types = COALESCE(@eventTypes::TEXT[], '{}'), enabled = @enabled
+		WHERE id = @id
+		RETURNING `+notificationChannelOutExpr,
+		pgx.NamedArgs{
+			"id":         channel.ID,
+			"name":       channel.Name,
+			"url":        channel.URL,
+			"eventTypes": channel.EventTypes,
+			"enabled":    channel.Enabled,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query NotificationChannel: %w", err)
+	}
+	if result, err := collectExactlyOneNotificationChannel(rows); err != nil {
+		return fmt.Errorf("failed to scan NotificationChannel: %w", err)
+	} else {
+		*channel = *result
+		return nil
+	}
+}
+
+// EncryptNotificationChannelSecrets encrypts the secrets of all channels that have been stored in plaintext and
+// removes the plaintext. It returns the number of encrypted secrets.
+func EncryptNotificationChannelSecrets(ctx context.Context) (int, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT id, secret FROM NotificationChannel WHERE secret IS NOT NULL`)
+	if err != nil {
+		return 0, err
+	}
+	type plaintextSecret struct {
+		ID     uuid.UUID `db:"id"`
+		Secret string    `db:"secret"`
+	}
+	plaintextSecrets, err := pgx.CollectRows(rows, pgx.RowToStructByName[plaintextSecret])
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range plaintextSecrets {
+		secretEncrypted, err := secrets.Encrypt(s.Secret)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt NotificationChannel %v: %w", s.ID, err)
+		}
+		_, err = db.Exec(
+			ctx,
+			`UPDATE NotificationChannel SET secret_encrypted = @secretEncrypted, secret = NULL
+			WHERE id = @id AND secret IS NOT NULL`,
+			pgx.NamedArgs{"id": s.ID, "secretEncrypted": secretEncrypted},
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(plaintextSecrets), nil
+}
+
+func DeleteNotificationChannel(ctx context.Context, id uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	if res, err := db.Exec(ctx, `DELETE FROM NotificationChannel WHERE id = @id`, pgx.NamedArgs{"id": id}); err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	} else {
+		return nil
+	}
+}
+
+// CreateNotificationDeliveries enqueues the payload for all enabled channels of the organization that are subscribed
+// to the event type
+func CreateNotificationDeliveries(
+	ctx context.Context,
+	orgID uuid.UUID,
+	eventType types.NotificationEventType,
+	payload []byte,
+) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`INSERT INTO NotificationDelivery (notification_channel_id, event_type, payload)
+		SELECT nc.id, @eventType, @payload
+		FROM NotificationChannel nc
+		WHERE nc.organization_id = @orgId
+			AND nc.enabled
+			AND (cardinality(nc.event_types) = 0 OR @eventType = ANY(nc.event_types))`,
+		pgx.NamedArgs{"orgId": orgID, "eventType": string(eventType), "payload": string(payload)},
+	)
+	return err
+}
+
+func GetNotificationDeliveriesForChannel(
+	ctx context.Context,
+	channelID uuid.UUID,
+	pagination lists.Pagination,
+) ([]types.NotificationDelivery, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+notificationDeliveryOutExpr+`
+		FROM NotificationDelivery nd
+		WHERE nd.notification_channel_id = @channelId
+		ORDER BY nd.created_at DESC
+		LIMIT @count OFFSET @offset`,
+		pgx.NamedArgs{"channelId": channelID, "count": pagination.Count, "offset": pagination.Count * pagination.Page},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.NotificationDelivery])
+}
+
+// LockNextPendingNotificationDelivery returns the pending delivery with the earliest next attempt before now and
+// locks it until the end of the current transaction. Deliveries that are locked by another transaction are skipped.
+func LockNextPendingNotificationDelivery(
+	ctx context.Context,
+	now time.Time,
+) (*types.NotificationDeliveryWithChannel, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+notificationDeliveryOutExpr+`
+		FROM NotificationDelivery nd
+		WHERE nd.status = 'pending' AND nd.next_attempt_at <= @now
+		ORDER BY nd.next_attempt_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		pgx.NamedArgs{"now": now.UTC()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.NotificationDelivery])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	// the channel is loaded separately, because its secret has to be decrypted
+	channel, err := GetNotificationChannel(ctx, delivery.NotificationChannelID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.NotificationDeliveryWithChannel{NotificationDelivery: delivery, Channel: *channel}, nil
+}
+
+// UpdateNotificationDeliveryAttempt stores the result of a delivery attempt
+func UpdateNotificationDeliveryAttempt(ctx context.Context, delivery *types.NotificationDelivery) error {
+	db := internalctx.GetDb(ctx)
+	_, err := db.Exec(
+		ctx,
+		`UPDATE NotificationDelivery
+		SET status = @status, attempts = @attempts, next_attempt_at = @nextAttemptAt, last_attempt_at = @lastAttemptAt,
+			last_status_code = @lastStatusCode, last_error = @lastError, delivered_at = @deliveredAt
+		WHERE id = @id`,
+		pgx.NamedArgs{
+			"id":             delivery.ID,
+			"status":         delivery.Status,
+			"attempts":       delivery.Attempts,
+			"nextAttemptAt":  delivery.NextAttemptAt.UTC(),
+			"lastAttemptAt":  delivery.LastAttemptAt,
+			"lastStatusCode": delivery.LastStatusCode,
+			"lastError":      delivery.LastError,
+			"deliveredAt":    delivery.DeliveredAt,
+		},
+	)
+	return err
+}
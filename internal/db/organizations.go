@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyprmcp/jetski/internal/apierrors"
 	"github.com/jackc/pgerrcode"
@@ -22,8 +23,28 @@ const (
 		o.name,
 		ROW(
 			o.settings_custom_domain,
+			ROW(
+				o.settings_custom_domain_verification_token,
+				o.settings_custom_domain_verified,
+				o.settings_custom_domain_verified_at,
+				o.settings_custom_domain_checked_at
+			),
 			ROW(
 				o.settings_authorization_dcr_public_client
+			),
+			ROW(
+				o.settings_gateway_replicas,
+				o.settings_gateway_cpu_request,
+				o.settings_gateway_cpu_limit,
+				o.settings_gateway_memory_request,
+				o.settings_gateway_memory_limit,
+				ROW(
+					o.settings_gateway_autoscaling_enabled,
+					o.settings_gateway_autoscaling_min_replicas,
+					o.settings_gateway_autoscaling_max_replicas,
+					o.settings_gateway_autoscaling_target_cpu_utilization
+				),
+				o.settings_gateway_min_available
 			)
 		) `
 )
@@ -49,6 +70,28 @@ func GetOrganizationsOfUser(ctx context.Context, userID uuid.UUID) ([]types.Orga
 	}
 }
 
+func GetOrganizations(ctx context.Context) ([]types.Organization, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT`+organizationOutputExpr+` FROM Organization o ORDER BY o.name`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[types.Organization])
+}
+
+func GetOrganization(ctx context.Context, id uuid.UUID) (*types.Organization, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT`+organizationOutputExpr+` FROM Organization o WHERE o.id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[types.Organization])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
 func CreateOrganization(ctx context.Context, name string) (*types.Organization, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(
@@ -77,13 +120,42 @@ func UpdateOrganization(ctx context.Context, org *types.Organization) error {
 		ctx,
 		`UPDATE Organization AS o
 			SET settings_custom_domain = @settings_custom_domain,
-				settings_authorization_dcr_public_client = @settings_authorization_dcr_public_client
+				settings_custom_domain_verification_token = @settings_custom_domain_verification_token,
+				settings_custom_domain_verified = @settings_custom_domain_verified,
+				settings_custom_domain_verified_at = @settings_custom_domain_verified_at,
+				settings_custom_domain_checked_at = @settings_custom_domain_checked_at,
+				settings_authorization_dcr_public_client = @settings_authorization_dcr_public_client,
+				settings_gateway_replicas = @settings_gateway_replicas,
+				settings_gateway_cpu_request = @settings_gateway_cpu_request,
+				settings_gateway_cpu_limit = @settings_gateway_cpu_limit,
+				settings_gateway_memory_request = @settings_gateway_memory_request,
+				settings_gateway_memory_limit = @settings_gateway_memory_limit,
+				settings_gateway_autoscaling_enabled = @settings_gateway_autoscaling_enabled,
+				settings_gateway_autoscaling_min_replicas = @settings_gateway_autoscaling_min_replicas,
+				settings_gateway_autoscaling_max_replicas = @settings_gateway_autoscaling_max_replicas,
+				settings_gateway_autoscaling_target_cpu_utilization =
+					@settings_gateway_autoscaling_target_cpu_utilization,
+				settings_gateway_min_available = @settings_gateway_min_available
 		WHERE id = @id
 		RETURNING `+organizationOutputExpr,
 		pgx.NamedArgs{
 			"id":                     org.ID,
 			"settings_custom_domain": org.Settings.CustomDomain,
-			"settings_authorization_dcr_public_client": org.Settings.Authorization.DCRPublicClient,
+			"settings_custom_domain_verification_token":           org.Settings.CustomDomainVerification.Token,
+			"settings_custom_domain_verified":                     org.Settings.CustomDomainVerification.Verified,
+			"settings_custom_domain_verified_at":                  org.Settings.CustomDomainVerification.VerifiedAt,
+			"settings_custom_domain_checked_at":                   org.Settings.CustomDomainVerification.CheckedAt,
+			"settings_authorization_dcr_public_client":            org.Settings.Authorization.DCRPublicClient,
+			"settings_gateway_replicas":                           org.Settings.Gateway.Replicas,
+			"settings_gateway_cpu_request":                        org.Settings.Gateway.CPURequest,
+			"settings_gateway_cpu_limit":                          org.Settings.Gateway.CPULimit,
+			"settings_gateway_memory_request":                     org.Settings.Gateway.MemoryRequest,
+			"settings_gateway_memory_limit":                       org.Settings.Gateway.MemoryLimit,
+			"settings_gateway_autoscaling_enabled":                org.Settings.Gateway.Autoscaling.Enabled,
+			"settings_gateway_autoscaling_min_replicas":           org.Settings.Gateway.Autoscaling.MinReplicas,
+			"settings_gateway_autoscaling_max_replicas":           org.Settings.Gateway.Autoscaling.MaxReplicas,
+			"settings_gateway_autoscaling_target_cpu_utilization": org.Settings.Gateway.Autoscaling.TargetCPUUtilization,
+			"settings_gateway_min_available":                      org.Settings.Gateway.MinAvailable,
 		},
 	)
 	if err != nil {
@@ -102,19 +174,19 @@ func UpdateOrganization(ctx context.Context, org *types.Organization) error {
 	}
 }
 
-func GetOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]types.UserAccount, error) {
+func GetOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]types.OrganizationMember, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(ctx, `
-		SELECT`+userOutExpr+`
+		SELECT`+userOutExpr+`, j.role
 			FROM UserAccount u
 			INNER JOIN Organization_UserAccount j ON u.id = j.user_account_id
-			WHERE j.organization_id = @id
+			WHERE j.organization_id = @id AND NOT EXISTS (SELECT 1 FROM ServiceAccount sa WHERE sa.id = u.id)
 			ORDER BY u.created_at
 	`, pgx.NamedArgs{"id": orgID})
 	if err != nil {
 		return nil, err
 	}
-	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[types.UserAccount])
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[types.OrganizationMember])
 	if err != nil {
 		return nil, err
 	} else {
@@ -122,6 +194,25 @@ func GetOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]types.UserA
 	}
 }
 
+func GetOrganizationMember(ctx context.Context, orgID, userID uuid.UUID) (*types.OrganizationMember, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `
+		SELECT`+userOutExpr+`, j.role
+			FROM UserAccount u
+			INNER JOIN Organization_UserAccount j ON u.id = j.user_account_id
+			WHERE j.organization_id = @orgId AND j.user_account_id = @userId
+				AND NOT EXISTS (SELECT 1 FROM ServiceAccount sa WHERE sa.id = u.id)
+	`, pgx.NamedArgs{"orgId": orgID, "userId": userID})
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.OrganizationMember])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
 func ExistsOrganizationWithName(ctx context.Context, name string) (bool, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(ctx, "SELECT true FROM Organization WHERE name = @name", pgx.NamedArgs{"name": name})
@@ -137,11 +228,13 @@ func ExistsOrganizationWithName(ctx context.Context, name string) (bool, error)
 	return exists, err
 }
 
+// ExistsOrganizationWithCustomDomain returns whether an organization has verified the custom domain
 func ExistsOrganizationWithCustomDomain(ctx context.Context, domain string) (bool, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(
 		ctx,
-		"SELECT true FROM Organization WHERE settings_custom_domain = @settings_custom_domain",
+		`SELECT true FROM Organization
+		WHERE settings_custom_domain = @settings_custom_domain AND settings_custom_domain_verified`,
 		pgx.NamedArgs{"settings_custom_domain": domain},
 	)
 	if err != nil {
@@ -155,3 +248,46 @@ func ExistsOrganizationWithCustomDomain(ctx context.Context, domain string) (boo
 
 	return exists, err
 }
+
+// GetNextOrganizationForDomainVerification returns the next organization with a custom domain that has not been
+// checked since checkedBefore. The organization is not locked, so that the TXT record can be looked up without
+// holding a lock. It returns apierrors.ErrNotFound if there is no such organization.
+func GetNextOrganizationForDomainVerification(ctx context.Context, checkedBefore time.Time) (*types.Organization, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT`+organizationOutputExpr+`
+		FROM Organization o
+		WHERE o.settings_custom_domain IS NOT NULL
+			AND (o.settings_custom_domain_checked_at IS NULL OR o.settings_custom_domain_checked_at < @checkedBefore)
+		ORDER BY o.settings_custom_domain_checked_at NULLS FIRST
+		LIMIT 1`,
+		pgx.NamedArgs{"checkedBefore": checkedBefore.UTC()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[types.Organization])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// LockOrganization returns the organization and locks it until the end of the transaction
+func LockOrganization(ctx context.Context, id uuid.UUID) (*types.Organization, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT`+organizationOutputExpr+` FROM Organization o WHERE o.id = @id FOR UPDATE`,
+		pgx.NamedArgs{"id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[types.Organization])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
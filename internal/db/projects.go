@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/hyprmcp/jetski/internal/apierrors"
@@ -11,7 +13,20 @@ import (
 )
 
 const (
-	projectOutExpr = " p.id, p.created_at, p.created_by, p.organization_id, p.name, p.latest_deployment_revision_id, p.latest_deployment_revision_event_id "
+	projectOutExpr = `
+		p.id,
+		p.created_at,
+		p.created_by,
+		p.organization_id,
+		p.name,
+		p.latest_deployment_revision_id,
+		p.latest_deployment_revision_event_id,
+		ROW(
+			ROW(
+				p.settings_analytics_excluded_arguments,
+				p.settings_analytics_prompt_argument_name
+			)
+		) `
 )
 
 func GetProjectsForUser(ctx context.Context, userID uuid.UUID) ([]types.Project, error) {
@@ -27,7 +42,7 @@ func GetProjectsForUser(ctx context.Context, userID uuid.UUID) ([]types.Project,
 	if err != nil {
 		return nil, err
 	}
-	result, err := pgx.CollectRows(rows, pgx.RowToStructByName[types.Project])
+	result, err := pgx.CollectRows(rows, pgx.RowToStructByPos[types.Project])
 	if err != nil {
 		return nil, err
 	} else {
@@ -38,14 +53,14 @@ func GetProjectsForUser(ctx context.Context, userID uuid.UUID) ([]types.Project,
 func CreateProject(ctx context.Context, orgID, createdBy uuid.UUID, name string) (*types.Project, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(ctx, `
-		INSERT INTO Project (created_by, organization_id, name)
+		INSERT INTO Project AS p (created_by, organization_id, name)
 		VALUES (@createdBy, @orgID, @name)
-		RETURNING id, created_at, created_by, organization_id, name, latest_deployment_revision_id, latest_deployment_revision_event_id
-	`, pgx.NamedArgs{"orgID": orgID, "createdBy": createdBy, "name": name})
+		RETURNING `+projectOutExpr,
+		pgx.NamedArgs{"orgID": orgID, "createdBy": createdBy, "name": name})
 	if err != nil {
 		return nil, err
 	}
-	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.Project])
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByPos[types.Project])
 	if err != nil {
 		return nil, err
 	} else {
@@ -53,6 +68,19 @@ func CreateProject(ctx context.Context, orgID, createdBy uuid.UUID, name string)
 	}
 }
 
+func GetOrganizationIDOfProject(ctx context.Context, projectID uuid.UUID) (uuid.UUID, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT organization_id FROM Project WHERE id = @id`, pgx.NamedArgs{"id": projectID})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[uuid.UUID])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
 func DeleteProject(ctx context.Context, projectID uuid.UUID) error {
 	db := internalctx.GetDb(ctx)
 	if res, err := db.Exec(ctx, `DELETE FROM Project WHERE id = @id`, pgx.NamedArgs{"id": projectID}); err != nil {
@@ -63,3 +91,34 @@ func DeleteProject(ctx context.Context, projectID uuid.UUID) error {
 		return nil
 	}
 }
+
+func UpdateProject(ctx context.Context, project *types.Project) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE Project AS p
+			SET settings_analytics_excluded_arguments = COALESCE(@settings_analytics_excluded_arguments::TEXT[], '{}'),
+				settings_analytics_prompt_argument_name = @settings_analytics_prompt_argument_name
+		WHERE id = @id
+		RETURNING `+projectOutExpr,
+		pgx.NamedArgs{
+			"id":                                    project.ID,
+			"settings_analytics_excluded_arguments": project.Settings.Analytics.ExcludedArguments,
+			"settings_analytics_prompt_argument_name": project.Settings.Analytics.PromptArgumentName,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query Project: %w", err)
+	}
+
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByPos[types.Project]); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = apierrors.ErrNotFound
+		}
+
+		return fmt.Errorf("failed to scan Project: %w", err)
+	} else {
+		*project = result
+		return nil
+	}
+}
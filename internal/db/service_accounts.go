@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const serviceAccountOutExpr = ` sa.id, sa.created_at, sa.created_by, sa.organization_id, sa.name, j.role `
+
+func GetServiceAccountsForOrganization(ctx context.Context, orgID uuid.UUID) ([]types.ServiceAccount, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+serviceAccountOutExpr+`
+		FROM ServiceAccount sa
+		INNER JOIN Organization_UserAccount j ON j.user_account_id = sa.id AND j.organization_id = sa.organization_id
+		WHERE sa.organization_id = @orgId
+		ORDER BY sa.name`,
+		pgx.NamedArgs{"orgId": orgID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.ServiceAccount])
+}
+
+func GetServiceAccount(ctx context.Context, orgID, id uuid.UUID) (*types.ServiceAccount, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+serviceAccountOutExpr+`
+		FROM ServiceAccount sa
+		INNER JOIN Organization_UserAccount j ON j.user_account_id = sa.id AND j.organization_id = sa.organization_id
+		WHERE sa.organization_id = @orgId AND sa.id = @id`,
+		pgx.NamedArgs{"orgId": orgID, "id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.ServiceAccount])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+// CreateServiceAccount creates the user account of the service account and adds it to the organization with the
+// role of the service account. Service accounts never receive digest mails. This function must be called inside a
+// transaction.
+func CreateServiceAccount(ctx context.Context, serviceAccount *types.ServiceAccount) error {
+	db := internalctx.GetDb(ctx)
+	id := uuid.New()
+	if _, err := db.Exec(
+		ctx,
+		`INSERT INTO UserAccount (id, email) VALUES (@id, @email)`,
+		pgx.NamedArgs{"id": id, "email": fmt.Sprintf("%v@%v", id, types.ServiceAccountEmailDomain)},
+	); err != nil {
+		return err
+	}
+
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO ServiceAccount AS sa (id, created_by, organization_id, name)
+		VALUES (@id, @createdBy, @orgId, @name)
+		RETURNING sa.id, sa.created_at, sa.created_by, sa.organization_id, sa.name`,
+		pgx.NamedArgs{
+			"id":        id,
+			"createdBy": serviceAccount.CreatedBy,
+			"orgId":     serviceAccount.OrganizationID,
+			"name":      serviceAccount.Name,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByNameLax[types.ServiceAccount])
+	if err != nil {
+		if pgerr := (*pgconn.PgError)(nil); errors.As(err, &pgerr) && pgerr.Code == pgerrcode.UniqueViolation {
+			return apierrors.ErrAlreadyExists
+		}
+		return err
+	}
+
+	if _, err := db.Exec(
+		ctx,
+		`INSERT INTO Organization_UserAccount (organization_id, user_account_id, role, digest_frequency)
+		VALUES (@orgId, @userId, @role, 'never')`,
+		pgx.NamedArgs{"orgId": result.OrganizationID, "userId": result.ID, "role": serviceAccount.Role},
+	); err != nil {
+		return err
+	}
+
+	result.Role = serviceAccount.Role
+	*serviceAccount = result
+	return nil
+}
+
+// DeleteServiceAccount removes the service account from its organization and deletes all of its tokens. The user
+// account is kept, because it may still be referenced as the creator of other resources.
+func DeleteServiceAccount(ctx context.Context, orgID, id uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	res, err := db.Exec(
+		ctx,
+		`DELETE FROM ServiceAccount WHERE organization_id = @orgId AND id = @id`,
+		pgx.NamedArgs{"orgId": orgID, "id": id},
+	)
+	if err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	}
+
+	if _, err := db.Exec(ctx, `DELETE FROM ApiToken WHERE user_account_id = @id`, pgx.NamedArgs{"id": id}); err != nil {
+		return err
+	}
+	return RemoveUserFromOrganization(ctx, id, orgID)
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const toolPolicyOutExpr = ` tp.id, tp.created_at, tp.created_by, tp.project_id, tp.name, tp.priority, tp.effect, tp.tools,
+	tp.emails, tp.domains, tp.claims `
+
+// GetToolPoliciesForProject returns the policies of the project in the order they are evaluated
+func GetToolPoliciesForProject(ctx context.Context, projectID uuid.UUID) ([]types.ToolPolicy, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+toolPolicyOutExpr+`
+		FROM ToolPolicy tp
+		WHERE tp.project_id = @projectId
+		ORDER BY tp.priority, tp.created_at`,
+		pgx.NamedArgs{"projectId": projectID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[types.ToolPolicy])
+}
+
+func GetToolPolicy(ctx context.Context, id uuid.UUID) (*types.ToolPolicy, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT `+toolPolicyOutExpr+` FROM ToolPolicy tp WHERE tp.id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.ToolPolicy])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return result, err
+}
+
+func CreateToolPolicy(ctx context.Context, policy *types.ToolPolicy) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO ToolPolicy AS tp (created_by, project_id, name, priority, effect, tools, emails, domains, claims)
+		VALUES (@createdBy, @projectId, @name, @priority, @effect, @tools, COALESCE(@emails::TEXT[], '{}'),
+			COALESCE(@domains::TEXT[], '{}'), @claims)
+		RETURNING `+toolPolicyOutExpr,
+		pgx.NamedArgs{
+			"createdBy": policy.CreatedBy,
+			"projectId": policy.ProjectID,
+			"name":      policy.Name,
+			"priority":  policy.Priority,
+			"effect":    policy.Effect,
+			"tools":     policy.Tools,
+			"emails":    policy.Emails,
+			"domains":   policy.Domains,
+			"claims":    policy.Claims,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query ToolPolicy: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.ToolPolicy]); err != nil {
+		return fmt.Errorf("failed to scan ToolPolicy: %w", err)
+	} else {
+		*policy = result
+		return nil
+	}
+}
+
+func UpdateToolPolicy(ctx context.Context, policy *types.ToolPolicy) error {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`UPDATE ToolPolicy AS tp
+			SET name = @name, priority = @priority, effect = @effect, tools = @tools,
+				emails = COALESCE(@emails::TEXT[], '{}'), domains = COALESCE(@domains::TEXT[], '{}'),
+				claims = @claims
+		WHERE id = @id
+		RETURNING `+toolPolicyOutExpr,
+		pgx.NamedArgs{
+			"id":       policy.ID,
+			"name":     policy.Name,
+			"priority": policy.Priority,
+			"effect":   policy.Effect,
+			"tools":    policy.Tools,
+			"emails":   policy.Emails,
+			"domains":  policy.Domains,
+			"claims":   policy.Claims,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query ToolPolicy: %w", err)
+	}
+	if result, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[types.ToolPolicy]); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = apierrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to scan ToolPolicy: %w", err)
+	} else {
+		*policy = result
+		return nil
+	}
+}
+
+func DeleteToolPolicy(ctx context.Context, id uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	if res, err := db.Exec(ctx, `DELETE FROM ToolPolicy WHERE id = @id`, pgx.NamedArgs{"id": id}); err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	} else {
+		return nil
+	}
+}
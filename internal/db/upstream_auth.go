@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/secrets"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/jackc/pgx/v5"
+)
+
+const upstreamAuthOutExpr = ` ua.project_id, ua.created_at, ua.updated_at, ua.type, ua.header_name, ua.token_url,
+	ua.client_id, ua.scopes, ua.secret_encrypted `
+
+type upstreamAuthRow struct {
+	types.UpstreamAuth
+	SecretEncrypted []byte `db:"secret_encrypted"`
+}
+
+func (row *upstreamAuthRow) decrypt() (*types.UpstreamAuth, error) {
+	if secret, err := secrets.Decrypt(row.SecretEncrypted); err != nil {
+		return nil, fmt.Errorf("failed to decrypt UpstreamAuth of project %v: %w", row.ProjectID, err)
+	} else {
+		result := row.UpstreamAuth
+		result.Secret = &secret
+		return &result, nil
+	}
+}
+
+// GetUpstreamAuth returns the upstream auth settings of the project with the decrypted secret or
+// apierrors.ErrNotFound if the project has none
+func GetUpstreamAuth(ctx context.Context, projectID uuid.UUID) (*types.UpstreamAuth, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`SELECT `+upstreamAuthOutExpr+` FROM UpstreamAuth ua WHERE ua.project_id = @projectId`,
+		pgx.NamedArgs{"projectId": projectID},
+	)
+	if err != nil {
+		return nil, err
+	}
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[upstreamAuthRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return row.decrypt()
+}
+
+// SetUpstreamAuth creates or replaces the upstream auth settings of the project. Secret must be set.
+func SetUpstreamAuth(ctx context.Context, auth *types.UpstreamAuth) error {
+	if auth.Secret == nil {
+		return errors.New("UpstreamAuth has no secret")
+	}
+	secretEncrypted, err := secrets.Encrypt(*auth.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt UpstreamAuth: %w", err)
+	}
+
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(
+		ctx,
+		`INSERT INTO UpstreamAuth AS ua (project_id, type, header_name, token_url, client_id, scopes, secret_encrypted)
+		VALUES (@projectId, @type, @headerName, @tokenUrl, @clientId, COALESCE(@scopes::TEXT[], '{}'), @secretEncrypted)
+		ON CONFLICT (project_id) DO UPDATE
+			SET updated_at = current_timestamp, type = excluded.type, header_name = excluded.header_name,
+				token_url = excluded.token_url, client_id = excluded.client_id, scopes = excluded.scopes,
+				secret_encrypted = excluded.secret_encrypted
+		RETURNING `+upstreamAuthOutExpr,
+		pgx.NamedArgs{
+			"projectId":       auth.ProjectID,
+			"type":            auth.Type,
+			"headerName":      auth.HeaderName,
+			"tokenUrl":        auth.TokenURL,
+			"clientId":        auth.ClientID,
+			"scopes":          auth.Scopes,
+			"secretEncrypted": secretEncrypted,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query UpstreamAuth: %w", err)
+	}
+	if row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[upstreamAuthRow]); err != nil {
+		return fmt.Errorf("failed to scan UpstreamAuth: %w", err)
+	} else {
+		secret := auth.Secret
+		*auth = row.UpstreamAuth
+		auth.Secret = secret
+		return nil
+	}
+}
+
+func DeleteUpstreamAuth(ctx context.Context, projectID uuid.UUID) error {
+	db := internalctx.GetDb(ctx)
+	cmd, err := db.Exec(ctx, `DELETE FROM UpstreamAuth WHERE project_id = @projectId`, pgx.NamedArgs{"projectId": projectID})
+	if err != nil {
+		return err
+	} else if cmd.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
+	} else {
+		return nil
+	}
+}
@@ -39,20 +39,54 @@ func CreateUser(ctx context.Context, email string) (*types.UserAccount, error) {
 	}
 }
 
-func AddUserToOrganization(ctx context.Context, userID, orgID uuid.UUID) error {
+// AddUserToOrganization adds the user as a member with the given role. It returns apierrors.ErrAlreadyExists if the
+// user is already a member of the organization.
+func AddUserToOrganization(ctx context.Context, userID, orgID uuid.UUID, role types.OrganizationRole) error {
 	db := internalctx.GetDb(ctx)
-	_, err := db.Exec(ctx, `
-		INSERT INTO Organization_UserAccount (organization_id, user_account_id)
-		VALUES (@orgID, @userID)
+	res, err := db.Exec(ctx, `
+		INSERT INTO Organization_UserAccount (organization_id, user_account_id, role)
+		VALUES (@orgID, @userID, @role)
 		ON CONFLICT (organization_id, user_account_id) DO NOTHING
-	`, pgx.NamedArgs{"orgID": orgID, "userID": userID})
+	`, pgx.NamedArgs{"orgID": orgID, "userID": userID, "role": role})
+	if err != nil {
+		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrAlreadyExists
+	} else {
+		return nil
+	}
+}
+
+func UpdateOrganizationMemberRole(ctx context.Context, userID, orgID uuid.UUID, role types.OrganizationRole) error {
+	db := internalctx.GetDb(ctx)
+	res, err := db.Exec(ctx, `
+		UPDATE Organization_UserAccount SET role = @role
+		WHERE user_account_id = @userID AND organization_id = @orgID
+	`, pgx.NamedArgs{"orgID": orgID, "userID": userID, "role": role})
 	if err != nil {
 		return err
+	} else if res.RowsAffected() == 0 {
+		return apierrors.ErrNotFound
 	} else {
 		return nil
 	}
 }
 
+// LockOrganizationOwners returns the IDs of all owners of the organization and locks their memberships until the end
+// of the current transaction, so that concurrent changes can not remove the last owner.
+func LockOrganizationOwners(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `
+		SELECT user_account_id FROM Organization_UserAccount
+		WHERE organization_id = @orgID AND role = 'owner'
+		FOR UPDATE
+	`, pgx.NamedArgs{"orgID": orgID})
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
+
 func RemoveUserFromOrganization(ctx context.Context, userID, orgID uuid.UUID) error {
 	db := internalctx.GetDb(ctx)
 	_, err := db.Exec(ctx, `
@@ -65,6 +99,19 @@ func RemoveUserFromOrganization(ctx context.Context, userID, orgID uuid.UUID) er
 	}
 }
 
+func GetUserByID(ctx context.Context, id uuid.UUID) (*types.UserAccount, error) {
+	db := internalctx.GetDb(ctx)
+	rows, err := db.Query(ctx, `SELECT `+userOutExpr+` FROM UserAccount u WHERE u.id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	user, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[types.UserAccount])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierrors.ErrNotFound
+	}
+	return user, err
+}
+
 func GetUserByEmail(ctx context.Context, email string) (*types.UserAccount, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(ctx, `
@@ -109,49 +156,56 @@ func GetUserByEmailOrCreate(ctx context.Context, email string) (*types.UserAccou
 	return user, nil
 }
 
-func IsUserPartOfOrg(ctx context.Context, userID, orgID uuid.UUID) (bool, *types.Organization, error) {
+// GetOrganizationRole returns the organization and the role of the user in it. The role is nil if the user is not a
+// member of the organization.
+func GetOrganizationRole(
+	ctx context.Context,
+	userID, orgID uuid.UUID,
+) (*types.OrganizationRole, *types.Organization, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(ctx, `
 		SELECT
-			EXISTS (
-				SELECT * FROM Organization_UserAccount
+			(
+				SELECT role FROM Organization_UserAccount
 				WHERE user_account_id = @userID AND organization_id = @orgID
 			), `+
 		`(`+organizationOutputExpr+`)
 		FROM Organization o
 		WHERE o.id = @orgID`, pgx.NamedArgs{"userID": userID, "orgID": orgID})
 	if err != nil {
-		return false, nil, err
+		return nil, nil, err
 	}
 	res, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByPos[struct {
-		Exists       bool
+		Role         *types.OrganizationRole
 		Organization types.Organization
 	}])
-	if err != nil {
-		return false, nil, err
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, apierrors.ErrNotFound
+	} else if err != nil {
+		return nil, nil, err
 	}
-	return res.Exists, &res.Organization, nil
+	return res.Role, &res.Organization, nil
 }
 
-func CanUserAccessProject(ctx context.Context, userID, projectID uuid.UUID) (bool, error) {
+// GetProjectRole returns the role of the user in the organization of the project or nil if the user can not access
+// the project
+func GetProjectRole(ctx context.Context, userID, projectID uuid.UUID) (*types.OrganizationRole, error) {
 	db := internalctx.GetDb(ctx)
 	rows, err := db.Query(ctx, `
-		SELECT EXISTS (
-			SELECT *
-			FROM Organization_UserAccount oua
-			INNER JOIN Project p ON p.organization_id = oua.organization_id
-			WHERE oua.user_account_id = @userID AND p.id = @projectID
-		)`, pgx.NamedArgs{"userID": userID, "projectID": projectID})
+		SELECT oua.role
+		FROM Organization_UserAccount oua
+		INNER JOIN Project p ON p.organization_id = oua.organization_id
+		WHERE oua.user_account_id = @userID AND p.id = @projectID`, pgx.NamedArgs{"userID": userID, "projectID": projectID})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	res, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByPos[struct {
-		Exists bool
-	}])
-	if err != nil {
-		return false, err
+	role, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[types.OrganizationRole])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
-	return res.Exists, nil
+	return &role, nil
 }
 
 func GetAllUsers(ctx context.Context) ([]types.UserAccount, error) {
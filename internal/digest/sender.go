@@ -0,0 +1,113 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/analytics"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/db/queryable"
+	"github.com/hyprmcp/jetski/internal/mailsending"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+)
+
+// Sender periodically sends usage digests to all organization members whose digest is due according to their
+// preferred digest frequency.
+type Sender struct {
+	logger   *zap.Logger
+	db       queryable.Queryable
+	interval time.Duration
+}
+
+func NewSender(logger *zap.Logger, db queryable.Queryable, interval time.Duration) *Sender {
+	return &Sender{logger: logger, db: db, interval: interval}
+}
+
+// Run sends all digests that are due every interval until ctx is canceled
+func (s *Sender) Run(ctx context.Context) {
+	ctx = internalctx.WithDb(ctx, s.db)
+	ctx = internalctx.WithLogger(ctx, s.logger)
+
+	s.logger.Info("starting digest sender", zap.Duration("interval", s.interval))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.sendDueDigests(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("stopping digest sender")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type digestKey struct {
+	organizationID uuid.UUID
+	frequency      types.DigestFrequency
+}
+
+func (s *Sender) sendDueDigests(ctx context.Context) {
+	// all members of an organization with the same frequency receive the same digest, so it is only calculated once
+	// per run
+	now := time.Now()
+	digests := make(map[digestKey]*types.OrganizationDigest)
+
+	for ctx.Err() == nil {
+		err := db.RunTx(ctx, func(ctx context.Context) error { return s.sendNextDigest(ctx, now, digests) })
+		if errors.Is(err, apierrors.ErrNotFound) {
+			return
+		} else if err != nil {
+			s.logger.Error("sending digest failed", zap.Error(err))
+			sentry.CaptureException(err)
+			return
+		}
+	}
+}
+
+// sendNextDigest locks the next recipient whose digest is due and sends the digest. It returns apierrors.ErrNotFound
+// if there is no such recipient.
+func (s *Sender) sendNextDigest(
+	ctx context.Context,
+	now time.Time,
+	digests map[digestKey]*types.OrganizationDigest,
+) error {
+	recipient, err := db.LockNextDigestRecipient(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	key := digestKey{organizationID: recipient.Organization.ID, frequency: recipient.DigestFrequency}
+	digest, ok := digests[key]
+	if !ok {
+		digest, err = analytics.GetOrganizationDigest(
+			ctx,
+			recipient.Organization.ID,
+			now.Add(-recipient.DigestFrequency.Period()),
+			now,
+		)
+		if err != nil {
+			return err
+		}
+		digests[key] = digest
+	}
+
+	// organizations without projects have nothing to report, but the digest is still marked as sent so that the
+	// recipient isn't checked again until the next period
+	if len(digest.Projects) > 0 {
+		if err := mailsending.SendDigestMail(ctx, recipient.UserAccount, recipient.Organization, *digest); err != nil {
+			return err
+		}
+	}
+
+	return db.SetDigestSent(ctx, recipient.Organization.ID, recipient.UserAccount.ID, now)
+}
@@ -0,0 +1,85 @@
+package domainverification
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+)
+
+// RecordPrefix is prepended to the custom domain to get the name of the TXT record that must contain the token
+const RecordPrefix = "_jetski-challenge"
+
+// Resolver looks up the TXT records of a domain name. It is implemented by net.Resolver and can be replaced in tests.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// RecordName returns the name of the TXT record for the custom domain
+func RecordName(domain string) string {
+	return RecordPrefix + "." + domain
+}
+
+// HasToken returns whether the TXT record of the domain contains the token. A record that does not exist is not an
+// error, all other lookup failures are returned, because the state of the record is unknown.
+func HasToken(ctx context.Context, resolver Resolver, domain, token string) (bool, error) {
+	records, err := resolver.LookupTXT(ctx, RecordName(domain))
+	if dnsErr := (*net.DNSError)(nil); errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(records, func(record string) bool { return strings.TrimSpace(record) == token }), nil
+}
+
+// Check looks up the TXT record of the custom domain and updates the verification accordingly (see Lookup and
+// Update). The verification is not changed if the lookup fails.
+func Check(
+	ctx context.Context,
+	resolver Resolver,
+	settings *types.OrganizationSettings,
+	now time.Time,
+	gracePeriod time.Duration,
+) (bool, error) {
+	found, err := Lookup(ctx, resolver, *settings)
+	if err != nil {
+		return false, err
+	}
+	return Update(settings, found, now, gracePeriod), nil
+}
+
+// Lookup returns whether the TXT record of the custom domain contains the verification token. It does not change the
+// settings, so that the lookup can be done before the organization is locked.
+func Lookup(ctx context.Context, resolver Resolver, settings types.OrganizationSettings) (bool, error) {
+	if settings.CustomDomain == nil || settings.CustomDomainVerification.Token == nil {
+		return false, errors.New("organization has no custom domain to verify")
+	}
+	return HasToken(ctx, resolver, *settings.CustomDomain, *settings.CustomDomainVerification.Token)
+}
+
+// Update updates the verification with the result of a lookup. A verified domain only loses its verification if the
+// record has not been found for longer than the grace period. It returns whether the verified state has changed.
+func Update(settings *types.OrganizationSettings, found bool, now time.Time, gracePeriod time.Duration) bool {
+	verification := &settings.CustomDomainVerification
+	wasVerified := verification.Verified
+	verification.CheckedAt = &now
+	if found {
+		verification.Verified = true
+		verification.VerifiedAt = &now
+	} else if verification.VerifiedAt == nil || now.Sub(*verification.VerifiedAt) > gracePeriod {
+		verification.Verified = false
+	}
+	return wasVerified != verification.Verified
+}
+
+// SameDomain returns whether both settings have the same custom domain and token, i.e. whether the result of a lookup
+// for one of them is valid for the other
+func SameDomain(a, b types.OrganizationSettings) bool {
+	return util.PtrEq(a.CustomDomain, b.CustomDomain) &&
+		util.PtrEq(a.CustomDomainVerification.Token, b.CustomDomainVerification.Token)
+}
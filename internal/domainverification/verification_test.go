@@ -0,0 +1,84 @@
+package domainverification
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+)
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if name == RecordName("broken.example.com") {
+		return nil, &net.DNSError{Err: "server misbehaving", Name: name, IsTemporary: true}
+	} else if records, ok := r[name]; ok {
+		return records, nil
+	} else {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+}
+
+func TestHasToken(t *testing.T) {
+	resolver := fakeResolver{
+		"_jetski-challenge.mcp.example.com":   {"other", " token "},
+		"_jetski-challenge.wrong.example.com": {"other"},
+	}
+	expect := func(domain string, expected bool, expectErr bool) {
+		found, err := HasToken(context.Background(), resolver, domain, "token")
+		if (err != nil) != expectErr {
+			t.Errorf("%v: unexpected error %v", domain, err)
+		} else if found != expected {
+			t.Errorf("%v: expected %v, got %v", domain, expected, found)
+		}
+	}
+
+	expect("mcp.example.com", true, false)
+	expect("wrong.example.com", false, false)
+	expect("missing.example.com", false, false)
+	expect("broken.example.com", false, true)
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Now()
+	gracePeriod := 72 * time.Hour
+	resolver := fakeResolver{"_jetski-challenge.mcp.example.com": {"token"}}
+	settings := func(domain string, verifiedAt *time.Time) types.OrganizationSettings {
+		return types.OrganizationSettings{
+			CustomDomain: &domain,
+			CustomDomainVerification: types.OrganizationCustomDomainVerification{
+				Token:      util.PtrTo("token"),
+				Verified:   verifiedAt != nil,
+				VerifiedAt: verifiedAt,
+			},
+		}
+	}
+	expect := func(name string, s types.OrganizationSettings, expectVerified, expectChanged bool) {
+		changed, err := Check(context.Background(), resolver, &s, now, gracePeriod)
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", name, err)
+		} else if s.CustomDomainVerification.Verified != expectVerified || changed != expectChanged {
+			t.Errorf("%v: expected verified=%v changed=%v, got verified=%v changed=%v",
+				name, expectVerified, expectChanged, s.CustomDomainVerification.Verified, changed)
+		} else if !util.PtrEq(s.CustomDomainVerification.CheckedAt, &now) {
+			t.Errorf("%v: expected checkedAt to be set", name)
+		}
+	}
+
+	expect("record found", settings("mcp.example.com", nil), true, true)
+	expect("record still found", settings("mcp.example.com", util.PtrTo(now.Add(-time.Hour))), true, false)
+	expect("record not found", settings("missing.example.com", nil), false, false)
+	expect("within grace period", settings("missing.example.com", util.PtrTo(now.Add(-time.Hour))), true, false)
+	expect("grace period exceeded", settings("missing.example.com", util.PtrTo(now.Add(-gracePeriod-1))), false, true)
+
+	s := settings("broken.example.com", util.PtrTo(now.Add(-gracePeriod-1)))
+	if _, err := Check(context.Background(), resolver, &s, now, gracePeriod); err == nil {
+		t.Error("expected error for failed lookup")
+	} else if dnsErr := (*net.DNSError)(nil); !errors.As(err, &dnsErr) || !s.CustomDomainVerification.Verified {
+		t.Errorf("expected the verification to be unchanged after a failed lookup, got %v", err)
+	}
+}
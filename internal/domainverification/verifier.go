@@ -0,0 +1,150 @@
+package domainverification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/db/queryable"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+)
+
+type gatewayApplier interface {
+	Apply(ctx context.Context, org types.Organization) error
+}
+
+// Verifier periodically checks the TXT records of all custom domains and applies the gateway of every organization
+// whose domain has been verified or has lost its verification.
+type Verifier struct {
+	logger         *zap.Logger
+	db             queryable.Queryable
+	resolver       Resolver
+	gatewayApplier gatewayApplier
+	interval       time.Duration
+	gracePeriod    time.Duration
+}
+
+func NewVerifier(
+	logger *zap.Logger,
+	db queryable.Queryable,
+	resolver Resolver,
+	gatewayApplier gatewayApplier,
+	interval time.Duration,
+	gracePeriod time.Duration,
+) *Verifier {
+	return &Verifier{
+		logger:         logger,
+		db:             db,
+		resolver:       resolver,
+		gatewayApplier: gatewayApplier,
+		interval:       interval,
+		gracePeriod:    gracePeriod,
+	}
+}
+
+// Run checks all custom domains every interval until ctx is canceled
+func (v *Verifier) Run(ctx context.Context) {
+	ctx = internalctx.WithDb(ctx, v.db)
+	ctx = internalctx.WithLogger(ctx, v.logger)
+
+	v.logger.Info("starting domain verifier", zap.Duration("interval", v.interval))
+
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		v.checkDomains(ctx)
+
+		select {
+		case <-ctx.Done():
+			v.logger.Info("stopping domain verifier")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *Verifier) checkDomains(ctx context.Context) {
+	start := time.Now()
+	for ctx.Err() == nil {
+		org, err := db.GetNextOrganizationForDomainVerification(ctx, start)
+		if errors.Is(err, apierrors.ErrNotFound) {
+			return
+		} else if err != nil {
+			v.logger.Error("domain verification failed", zap.Error(err))
+			sentry.CaptureException(err)
+			return
+		}
+
+		// the lookup can take a while, so it is done before the organization is locked
+		found, lookupErr := Lookup(ctx, v.resolver, org.Settings)
+
+		var changed *types.Organization
+		err = db.RunTx(ctx, func(ctx context.Context) (err error) {
+			changed, err = v.updateDomain(ctx, *org, found, lookupErr, start)
+			return
+		})
+		if err != nil {
+			v.logger.Error("domain verification failed", zap.Error(err))
+			sentry.CaptureException(err)
+			return
+		}
+
+		// the gateway is applied after the transaction, so that the sync hook sees the new state
+		if changed != nil {
+			if err := v.gatewayApplier.Apply(ctx, *changed); err != nil {
+				v.logger.Error("failed to apply gateway after domain verification changed",
+					zap.String("organization", changed.Name), zap.Error(err))
+			}
+		}
+	}
+}
+
+// updateDomain locks the organization and updates its verification with the result of the lookup. The result is
+// discarded if the domain has been changed or checked by another instance since the lookup. It returns the
+// organization if the verified state has changed.
+func (v *Verifier) updateDomain(
+	ctx context.Context,
+	lookedUp types.Organization,
+	found bool,
+	lookupErr error,
+	start time.Time,
+) (*types.Organization, error) {
+	org, err := db.LockOrganization(ctx, lookedUp.ID)
+	if errors.Is(err, apierrors.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if !SameDomain(org.Settings, lookedUp.Settings) {
+		return nil, nil
+	} else if checkedAt := org.Settings.CustomDomainVerification.CheckedAt; checkedAt != nil && !checkedAt.Before(start) {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	changed := false
+	if lookupErr != nil {
+		// the domain is checked again in the next run
+		v.logger.Warn("failed to look up TXT record",
+			zap.String("organization", org.Name), zap.Stringp("domain", org.Settings.CustomDomain), zap.Error(lookupErr))
+		org.Settings.CustomDomainVerification.CheckedAt = &now
+	} else if changed = Update(&org.Settings, found, now, v.gracePeriod); changed {
+		v.logger.Info("custom domain verification changed",
+			zap.String("organization", org.Name),
+			zap.Stringp("domain", org.Settings.CustomDomain),
+			zap.Bool("verified", org.Settings.CustomDomainVerification.Verified))
+	}
+
+	if err := db.UpdateOrganization(ctx, org); err != nil {
+		return nil, err
+	} else if changed {
+		return org, nil
+	} else {
+		return nil, nil
+	}
+}
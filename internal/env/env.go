@@ -1,11 +1,14 @@
 package env
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
+	"github.com/hyprmcp/jetski/internal/buildconfig"
 	"github.com/hyprmcp/jetski/internal/envparse"
 	"github.com/hyprmcp/jetski/internal/envutil"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var (
@@ -14,6 +17,12 @@ var (
 	databaseUrl                   string
 	oidcUrl                       string
 	oidcClientID                  string
+	oidcAdditionalIssuers         []string
+	oidcAllowedAudiences          []string
+	oidcGatewayAudiences          []string
+	oidcClockSkew                 time.Duration
+	oidcRequireEmailVerified      bool
+	devAuthSecret                 *[]byte
 	dexGRPCAddr                   string
 	databaseMaxConns              *int
 	mailerConfig                  MailerConfig
@@ -30,13 +39,33 @@ var (
 	frontendPosthogUIHost         *string
 	serverShutdownDelayDuration   *time.Duration
 	gatewayContainerImageTag      string
+	gatewayToolPoliciesEnabled    bool
+	gatewayContextEnabled         bool
+	gatewayPromptArgumentEnabled  bool
+	gatewayUpstreamAuthEnabled    bool
 	gatewayWebhookURL             string
 	gatewayNamespace              string
 	gatewayIngressClass           string
 	gatewayIngressAnnotations     map[string]string
+	gatewayRoutingMode            GatewayRoutingModeString
+	gatewayHTTPRouteParent        GatewayHTTPRouteParentConfig
+	gatewayCertManagerIssuer      *GatewayCertManagerIssuerConfig
 	gatewayHostFormat             string = "%v.hyprmcp.cloud"
 	gatewayPathFormat             string = "/%v/mcp"
 	gatewayHostScheme             string = "https"
+	hostedServerPath              string = "/mcp"
+	gatewayScaling                GatewayScalingConfig
+	alertEvaluationInterval       time.Duration
+	notificationDispatchInterval  time.Duration
+	digestCheckInterval           time.Duration
+	mailDispatchInterval          time.Duration
+	domainVerificationInterval    time.Duration
+	promptReindexInterval         time.Duration
+	domainVerificationGracePeriod time.Duration
+	gatewayReconcileInterval      time.Duration
+	invitationSigningKey          []byte
+	gatewayWebhookSigningKey      []byte
+	secretEncryptionKey           []byte
 )
 
 func Initialize() {
@@ -45,7 +74,23 @@ func Initialize() {
 	databaseUrl = envutil.RequireEnv("DATABASE_URL")
 	oidcUrl = envutil.RequireEnv("OIDC_URL")
 	oidcClientID = envutil.RequireEnv("OIDC_CLIENT_ID")
+	oidcAdditionalIssuers = envutil.GetEnvParsedOrDefault("OIDC_ADDITIONAL_ISSUERS", envparse.StringList, nil)
+	oidcAllowedAudiences = envutil.GetEnvParsedOrDefault(
+		"OIDC_ALLOWED_AUDIENCES",
+		envparse.StringList,
+		[]string{oidcClientID},
+	)
+	oidcGatewayAudiences = envutil.GetEnvParsedOrDefault("OIDC_GATEWAY_AUDIENCES", envparse.StringList, nil)
+	oidcClockSkew = envutil.GetEnvParsedOrDefault("OIDC_CLOCK_SKEW", envparse.NonNegativeDuration, 30*time.Second)
+	oidcRequireEmailVerified = envutil.GetEnvParsedOrDefault("OIDC_REQUIRE_EMAIL_VERIFIED", strconv.ParseBool, true)
+	devAuthSecret = envutil.GetEnvParsedOrNil("DEV_AUTH_SECRET", parseDevAuthSecret)
+	if devAuthSecret != nil && buildconfig.IsRelease() {
+		panic(errors.New("DEV_AUTH_SECRET must not be set in release builds"))
+	}
 	dexGRPCAddr = envutil.RequireEnv("DEX_GRPC_ADDR")
+	invitationSigningKey = []byte(envutil.RequireEnv("INVITATION_SIGNING_KEY"))
+	gatewayWebhookSigningKey = []byte(envutil.RequireEnv("GATEWAY_WEBHOOK_SIGNING_KEY"))
+	secretEncryptionKey = envutil.RequireEnvParsed("SECRET_ENCRYPTION_KEY", parseSecretEncryptionKey)
 	databaseMaxConns = envutil.GetEnvParsedOrNil("DATABASE_MAX_CONNS", strconv.Atoi)
 	enableQueryLogging = envutil.GetEnvParsedOrDefault("ENABLE_QUERY_LOGGING", strconv.ParseBool, false)
 	serverShutdownDelayDuration = envutil.GetEnvParsedOrNil("SERVER_SHUTDOWN_DELAY_DURATION", envparse.PositiveDuration)
@@ -79,6 +124,14 @@ func Initialize() {
 		"GATEWAY_CONTAINER_IMAGE_TAG",
 		"ghcr.io/jetski-sh/mcp-proxy:0.1.0-alpha.4",
 	)
+	gatewayToolPoliciesEnabled = envutil.GetEnvParsedOrDefault("GATEWAY_TOOL_POLICIES_ENABLED", strconv.ParseBool, false)
+	gatewayContextEnabled = envutil.GetEnvParsedOrDefault("GATEWAY_CONTEXT_ENABLED", strconv.ParseBool, false)
+	gatewayUpstreamAuthEnabled = envutil.GetEnvParsedOrDefault("GATEWAY_UPSTREAM_AUTH_ENABLED", strconv.ParseBool, false)
+	gatewayPromptArgumentEnabled = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_PROMPT_ARGUMENT_NAME_ENABLED",
+		strconv.ParseBool,
+		false,
+	)
 	gatewayWebhookURL = envutil.GetEnvOrDefault("GATEWAY_WEBHOOK_URL", "http://host.minikube.internal:8085/sync")
 	gatewayNamespace = envutil.GetEnvOrDefault("GATEWAY_NAMESPACE", "default")
 	gatewayIngressClass = envutil.GetEnv("GATEWAY_INGRESS_CLASS")
@@ -87,9 +140,123 @@ func Initialize() {
 		parseYAMLMap,
 		map[string]string{},
 	)
+	gatewayRoutingMode = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_ROUTING_MODE",
+		parseGatewayRoutingMode,
+		GatewayRoutingModeIngress,
+	)
+	if gatewayRoutingMode == GatewayRoutingModeHTTPRoute {
+		gatewayHTTPRouteParent = GatewayHTTPRouteParentConfig{
+			Name:        envutil.RequireEnv("GATEWAY_HTTPROUTE_PARENT_NAME"),
+			Namespace:   envutil.GetEnv("GATEWAY_HTTPROUTE_PARENT_NAMESPACE"),
+			SectionName: envutil.GetEnv("GATEWAY_HTTPROUTE_PARENT_SECTION_NAME"),
+		}
+	}
+	if issuerName := envutil.GetEnv("GATEWAY_CERT_MANAGER_ISSUER_NAME"); issuerName != "" {
+		// with HTTPRoutes, TLS is terminated by the parent Gateway, so a certificate would not be used by anything
+		if gatewayRoutingMode == GatewayRoutingModeHTTPRoute {
+			panic(errors.New("GATEWAY_CERT_MANAGER_ISSUER_NAME must not be set if GATEWAY_ROUTING_MODE is httproute"))
+		}
+		gatewayCertManagerIssuer = &GatewayCertManagerIssuerConfig{
+			Name: issuerName,
+			Kind: envutil.GetEnvOrDefault("GATEWAY_CERT_MANAGER_ISSUER_KIND", "ClusterIssuer"),
+		}
+	}
 	gatewayHostFormat = envutil.GetEnvOrDefault("GATEWAY_HOST_FORMAT", gatewayHostFormat)
 	gatewayPathFormat = envutil.GetEnvOrDefault("GATEWAY_PATH_FORMAT", gatewayPathFormat)
 	gatewayHostScheme = envutil.GetEnvOrDefault("GATEWAY_HOST_SCHEME", gatewayHostScheme)
+	hostedServerPath = envutil.GetEnvOrDefault("HOSTED_SERVER_PATH", hostedServerPath)
+
+	gatewayScaling.Replicas = envutil.GetEnvParsedOrDefault("GATEWAY_REPLICAS", parseInt32, 1)
+	gatewayScaling.CPURequest = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_CPU_REQUEST",
+		resource.ParseQuantity,
+		resource.MustParse("5m"),
+	)
+	gatewayScaling.CPULimit = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_CPU_LIMIT",
+		resource.ParseQuantity,
+		resource.MustParse("500m"),
+	)
+	gatewayScaling.MemoryRequest = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_MEMORY_REQUEST",
+		resource.ParseQuantity,
+		resource.MustParse("16Mi"),
+	)
+	gatewayScaling.MemoryLimit = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_MEMORY_LIMIT",
+		resource.ParseQuantity,
+		resource.MustParse("128Mi"),
+	)
+	gatewayScaling.AutoscalingEnabled = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_AUTOSCALING_ENABLED",
+		strconv.ParseBool,
+		false,
+	)
+	gatewayScaling.AutoscalingMinReplicas = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_AUTOSCALING_MIN_REPLICAS",
+		parseInt32,
+		1,
+	)
+	gatewayScaling.AutoscalingMaxReplicas = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_AUTOSCALING_MAX_REPLICAS",
+		parseInt32,
+		3,
+	)
+	gatewayScaling.AutoscalingTargetCPUUtilization = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_AUTOSCALING_TARGET_CPU_UTILIZATION",
+		parseInt32,
+		80,
+	)
+	gatewayScaling.MinAvailable = envutil.GetEnvParsedOrDefault("GATEWAY_MIN_AVAILABLE", parseInt32, 0)
+	gatewayScaling.MaxReplicas = envutil.GetEnvParsedOrDefault("GATEWAY_MAX_REPLICAS", parseInt32, 10)
+	gatewayScaling.MaxCPU = envutil.GetEnvParsedOrDefault("GATEWAY_MAX_CPU", resource.ParseQuantity, resource.MustParse("2"))
+	gatewayScaling.MaxMemory = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_MAX_MEMORY",
+		resource.ParseQuantity,
+		resource.MustParse("1Gi"),
+	)
+
+	alertEvaluationInterval = envutil.GetEnvParsedOrDefault(
+		"ALERT_EVALUATION_INTERVAL",
+		envparse.PositiveDuration,
+		1*time.Minute,
+	)
+	notificationDispatchInterval = envutil.GetEnvParsedOrDefault(
+		"NOTIFICATION_DISPATCH_INTERVAL",
+		envparse.PositiveDuration,
+		10*time.Second,
+	)
+	digestCheckInterval = envutil.GetEnvParsedOrDefault(
+		"DIGEST_CHECK_INTERVAL",
+		envparse.PositiveDuration,
+		1*time.Hour,
+	)
+	mailDispatchInterval = envutil.GetEnvParsedOrDefault(
+		"MAIL_DISPATCH_INTERVAL",
+		envparse.PositiveDuration,
+		10*time.Second,
+	)
+	promptReindexInterval = envutil.GetEnvParsedOrDefault(
+		"PROMPT_REINDEX_INTERVAL",
+		envparse.PositiveDuration,
+		10*time.Second,
+	)
+	domainVerificationInterval = envutil.GetEnvParsedOrDefault(
+		"DOMAIN_VERIFICATION_INTERVAL",
+		envparse.PositiveDuration,
+		1*time.Hour,
+	)
+	domainVerificationGracePeriod = envutil.GetEnvParsedOrDefault(
+		"DOMAIN_VERIFICATION_GRACE_PERIOD",
+		envparse.NonNegativeDuration,
+		72*time.Hour,
+	)
+	gatewayReconcileInterval = envutil.GetEnvParsedOrDefault(
+		"GATEWAY_RECONCILE_INTERVAL",
+		envparse.PositiveDuration,
+		5*time.Minute,
+	)
 }
 
 func Host() string {
@@ -112,6 +279,40 @@ func OIDCClientID() string {
 	return oidcClientID
 }
 
+// OIDCTrustedIssuers returns the URLs of all identity providers whose tokens are accepted by the API. The first
+// one is always OIDCUrl.
+func OIDCTrustedIssuers() []string {
+	return append([]string{oidcUrl}, oidcAdditionalIssuers...)
+}
+
+// OIDCAllowedAudiences returns the client IDs that API tokens must be issued for. Defaults to OIDCClientID.
+func OIDCAllowedAudiences() []string {
+	return oidcAllowedAudiences
+}
+
+// OIDCGatewayAudiences returns the client IDs that tokens of MCP gateway sessions must be issued for. If it is empty,
+// tokens of all clients are accepted, because the gateway registers its clients dynamically.
+func OIDCGatewayAudiences() []string {
+	return oidcGatewayAudiences
+}
+
+func OIDCClockSkew() time.Duration {
+	return oidcClockSkew
+}
+
+func OIDCRequireEmailVerified() bool {
+	return oidcRequireEmailVerified
+}
+
+// DevAuthSecret returns the key that is used to sign and verify tokens in the offline development auth mode or nil if
+// the mode is disabled. It is always nil in release builds.
+func DevAuthSecret() []byte {
+	if devAuthSecret == nil || buildconfig.IsRelease() {
+		return nil
+	}
+	return *devAuthSecret
+}
+
 func DexGRPCAddr() string {
 	return dexGRPCAddr
 }
@@ -180,6 +381,30 @@ func GatewayContainerImageTag() string {
 	return gatewayContainerImageTag
 }
 
+// GatewayToolPoliciesEnabled returns true if tool policies are rendered into the gateway configuration. It must only
+// be enabled if the gateway image supports tool policies, older gateways reject configurations that contain them.
+func GatewayToolPoliciesEnabled() bool {
+	return gatewayToolPoliciesEnabled
+}
+
+// GatewayContextEnabled returns true if the context webhook is rendered into the gateway configuration. It must only
+// be enabled if the gateway image supports the context webhook.
+func GatewayContextEnabled() bool {
+	return gatewayContextEnabled
+}
+
+// GatewayUpstreamAuthEnabled returns true if the upstream auth settings of proxied projects are rendered into the
+// gateway configuration. It must only be enabled if the gateway image supports authenticating to upstream servers.
+func GatewayUpstreamAuthEnabled() bool {
+	return gatewayUpstreamAuthEnabled
+}
+
+// GatewayPromptArgumentNameEnabled returns true if custom prompt argument names are rendered into the gateway
+// configuration. It must only be enabled if the gateway image supports configuring the prompt argument name.
+func GatewayPromptArgumentNameEnabled() bool {
+	return gatewayPromptArgumentEnabled
+}
+
 func GatewayNamespace() string {
 	return gatewayNamespace
 }
@@ -196,6 +421,24 @@ func GatewayIngressAnnotations() map[string]string {
 	return gatewayIngressAnnotations
 }
 
+// GatewayRoutingMode determines whether the gateways are exposed with an Ingress or a Gateway API HTTPRoute
+func GatewayRoutingMode() GatewayRoutingModeString {
+	return gatewayRoutingMode
+}
+
+// GatewayHTTPRouteParent is the parent Gateway of the HTTPRoutes. It is only set if GatewayRoutingMode is
+// GatewayRoutingModeHTTPRoute.
+func GatewayHTTPRouteParent() GatewayHTTPRouteParentConfig {
+	return gatewayHTTPRouteParent
+}
+
+// GatewayCertManagerIssuer is the issuer of the cert-manager certificates for custom domains or nil if certificates
+// are not managed by cert-manager. Certificates are only managed for Ingresses, with HTTPRoutes the listeners of the
+// parent Gateway must reference the certificates for custom domains.
+func GatewayCertManagerIssuer() *GatewayCertManagerIssuerConfig {
+	return gatewayCertManagerIssuer
+}
+
 func GatewayHostFormat() string {
 	return gatewayHostFormat
 }
@@ -207,3 +450,72 @@ func GatewayPathFormat() string {
 func GatewayHostScheme() string {
 	return gatewayHostScheme
 }
+
+// GatewayScaling contains the defaults for the scaling of gateways that organizations can override
+func GatewayScaling() GatewayScalingConfig {
+	return gatewayScaling
+}
+
+// HostedServerPath is the path of the MCP endpoint of hosted MCP servers
+func HostedServerPath() string {
+	return hostedServerPath
+}
+
+// AlertEvaluationInterval is the interval in which every enabled alert rule is evaluated
+func AlertEvaluationInterval() time.Duration {
+	return alertEvaluationInterval
+}
+
+// NotificationDispatchInterval is the interval in which pending notification deliveries are sent
+func NotificationDispatchInterval() time.Duration {
+	return notificationDispatchInterval
+}
+
+// DigestCheckInterval is the interval in which organization members are checked for due usage digests
+func DigestCheckInterval() time.Duration {
+	return digestCheckInterval
+}
+
+// MailDispatchInterval is the interval in which pending mails from the outbox are sent
+func MailDispatchInterval() time.Duration {
+	return mailDispatchInterval
+}
+
+// PromptReindexInterval is the interval in which the prompts of projects whose prompt argument name has been changed
+// are reindexed
+func PromptReindexInterval() time.Duration {
+	return promptReindexInterval
+}
+
+// DomainVerificationInterval is the interval in which the custom domains of organizations are verified again
+func DomainVerificationInterval() time.Duration {
+	return domainVerificationInterval
+}
+
+// DomainVerificationGracePeriod is the duration for which a verified custom domain stays verified while its TXT record
+// can not be found
+func DomainVerificationGracePeriod() time.Duration {
+	return domainVerificationGracePeriod
+}
+
+// GatewayReconcileInterval is the interval in which the gateways of all organizations are applied again to correct
+// drift between the database and the cluster
+func GatewayReconcileInterval() time.Duration {
+	return gatewayReconcileInterval
+}
+
+// InvitationSigningKey is the secret key that is used to sign the tokens of organization invitation links
+func InvitationSigningKey() []byte {
+	return invitationSigningKey
+}
+
+// GatewayWebhookSigningKey is the secret key that is used to sign the tokens in the webhook URLs that are called by
+// the MCP gateways
+func GatewayWebhookSigningKey() []byte {
+	return gatewayWebhookSigningKey
+}
+
+// SecretEncryptionKey is the AES-256 key that is used to encrypt the environment variables of hosted MCP servers
+func SecretEncryptionKey() []byte {
+	return secretEncryptionKey
+}
@@ -1,8 +1,43 @@
 package env
 
-import "gopkg.in/yaml.v3"
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+
+	"github.com/hyprmcp/jetski/internal/envparse"
+
+	"gopkg.in/yaml.v3"
+)
 
 func parseYAMLMap(input string) (result map[string]string, err error) {
 	err = yaml.Unmarshal([]byte(input), &result)
 	return
 }
+
+func parseDevAuthSecret(input string) ([]byte, error) {
+	if len(input) < 32 {
+		return nil, errors.New("must be at least 32 characters long")
+	}
+	return []byte(input), nil
+}
+
+func parseSecretEncryptionKey(input string) ([]byte, error) {
+	if key, err := base64.StdEncoding.DecodeString(input); err != nil {
+		return nil, err
+	} else if len(key) != 32 {
+		return nil, errors.New("must be 32 bytes encoded as base64")
+	} else {
+		return key, nil
+	}
+}
+
+func parseInt32(input string) (int32, error) {
+	if value, err := envparse.NonNegativeNumber(input); err != nil {
+		return 0, err
+	} else if value > math.MaxInt32 {
+		return 0, errors.New("number is too large")
+	} else {
+		return int32(value), nil
+	}
+}
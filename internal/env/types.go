@@ -3,6 +3,8 @@ package env
 import (
 	"fmt"
 	"net/mail"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 type MailerTypeString string
@@ -22,6 +24,37 @@ func parseMailerType(value string) (MailerTypeString, error) {
 	}
 }
 
+// GatewayRoutingModeString determines which resource routes external traffic to the gateways
+type GatewayRoutingModeString string
+
+const (
+	GatewayRoutingModeIngress   GatewayRoutingModeString = "ingress"
+	GatewayRoutingModeHTTPRoute GatewayRoutingModeString = "httproute"
+)
+
+func parseGatewayRoutingMode(value string) (GatewayRoutingModeString, error) {
+	switch value {
+	case string(GatewayRoutingModeIngress), string(GatewayRoutingModeHTTPRoute):
+		return GatewayRoutingModeString(value), nil
+	default:
+		return "", fmt.Errorf("invalid GatewayRoutingModeString: %v", value)
+	}
+}
+
+// GatewayHTTPRouteParentConfig references the Gateway that the HTTPRoutes of the gateways are attached to. Namespace
+// and SectionName are optional.
+type GatewayHTTPRouteParentConfig struct {
+	Name        string
+	Namespace   string
+	SectionName string
+}
+
+// GatewayCertManagerIssuerConfig references the cert-manager issuer of the certificates for custom domains
+type GatewayCertManagerIssuerConfig struct {
+	Name string
+	Kind string
+}
+
 type MailerConfig struct {
 	Type        MailerTypeString
 	FromAddress mail.Address
@@ -34,3 +67,22 @@ type MailerSMTPConfig struct {
 	Username string
 	Password string
 }
+
+// GatewayScalingConfig contains the platform defaults for the scaling of gateways. Organizations can override all
+// values except MaxReplicas, which is the upper bound for replicas and autoscaling.
+type GatewayScalingConfig struct {
+	Replicas                        int32
+	CPURequest                      resource.Quantity
+	CPULimit                        resource.Quantity
+	MemoryRequest                   resource.Quantity
+	MemoryLimit                     resource.Quantity
+	AutoscalingEnabled              bool
+	AutoscalingMinReplicas          int32
+	AutoscalingMaxReplicas          int32
+	AutoscalingTargetCPUUtilization int32
+	MinAvailable                    int32
+	MaxReplicas                     int32
+	// MaxCPU and MaxMemory are the maximum limits that organizations can set
+	MaxCPU    resource.Quantity
+	MaxMemory resource.Quantity
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/mail"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,6 +16,25 @@ func PositiveDuration(value string) (time.Duration, error) {
 	return parsed, err
 }
 
+func NonNegativeDuration(value string) (time.Duration, error) {
+	parsed, err := time.ParseDuration(value)
+	if err == nil && parsed.Nanoseconds() < 0 {
+		err = errors.New("duration must not be negative")
+	}
+	return parsed, err
+}
+
+// StringList parses a comma separated list. Surrounding whitespace and empty items are omitted.
+func StringList(value string) ([]string, error) {
+	var result []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
 func ByteSlice(s string) ([]byte, error) {
 	return []byte(s), nil
 }
@@ -1,4 +1,7 @@
 // copy+pasted from the mcp-proxy project. please don't forget to mirror any changes there
+//
+// Fields that are marked as Jetski extensions have no counterpart in mcp-proxy. Their types are declared in
+// extensions.go and toolpolicy.go and they are only rendered if the gateway image supports them.
 package gatewayconfig
 
 import (
@@ -55,10 +58,16 @@ type Proxy struct {
 	Authentication ProxyAuthentication `yaml:"authentication" json:"authentication"`
 	Telemetry      ProxyTelemetry      `yaml:"telemetry" json:"telemetry"`
 	Webhook        *Webhook            `yaml:"webhook,omitempty" json:"webhook,omitempty"`
+	// Context is a Jetski extension
+	Context *ProxyContext `yaml:"context,omitempty" json:"context,omitempty"`
+	// ToolPolicies is a Jetski extension
+	ToolPolicies []ToolPolicy `yaml:"toolPolicies,omitempty" json:"toolPolicies,omitempty"`
 }
 
 type ProxyHttp struct {
 	Url *URL `yaml:"url" json:"url"`
+	// Auth is a Jetski extension
+	Auth *ProxyHttpAuth `yaml:"auth,omitempty" json:"auth,omitempty"`
 }
 
 type ProxyAuthentication struct {
@@ -67,6 +76,9 @@ type ProxyAuthentication struct {
 
 type ProxyTelemetry struct {
 	Enabled bool `yaml:"enabled" json:"enabled"`
+	// PromptArgumentName is a Jetski extension. It is the name of the tool argument that is used to capture user
+	// prompts. The gateway uses its default name if it is empty.
+	PromptArgumentName string `yaml:"promptArgumentName,omitempty" json:"promptArgumentName,omitempty"`
 }
 
 type Webhook struct {
@@ -153,5 +165,18 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// the proxies are only validated for the Jetski extensions
+	for _, proxy := range c.Proxy {
+		if proxy.Context != nil && !proxy.Authentication.Enabled {
+			return fmt.Errorf("authentication must be enabled when context is configured for proxy %v", proxy.Path)
+		}
+
+		if proxy.Http != nil && proxy.Http.Auth != nil {
+			if err := proxy.Http.Auth.Validate(); err != nil {
+				return fmt.Errorf("invalid http auth for proxy %v: %w", proxy.Path, err)
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,83 @@
+// Jetski extensions of the gateway config. They have no counterpart in mcp-proxy and are only rendered if the gateway
+// image supports them.
+
+package gatewayconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+type ProxyHttpAuthType string
+
+const (
+	ProxyHttpAuthTypeHeader                  ProxyHttpAuthType = "header"
+	ProxyHttpAuthTypeBearer                  ProxyHttpAuthType = "bearer"
+	ProxyHttpAuthTypeOAuth2ClientCredentials ProxyHttpAuthType = "oauth2ClientCredentials"
+)
+
+// ProxyHttpAuth configures how the gateway authenticates to the upstream server. The secret (header value, bearer
+// token or client secret) is not part of the config but read from SecretFile on every use, so that it can be
+// mounted from a Kubernetes Secret and rotated without a restart.
+type ProxyHttpAuth struct {
+	Type       ProxyHttpAuthType `yaml:"type" json:"type"`
+	Header     string            `yaml:"header,omitempty" json:"header,omitempty"`
+	SecretFile string            `yaml:"secretFile" json:"secretFile"`
+	OAuth2     *ProxyHttpOAuth2  `yaml:"oauth2,omitempty" json:"oauth2,omitempty"`
+}
+
+// ProxyHttpOAuth2 configures the OAuth2 client credentials flow. SecretFile of the ProxyHttpAuth contains the
+// client secret.
+type ProxyHttpOAuth2 struct {
+	TokenUrl URL      `yaml:"tokenUrl" json:"tokenUrl"`
+	ClientID string   `yaml:"clientId" json:"clientId"`
+	Scopes   []string `yaml:"scopes,omitempty" json:"scopes,omitempty"`
+}
+
+func (a *ProxyHttpAuth) Validate() error {
+	if a.SecretFile == "" {
+		return fmt.Errorf("secretFile is required")
+	}
+
+	switch a.Type {
+	case ProxyHttpAuthTypeHeader:
+		if a.Header == "" {
+			return fmt.Errorf("header is required for type %v", a.Type)
+		}
+	case ProxyHttpAuthTypeBearer:
+	case ProxyHttpAuthTypeOAuth2ClientCredentials:
+		if a.OAuth2 == nil || a.OAuth2.ClientID == "" || a.OAuth2.TokenUrl.Host == "" {
+			return fmt.Errorf("oauth2 tokenUrl and clientId are required for type %v", a.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %v", a.Type)
+	}
+
+	return nil
+}
+
+// ProxyContext configures a webhook that is called before a request is forwarded to the upstream server. The webhook
+// receives a ContextRequest and responds with a ContextResponse. The headers of the response are added to the
+// upstream request and the metadata is added to the "_meta" field of the MCP request. If the webhook responds with a
+// status code other than 200, the request is rejected.
+type ProxyContext struct {
+	Webhook Webhook `yaml:"webhook" json:"webhook"`
+}
+
+type ContextRequest struct {
+	Subject         string `json:"subject"`
+	SubjectEmail    string `json:"subjectEmail"`
+	AuthTokenDigest string `json:"authTokenDigest"`
+}
+
+// AuthTokenDigest returns the digest of the auth token that the gateway sends as ContextRequest.AuthTokenDigest
+func AuthTokenDigest(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+type ContextResponse struct {
+	Headers  map[string]string `json:"headers,omitempty"`
+	Metadata map[string]any    `json:"metadata,omitempty"`
+}
@@ -0,0 +1,92 @@
+package gatewayconfig
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+type ToolPolicyEffect string
+
+const (
+	ToolPolicyEffectAllow ToolPolicyEffect = "allow"
+	ToolPolicyEffectDeny  ToolPolicyEffect = "deny"
+)
+
+// ToolPolicy allows or denies calls of the tools matching one of the Tools patterns (see path.Match). The policy
+// applies to a subject if all conditions match. Empty conditions match all subjects. If authentication is disabled,
+// the subject is empty, so only policies without conditions can match.
+type ToolPolicy struct {
+	Effect ToolPolicyEffect `yaml:"effect" json:"effect"`
+	Tools  []string         `yaml:"tools" json:"tools"`
+	// Emails contains the email addresses the policy applies to (case-insensitive)
+	Emails []string `yaml:"emails,omitempty" json:"emails,omitempty"`
+	// Domains contains the email domains the policy applies to (case-insensitive)
+	Domains []string `yaml:"domains,omitempty" json:"domains,omitempty"`
+	// Claims contains the values that claims of the access token must have. For array claims, the array must contain
+	// the value.
+	Claims map[string]string `yaml:"claims,omitempty" json:"claims,omitempty"`
+}
+
+type ToolPolicySubject struct {
+	Email  string
+	Claims map[string]any
+}
+
+// EvaluateToolPolicies returns whether the subject may call the tool and the index of the policy that decided. The
+// first policy that matches the subject and the tool decides. If no policy matches, the call is allowed and the
+// returned index is -1.
+func EvaluateToolPolicies(policies []ToolPolicy, subject ToolPolicySubject, tool string) (bool, int) {
+	for i, policy := range policies {
+		if policy.MatchesTool(tool) && policy.MatchesSubject(subject) {
+			return policy.Effect != ToolPolicyEffectDeny, i
+		}
+	}
+	return true, -1
+}
+
+func (p ToolPolicy) MatchesTool(tool string) bool {
+	return slices.ContainsFunc(p.Tools, func(pattern string) bool {
+		matched, _ := path.Match(pattern, tool)
+		return matched
+	})
+}
+
+func (p ToolPolicy) MatchesSubject(subject ToolPolicySubject) bool {
+	if len(p.Emails) > 0 && !slices.ContainsFunc(p.Emails, func(email string) bool {
+		return strings.EqualFold(email, subject.Email)
+	}) {
+		return false
+	}
+
+	if len(p.Domains) > 0 {
+		_, domain, ok := strings.Cut(subject.Email, "@")
+		if !ok || !slices.ContainsFunc(p.Domains, func(d string) bool { return strings.EqualFold(d, domain) }) {
+			return false
+		}
+	}
+
+	for name, expected := range p.Claims {
+		if !claimMatches(subject.Claims[name], expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func claimMatches(value any, expected string) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v == expected
+	case []string:
+		return slices.Contains(v, expected)
+	case []any:
+		return slices.ContainsFunc(v, func(item any) bool { return claimMatches(item, expected) })
+	default:
+		return fmt.Sprint(v) == expected
+	}
+}
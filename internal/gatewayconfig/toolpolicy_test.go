@@ -0,0 +1,31 @@
+package gatewayconfig
+
+import "testing"
+
+func TestEvaluateToolPolicies(t *testing.T) {
+	policies := []ToolPolicy{
+		{Effect: ToolPolicyEffectAllow, Tools: []string{"delete_*"}, Claims: map[string]string{"groups": "admins"}},
+		{Effect: ToolPolicyEffectAllow, Tools: []string{"delete_*"}, Emails: []string{"Ops@example.com"}},
+		{Effect: ToolPolicyEffectDeny, Tools: []string{"delete_*"}},
+		{Effect: ToolPolicyEffectDeny, Tools: []string{"*"}, Domains: []string{"external.com"}},
+	}
+
+	expect := func(subject ToolPolicySubject, tool string, allowed bool, index int) {
+		if a, i := EvaluateToolPolicies(policies, subject, tool); a != allowed || i != index {
+			t.Errorf("EvaluateToolPolicies(%v, %v) expected (%v, %v) but found (%v, %v)",
+				subject, tool, allowed, index, a, i)
+		}
+	}
+
+	admin := ToolPolicySubject{Email: "admin@example.com", Claims: map[string]any{"groups": []any{"users", "admins"}}}
+	ops := ToolPolicySubject{Email: "ops@example.com"}
+	user := ToolPolicySubject{Email: "user@example.com", Claims: map[string]any{"groups": []any{"users"}}}
+	external := ToolPolicySubject{Email: "someone@external.com"}
+
+	expect(admin, "delete_file", true, 0)
+	expect(ops, "delete_file", true, 1)
+	expect(user, "delete_file", false, 2)
+	expect(user, "read_file", true, -1)
+	expect(external, "read_file", false, 3)
+	expect(external, "delete_file", false, 2)
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func alertRulesRouter(r chi.Router) {
+	r.Get("/", getAlertRules)
+	r.Post("/", postAlertRule)
+	r.Route("/{alertRuleId}", func(r chi.Router) {
+		r.Put("/", putAlertRule)
+		r.Delete("/", deleteAlertRule)
+	})
+}
+
+type alertRuleRequest struct {
+	Name                    string            `json:"name"`
+	Metric                  types.AlertMetric `json:"metric"`
+	Threshold               float64           `json:"threshold"`
+	EvaluationWindowMinutes int               `json:"evaluationWindowMinutes"`
+	MinRequestCount         int               `json:"minRequestCount"`
+	Enabled                 bool              `json:"enabled"`
+}
+
+func (req *alertRuleRequest) applyTo(rule *types.AlertRule) {
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.Metric = req.Metric
+	rule.Threshold = req.Threshold
+	rule.EvaluationWindow = time.Duration(req.EvaluationWindowMinutes) * time.Minute
+	rule.MinRequestCount = req.MinRequestCount
+	rule.Enabled = req.Enabled
+}
+
+func getAlertRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	if rules, err := db.GetAlertRulesForProject(ctx, projectID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get alert rules for project")
+	} else {
+		RespondJSON(w, rules)
+	}
+}
+
+func postAlertRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	var request alertRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+
+	rule := types.AlertRule{ProjectID: projectID, CreatedBy: user.ID}
+	request.applyTo(&rule)
+	if ok := validate(w, validateEvaluationWindowMinutes(request.EvaluationWindowMinutes), validateAlertRule(rule)); !ok {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.CreateAlertRule(ctx, &rule); err != nil {
+			return err
+		}
+		return audit.RecordForProject(ctx, projectID, audit.Event{
+			Action:     types.AuditActionAlertRuleCreate,
+			TargetType: types.AuditTargetTypeAlertRule,
+			TargetID:   rule.ID,
+			After:      rule,
+		})
+	})
+	if err != nil {
+		HandleInternalServerError(w, r, err, "failed to create alert rule")
+	} else {
+		RespondJSON(w, rule)
+	}
+}
+
+func putAlertRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rule := getAlertRuleIfAllowed(w, r)
+	if rule == nil {
+		return
+	}
+
+	var request alertRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+
+	before := *rule
+	request.applyTo(rule)
+	if ok := validate(w, validateEvaluationWindowMinutes(request.EvaluationWindowMinutes), validateAlertRule(*rule)); !ok {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.UpdateAlertRule(ctx, rule); err != nil {
+			return err
+		}
+		return audit.RecordForProject(ctx, rule.ProjectID, audit.Event{
+			Action:     types.AuditActionAlertRuleUpdate,
+			TargetType: types.AuditTargetTypeAlertRule,
+			TargetID:   rule.ID,
+			Before:     before,
+			After:      rule,
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to update alert rule")
+	} else {
+		RespondJSON(w, rule)
+	}
+}
+
+func deleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rule := getAlertRuleIfAllowed(w, r)
+	if rule == nil {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.DeleteAlertRule(ctx, rule.ID); err != nil {
+			return err
+		}
+		return audit.RecordForProject(ctx, rule.ProjectID, audit.Event{
+			Action:     types.AuditActionAlertRuleDelete,
+			TargetType: types.AuditTargetTypeAlertRule,
+			TargetID:   rule.ID,
+			Before:     rule,
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to delete alert rule")
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func getAlertIncidentsForProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	pagination, err := lists.ParsePaginationOrDefault(r, lists.Pagination{Count: 20})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var filter db.AlertIncidentFilter
+	if s := r.FormValue("alertRuleId"); s != "" {
+		if id, err := uuid.Parse(s); err != nil {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid alertRuleId")
+			return
+		} else {
+			filter.AlertRuleID = &id
+		}
+	}
+	if s := types.AlertIncidentStatus(r.FormValue("status")); s != "" {
+		if s != types.AlertIncidentStatusFiring && s != types.AlertIncidentStatusResolved {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		filter.Status = &s
+	}
+
+	if incidents, err := db.GetAlertIncidentsForProject(ctx, projectID, pagination, filter); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get alert incidents for project")
+	} else {
+		RespondJSON(w, incidents)
+	}
+}
+
+func getAlertRuleIfAllowed(w http.ResponseWriter, r *http.Request) *types.AlertRule {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+	if projectID == uuid.Nil {
+		return nil
+	}
+
+	if ruleID, err := uuid.Parse(r.PathValue("alertRuleId")); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid alertRuleId")
+		return nil
+	} else if rule, err := db.GetAlertRule(ctx, ruleID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get alert rule")
+		return nil
+	} else if rule.ProjectID != projectID {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else {
+		return rule
+	}
+}
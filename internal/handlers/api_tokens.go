@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/apitokens"
+	"github.com/hyprmcp/jetski/internal/audit"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+const maxAPITokenValidity = 366 * 24 * time.Hour
+
+// APITokensRouter contains the endpoints to manage the personal access tokens of the current user
+func APITokensRouter(r chi.Router) {
+	r.Use(denyAPITokens)
+	r.Get("/", getPersonalAccessTokens)
+	r.Post("/", postPersonalAccessToken)
+	r.Delete("/{tokenId}", deletePersonalAccessToken)
+}
+
+func serviceAccountsRouter(r chi.Router) {
+	r.Use(denyAPITokens)
+	r.Get("/", getServiceAccounts)
+	r.Post("/", postServiceAccount)
+	r.Route("/{serviceAccountId}", func(r chi.Router) {
+		r.Delete("/", deleteServiceAccount)
+		r.Route("/tokens", func(r chi.Router) {
+			r.Get("/", getServiceAccountTokens)
+			r.Post("/", postServiceAccountToken)
+			r.Delete("/{tokenId}", deleteServiceAccountToken)
+		})
+	})
+}
+
+// denyAPITokens rejects requests that have been authenticated with an API token. It is used for endpoints that are not
+// covered by a token scope, so that a leaked token can not be used to create further tokens or to gain access to other
+// organizations.
+func denyAPITokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if internalctx.GetAPIToken(r.Context()) != nil {
+			Handle4XXErrorWithStatusText(w, http.StatusForbidden, "This action can not be performed with an API token.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hasPermission returns true if members with the role have the permission and, if the request has been
+// authenticated with an API token, the token has been granted the permission
+func hasPermission(ctx context.Context, role types.OrganizationRole, permission types.Permission) bool {
+	if !role.Can(permission) {
+		return false
+	} else if token := internalctx.GetAPIToken(ctx); token != nil {
+		return token.Allows(permission)
+	} else {
+		return true
+	}
+}
+
+func getPersonalAccessTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	if result, err := db.GetAPITokensForUser(ctx, user.ID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get personal access tokens")
+	} else {
+		RespondJSON(w, result)
+	}
+}
+
+func postPersonalAccessToken(w http.ResponseWriter, r *http.Request) {
+	user := internalctx.GetUser(r.Context())
+	createAPIToken(w, r, nil, user.ID)
+}
+
+func deletePersonalAccessToken(w http.ResponseWriter, r *http.Request) {
+	user := internalctx.GetUser(r.Context())
+	revokeAPIToken(w, r, nil, user.ID)
+}
+
+func getServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageMembers)
+	if org == nil {
+		return
+	}
+
+	if result, err := db.GetServiceAccountsForOrganization(ctx, org.ID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get service accounts")
+	} else {
+		RespondJSON(w, result)
+	}
+}
+
+func postServiceAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageMembers)
+	if org == nil {
+		return
+	}
+
+	var req struct {
+		Name string                 `json:"name"`
+		Role types.OrganizationRole `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+	if ok := validate(w, validateName(req.Name), validateServiceAccountRole(req.Role)); !ok {
+		return
+	}
+
+	serviceAccount := types.ServiceAccount{
+		CreatedBy:      &user.ID,
+		OrganizationID: org.ID,
+		Name:           req.Name,
+		Role:           req.Role,
+	}
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.CreateServiceAccount(ctx, &serviceAccount); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: &org.ID,
+			Action:         types.AuditActionServiceAccountCreate,
+			TargetType:     types.AuditTargetTypeServiceAccount,
+			TargetID:       serviceAccount.ID,
+			After:          serviceAccount,
+		})
+	})
+	if errors.Is(err, apierrors.ErrAlreadyExists) {
+		Handle4XXErrorWithStatusText(w, http.StatusConflict, "A service account with this name already exists.")
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to create service account")
+	} else {
+		RespondJSON(w, serviceAccount)
+	}
+}
+
+func deleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	serviceAccount := getServiceAccountIfAllowed(w, r)
+	if serviceAccount == nil {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.DeleteServiceAccount(ctx, serviceAccount.OrganizationID, serviceAccount.ID); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: &serviceAccount.OrganizationID,
+			Action:         types.AuditActionServiceAccountDelete,
+			TargetType:     types.AuditTargetTypeServiceAccount,
+			TargetID:       serviceAccount.ID,
+			Before:         serviceAccount,
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to delete service account")
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func getServiceAccountTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	serviceAccount := getServiceAccountIfAllowed(w, r)
+	if serviceAccount == nil {
+		return
+	}
+
+	if result, err := db.GetAPITokensForUser(ctx, serviceAccount.ID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get service account tokens")
+	} else {
+		RespondJSON(w, result)
+	}
+}
+
+func postServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	if serviceAccount := getServiceAccountIfAllowed(w, r); serviceAccount != nil {
+		createAPIToken(w, r, &serviceAccount.OrganizationID, serviceAccount.ID)
+	}
+}
+
+func deleteServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	if serviceAccount := getServiceAccountIfAllowed(w, r); serviceAccount != nil {
+		revokeAPIToken(w, r, &serviceAccount.OrganizationID, serviceAccount.ID)
+	}
+}
+
+// createAPIToken creates a token for the user account. orgID is the organization that owns the user account, or nil
+// for personal access tokens.
+func createAPIToken(w http.ResponseWriter, r *http.Request, orgID *uuid.UUID, userID uuid.UUID) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+
+	var req struct {
+		Name      string             `json:"name"`
+		Scopes    []types.Permission `json:"scopes"`
+		ExpiresAt time.Time          `json:"expiresAt"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if ok := validate(
+		w,
+		validateAPITokenName(req.Name),
+		validateAPITokenScopes(req.Scopes),
+		validateAPITokenExpiry(req.ExpiresAt, time.Now(), maxAPITokenValidity),
+	); !ok {
+		return
+	}
+
+	plaintext, hash := apitokens.NewToken()
+	token := types.APIToken{
+		CreatedBy:     &user.ID,
+		UserAccountID: userID,
+		Name:          req.Name,
+		Scopes:        req.Scopes,
+		ExpiresAt:     req.ExpiresAt,
+	}
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.CreateAPIToken(ctx, &token, hash); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: orgID,
+			Action:         types.AuditActionAPITokenCreate,
+			TargetType:     types.AuditTargetTypeAPIToken,
+			TargetID:       token.ID,
+			After:          token,
+		})
+	})
+	if err != nil {
+		HandleInternalServerError(w, r, err, "failed to create API token")
+	} else {
+		RespondJSON(w, types.CreatedAPIToken{APIToken: token, Token: plaintext})
+	}
+}
+
+func revokeAPIToken(w http.ResponseWriter, r *http.Request, orgID *uuid.UUID, userID uuid.UUID) {
+	ctx := r.Context()
+	tokenID, err := uuid.Parse(r.PathValue("tokenId"))
+	if err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid tokenId")
+		return
+	}
+
+	err = db.RunTx(ctx, func(ctx context.Context) error {
+		if token, err := db.RevokeAPIToken(ctx, userID, tokenID, time.Now()); err != nil {
+			return err
+		} else {
+			before := *token
+			before.RevokedAt = nil
+			return audit.Record(ctx, audit.Event{
+				OrganizationID: orgID,
+				Action:         types.AuditActionAPITokenRevoke,
+				TargetType:     types.AuditTargetTypeAPIToken,
+				TargetID:       token.ID,
+				Before:         before,
+				After:          token,
+			})
+		}
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to revoke API token")
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func getServiceAccountIfAllowed(w http.ResponseWriter, r *http.Request) *types.ServiceAccount {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageMembers)
+	if org == nil {
+		return nil
+	}
+
+	if serviceAccountID, err := uuid.Parse(r.PathValue("serviceAccountId")); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid serviceAccountId")
+		return nil
+	} else if serviceAccount, err := db.GetServiceAccount(ctx, org.ID, serviceAccountID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get service account")
+		return nil
+	} else {
+		return serviceAccount
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestDenyAPITokens(t *testing.T) {
+	router := chi.NewRouter()
+	router.Route("/organizations", OrganizationsRouter(nil, nil))
+	router.Route("/invitations", InvitationsRouter)
+
+	expectForbidden := func(method, path string) {
+		token := &types.APIToken{Name: "ci", Scopes: []types.Permission{types.PermissionRead}}
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req = req.WithContext(internalctx.WithAPIToken(req.Context(), token))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%v %v: expected status %v, got %v", method, path, http.StatusForbidden, rec.Code)
+		}
+	}
+
+	expectForbidden(http.MethodPost, "/organizations")
+	expectForbidden(http.MethodPost, "/invitations/accept")
+}
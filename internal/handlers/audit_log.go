@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+)
+
+// auditLogExportBatchSize is the number of entries that are loaded at once when the audit log is exported
+const auditLogExportBatchSize = 1000
+
+func auditLogRouter(r chi.Router) {
+	r.Get("/", getAuditLog)
+	r.Get("/export", getAuditLogExport)
+}
+
+func getAuditLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageOrganization)
+	if org == nil {
+		return
+	}
+
+	pagination, err := lists.ParsePaginationOrDefault(r, lists.Pagination{Count: 20})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter, ok := parseAuditLogFilter(w, r)
+	if !ok {
+		return
+	}
+
+	if entries, err := db.GetAuditLogForOrganization(ctx, org.ID, pagination, filter); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get audit log for organization")
+	} else {
+		RespondJSON(w, entries)
+	}
+}
+
+// getAuditLogExport returns all audit log entries matching the filter as CSV (default) or JSON file. There is no limit
+// on the number of entries, they are streamed in batches using keyset pagination.
+func getAuditLogExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageOrganization)
+	if org == nil {
+		return
+	}
+
+	format := r.FormValue("format")
+	if format == "" {
+		format = "csv"
+	} else if format != "csv" && format != "json" {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid format")
+		return
+	}
+	filter, ok := parseAuditLogFilter(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := db.GetAuditLogForOrganization(ctx, org.ID, lists.Pagination{Count: auditLogExportBatchSize}, filter)
+	if err != nil {
+		HandleInternalServerError(w, r, err, "failed to export audit log for organization")
+		return
+	}
+
+	filename := fmt.Sprintf("audit-log-%v-%v.%v", org.Name, time.Now().UTC().Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%v"`, filename))
+	var out auditLogExportWriter
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		out = &auditLogJSONWriter{w: w, enc: json.NewEncoder(w)}
+	} else {
+		w.Header().Set("Content-Type", "text/csv")
+		out = &auditLogCSVWriter{w: csv.NewWriter(w)}
+	}
+
+	// The entries are streamed in batches. Once the response has been started, errors can not be reported with a
+	// status code anymore, so the response is aborted to make sure that the client does not get a truncated file.
+	for {
+		for _, entry := range entries {
+			if err := out.Write(entry); err != nil {
+				internalctx.GetLogger(ctx).Warn("failed to write audit log export", zap.Error(err))
+				panic(http.ErrAbortHandler)
+			}
+		}
+		if len(entries) < auditLogExportBatchSize {
+			break
+		}
+
+		last := entries[len(entries)-1]
+		filter.Cursor = &db.AuditLogCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		entries, err = db.GetAuditLogForOrganization(ctx, org.ID, lists.Pagination{Count: auditLogExportBatchSize}, filter)
+		if err != nil {
+			internalctx.GetLogger(ctx).Error("failed to export audit log for organization", zap.Error(err))
+			sentry.GetHubFromContext(ctx).CaptureException(err)
+			panic(http.ErrAbortHandler)
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		internalctx.GetLogger(ctx).Warn("failed to write audit log export", zap.Error(err))
+	}
+}
+
+type auditLogExportWriter interface {
+	Write(entry types.AuditLogEntry) error
+	Close() error
+}
+
+type auditLogCSVWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (cw *auditLogCSVWriter) Write(entry types.AuditLogEntry) error {
+	if !cw.headerWritten {
+		if err := cw.writeHeader(); err != nil {
+			return err
+		}
+	}
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+	return cw.w.Write([]string{
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+		uuidOrEmpty(entry.ActorID),
+		entry.ActorEmail,
+		uuidOrEmpty(entry.APITokenID),
+		string(entry.Action),
+		string(entry.TargetType),
+		entry.TargetID.String(),
+		string(changes),
+		entry.RequestID,
+		entry.IPAddress,
+	})
+}
+
+func (cw *auditLogCSVWriter) Close() error {
+	if !cw.headerWritten {
+		if err := cw.writeHeader(); err != nil {
+			return err
+		}
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *auditLogCSVWriter) writeHeader() error {
+	cw.headerWritten = true
+	return cw.w.Write([]string{
+		"createdAt", "actorId", "actorEmail", "apiTokenId", "action", "targetType", "targetId", "changes",
+		"requestId", "ipAddress",
+	})
+}
+
+// auditLogJSONWriter writes the entries as a JSON array
+type auditLogJSONWriter struct {
+	w     io.Writer
+	enc   *json.Encoder
+	count int
+}
+
+func (jw *auditLogJSONWriter) Write(entry types.AuditLogEntry) error {
+	separator := ","
+	if jw.count == 0 {
+		separator = "["
+	}
+	jw.count++
+	if _, err := io.WriteString(jw.w, separator); err != nil {
+		return err
+	}
+	return jw.enc.Encode(entry)
+}
+
+func (jw *auditLogJSONWriter) Close() error {
+	if jw.count == 0 {
+		_, err := io.WriteString(jw.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(jw.w, "]\n")
+	return err
+}
+
+func parseAuditLogFilter(w http.ResponseWriter, r *http.Request) (db.AuditLogFilter, bool) {
+	var filter db.AuditLogFilter
+	var err error
+	if s := types.AuditAction(r.FormValue("action")); s != "" {
+		filter.Action = &s
+	}
+	if s := types.AuditTargetType(r.FormValue("targetType")); s != "" {
+		filter.TargetType = &s
+	}
+	if s := r.FormValue("targetId"); s != "" {
+		if id, err := uuid.Parse(s); err != nil {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid targetId")
+			return filter, false
+		} else {
+			filter.TargetID = &id
+		}
+	}
+	if s := r.FormValue("actorId"); s != "" {
+		if id, err := uuid.Parse(s); err != nil {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid actorId")
+			return filter, false
+		} else {
+			filter.ActorID = &id
+		}
+	}
+	if filter.After, err = parseUnixTimestampParam(r, "after"); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid after timestamp")
+		return filter, false
+	}
+	if filter.Before, err = parseUnixTimestampParam(r, "before"); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid before timestamp")
+		return filter, false
+	}
+	return filter, true
+}
+
+func uuidOrEmpty(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
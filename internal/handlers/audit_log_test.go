@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestAuditLogExportWriters(t *testing.T) {
+	entries := []types.AuditLogEntry{
+		{ID: uuid.New(), Action: types.AuditActionProjectUpdate, TargetID: uuid.New()},
+		{ID: uuid.New(), Action: types.AuditActionProjectUpdate, TargetID: uuid.New()},
+	}
+
+	for _, n := range []int{0, 1, 2} {
+		var buf bytes.Buffer
+		out := &auditLogJSONWriter{w: &buf, enc: json.NewEncoder(&buf)}
+		for _, entry := range entries[:n] {
+			if err := out.Write(entry); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := out.Close(); err != nil {
+			t.Fatal(err)
+		}
+		var result []types.AuditLogEntry
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Errorf("json with %v entries: invalid JSON %q: %v", n, buf.String(), err)
+		} else if len(result) != n {
+			t.Errorf("json with %v entries: got %v entries", n, len(result))
+		}
+
+		buf.Reset()
+		cw := &auditLogCSVWriter{w: csv.NewWriter(&buf)}
+		for _, entry := range entries[:n] {
+			if err := cw.Write(entry); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := cw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if records, err := csv.NewReader(&buf).ReadAll(); err != nil {
+			t.Errorf("csv with %v entries: %v", n, err)
+		} else if len(records) != n+1 {
+			t.Errorf("csv with %v entries: expected header and %v records, got %v", n, n, len(records))
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/gatewayconfig"
+	"github.com/hyprmcp/jetski/internal/oidc"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+)
+
+func contextPropertiesRouter(r chi.Router) {
+	r.Get("/", getContextProperties)
+	r.With(requireGatewayFeature(env.GatewayContextEnabled, "Context properties")).Post("/", postContextProperty)
+	r.Route("/{contextPropertyId}", func(r chi.Router) {
+		r.With(requireGatewayFeature(env.GatewayContextEnabled, "Context properties")).Put("/", putContextProperty)
+		r.Delete("/", deleteContextProperty)
+	})
+}
+
+// contextValuesRouter contains the endpoints for users of the MCP server to manage the values of the context
+// properties of a project. Values are always set for a single auth token that is used to connect to the MCP server.
+// Users of the MCP server don't have to be members of the organization, so only the existence of the project is
+// checked. Values are always scoped to the current user.
+func contextValuesRouter(gatewayTokenVerifier *oidc.Verifier) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Use(denyAPITokens)
+		r.Get("/", getContextValues)
+		r.Put("/", putContextValuesHandler(gatewayTokenVerifier))
+		r.Delete("/", deleteContextValues)
+	}
+}
+
+type contextPropertyRequest struct {
+	Type     types.ContextPropertyType `json:"type"`
+	Name     string                    `json:"name"`
+	Required bool                      `json:"required"`
+}
+
+func (req *contextPropertyRequest) applyTo(property *types.ContextProperty) {
+	property.Type = req.Type
+	property.Name = strings.TrimSpace(req.Name)
+	property.Required = req.Required
+}
+
+func getContextProperties(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	if properties, err := db.GetContextPropertiesForProject(ctx, projectID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get context properties for project")
+	} else {
+		RespondJSON(w, properties)
+	}
+}
+
+func postContextProperty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	var request contextPropertyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+
+	property := types.ContextProperty{ProjectID: projectID}
+	request.applyTo(&property)
+	if ok := validate(w, validateContextProperty(property)); !ok {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.CreateContextProperty(ctx, &property); err != nil {
+			return err
+		}
+		return audit.RecordForProject(ctx, projectID, audit.Event{
+			Action:     types.AuditActionContextPropertyCreate,
+			TargetType: types.AuditTargetTypeContextProperty,
+			TargetID:   property.ID,
+			After:      property,
+		})
+	})
+	if errors.Is(err, apierrors.ErrAlreadyExists) {
+		Handle4XXErrorWithStatusText(w, http.StatusConflict, "A context property with this name already exists.")
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to create context property")
+	} else {
+		RespondJSON(w, property)
+	}
+}
+
+func putContextProperty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	property := getContextPropertyIfAllowed(w, r)
+	if property == nil {
+		return
+	}
+
+	var request contextPropertyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+
+	before := *property
+	request.applyTo(property)
+	if ok := validate(w, validateContextProperty(*property)); !ok {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.UpdateContextProperty(ctx, property); err != nil {
+			return err
+		}
+		if property.Type != before.Type {
+			if err := db.DeleteContextValuesForProperty(ctx, property.ID); err != nil {
+				return err
+			}
+		}
+		return audit.RecordForProject(ctx, property.ProjectID, audit.Event{
+			Action:     types.AuditActionContextPropertyUpdate,
+			TargetType: types.AuditTargetTypeContextProperty,
+			TargetID:   property.ID,
+			Before:     before,
+			After:      property,
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if errors.Is(err, apierrors.ErrAlreadyExists) {
+		Handle4XXErrorWithStatusText(w, http.StatusConflict, "A context property with this name already exists.")
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to update context property")
+	} else {
+		RespondJSON(w, property)
+	}
+}
+
+func deleteContextProperty(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	property := getContextPropertyIfAllowed(w, r)
+	if property == nil {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.DeleteContextProperty(ctx, property.ID); err != nil {
+			return err
+		}
+		return audit.RecordForProject(ctx, property.ProjectID, audit.Event{
+			Action:     types.AuditActionContextPropertyDelete,
+			TargetType: types.AuditTargetTypeContextProperty,
+			TargetID:   property.ID,
+			Before:     property,
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to delete context property")
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func getContextValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	projectID := getProjectIDForContextValues(w, r)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	var authTokenDigest *string
+	if s := r.FormValue("authTokenDigest"); s != "" {
+		authTokenDigest = &s
+	}
+
+	if values, err := db.GetContextValues(ctx, projectID, user.ID, authTokenDigest); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get context values")
+	} else {
+		RespondJSON(w, values)
+	}
+}
+
+// putContextValuesHandler replaces the values of the current user for the given auth token. The token must be valid
+// and must have been issued to the current user. It is issued for a client of the MCP gateway, not for the UI, so it
+// is checked with the verifier of the gateway. Only its digest is stored, which is the same digest the gateway sends
+// when resolving the context. Values are passed as an object keyed by the property name and must match the type of the
+// property. All required properties must be set.
+func putContextValuesHandler(gatewayTokenVerifier *oidc.Verifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := internalctx.GetLogger(ctx)
+		user := internalctx.GetUser(ctx)
+		projectID := getProjectIDForContextValues(w, r)
+		if projectID == uuid.Nil {
+			return
+		}
+
+		var request struct {
+			AuthToken string         `json:"authToken"`
+			Values    map[string]any `json:"values"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			Handle4XXError(w, http.StatusBadRequest)
+			return
+		} else if request.AuthToken == "" {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "authToken is required")
+			return
+		} else if _, email, err := gatewayTokenVerifier.Verify(ctx, request.AuthToken); err != nil {
+			log.Info("failed to verify auth token for context values", zap.Error(err))
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "authToken is invalid")
+			return
+		} else if !strings.EqualFold(email, user.Email) {
+			Handle4XXErrorWithStatusText(w, http.StatusForbidden, "authToken has not been issued to the current user")
+			return
+		}
+
+		properties, err := db.GetContextPropertiesForProject(ctx, projectID)
+		if err != nil {
+			HandleInternalServerError(w, r, err, "failed to get context properties for project")
+			return
+		} else if ok := validate(w, validateContextValues(properties, request.Values)); !ok {
+			return
+		}
+
+		values := make([]types.Context, 0, len(request.Values))
+		for _, property := range properties {
+			if value, ok := request.Values[property.Name]; ok {
+				values = append(values, types.Context{ContextPropertyID: property.ID, ContextPropertyValue: value})
+			}
+		}
+
+		authTokenDigest := gatewayconfig.AuthTokenDigest(request.AuthToken)
+		var result []types.Context
+		err = db.RunTx(ctx, func(ctx context.Context) (err error) {
+			result, err = db.SetContextValues(ctx, projectID, user.ID, authTokenDigest, values)
+			return
+		})
+		if err != nil {
+			HandleInternalServerError(w, r, err, "failed to set context values")
+		} else {
+			RespondJSON(w, result)
+		}
+	}
+}
+
+func deleteContextValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	projectID := getProjectIDForContextValues(w, r)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	authTokenDigest := r.FormValue("authTokenDigest")
+	if authTokenDigest == "" {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "authTokenDigest is required")
+		return
+	}
+
+	if err := db.DeleteContextValues(ctx, projectID, user.ID, authTokenDigest); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to delete context values")
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// getProjectIDForContextValues returns the ID of the project in the path if it exists. Unlike getProjectIDIfAllowed,
+// it does not require the user to be a member of the organization.
+func getProjectIDForContextValues(w http.ResponseWriter, r *http.Request) uuid.UUID {
+	if projectID, err := uuid.Parse(r.PathValue("projectId")); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid projectId")
+		return uuid.Nil
+	} else if _, err := db.GetOrganizationIDOfProject(r.Context(), projectID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return uuid.Nil
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get project")
+		return uuid.Nil
+	} else {
+		return projectID
+	}
+}
+
+func getContextPropertyIfAllowed(w http.ResponseWriter, r *http.Request) *types.ContextProperty {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+	if projectID == uuid.Nil {
+		return nil
+	}
+
+	if propertyID, err := uuid.Parse(r.PathValue("contextPropertyId")); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid contextPropertyId")
+		return nil
+	} else if property, err := db.GetContextProperty(ctx, propertyID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get context property")
+		return nil
+	} else if property.ProjectID != projectID {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else {
+		return property
+	}
+}
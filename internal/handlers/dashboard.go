@@ -2,20 +2,24 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/hyprmcp/jetski/internal/analytics"
 	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
 )
 
 func DashboardRouter(r chi.Router) {
 	r.Get("/projects", getProjectsForDashboard)
 	r.Get("/deployment-revisions", getDeploymentRevisionsForDashboard)
 	r.Get("/usage", getUsageForDashboard)
+	r.Get("/analytics", getAnalyticsForDashboard)
 }
 
 func getProjectsForDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	org := getOrganizationIfAllowed(w, r, queryParam)
+	org := getOrganizationIfAllowed(w, r, queryParam, types.PermissionRead)
 	if org == nil {
 		return
 	}
@@ -28,7 +32,7 @@ func getProjectsForDashboard(w http.ResponseWriter, r *http.Request) {
 
 func getDeploymentRevisionsForDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	org := getOrganizationIfAllowed(w, r, queryParam)
+	org := getOrganizationIfAllowed(w, r, queryParam, types.PermissionRead)
 	if org == nil {
 		return
 	}
@@ -41,7 +45,7 @@ func getDeploymentRevisionsForDashboard(w http.ResponseWriter, r *http.Request)
 
 func getUsageForDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	org := getOrganizationIfAllowed(w, r, queryParam)
+	org := getOrganizationIfAllowed(w, r, queryParam, types.PermissionRead)
 	if org == nil {
 		return
 	}
@@ -51,3 +55,25 @@ func getUsageForDashboard(w http.ResponseWriter, r *http.Request) {
 		RespondJSON(w, usage)
 	}
 }
+
+func getAnalyticsForDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, queryParam, types.PermissionRead)
+	if org == nil {
+		return
+	}
+
+	var startAt time.Time
+	if t, err := parseUnixTimestampParam(r, "startedAt"); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid startedAt timestamp")
+		return
+	} else if t != nil {
+		startAt = *t
+	}
+
+	if analyticsData, err := analytics.GetOrganizationAnalytics(ctx, org.ID, startAt); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get analytics for organization")
+	} else {
+		RespondJSON(w, analyticsData)
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
+	"github.com/hyprmcp/jetski/internal/notifications"
+	"github.com/hyprmcp/jetski/internal/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// environmentRouter contains the endpoints to manage the environment variables of the hosted MCP server of a project.
+// The variables belong to the latest deployment revision, so every change creates a new revision.
+func environmentRouter(k8sClient client.Client) func(r chi.Router) {
+	gatewayApplier := apply.MCPGateway(k8sClient)
+	return func(r chi.Router) {
+		r.Get("/", getEnvironmentVariables)
+		r.Put("/", putEnvironmentVariables(gatewayApplier))
+	}
+}
+
+type environmentVariableRequest struct {
+	Name   string  `json:"name"`
+	Secret bool    `json:"secret"`
+	Value  *string `json:"value"`
+}
+
+func getEnvironmentVariables(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	ps, err := db.GetProjectSummary(ctx, projectID)
+	if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get project summary")
+		return
+	} else if ps.LatestDeploymentRevisionID == nil {
+		RespondJSON(w, []types.EnvironmentVariable{})
+		return
+	}
+
+	if variables, err := db.GetEnvironmentVariables(ctx, *ps.LatestDeploymentRevisionID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get environment variables")
+	} else {
+		RespondJSON(w, maskEnvironmentVariables(variables))
+	}
+}
+
+var errProjectNotHosted = errors.New("project is not hosted")
+
+// putEnvironmentVariables replaces all environment variables of the project by creating a new deployment revision
+// with the same settings as the latest one. The value of an existing secret is kept if no value is passed for it.
+// The latest revision is locked, so that concurrent changes can not overwrite each other.
+func putEnvironmentVariables(gatewayApplier mcpGatewayApplier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := internalctx.GetUser(ctx)
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+		if projectID == uuid.Nil {
+			return
+		}
+
+		var request []environmentVariableRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			Handle4XXError(w, http.StatusBadRequest)
+			return
+		}
+
+		ps, err := db.GetProjectSummary(ctx, projectID)
+		if err != nil {
+			HandleInternalServerError(w, r, err, "failed to get project summary")
+			return
+		}
+
+		var variables []types.EnvironmentVariable
+		var validationErr error
+		err = db.RunTx(ctx, func(ctx context.Context) error {
+			latest, err := db.LockLatestDeploymentRevision(ctx, projectID)
+			if err != nil {
+				return err
+			} else if latest.OCIURL == nil {
+				return errProjectNotHosted
+			}
+
+			previous, err := db.GetEnvironmentVariables(ctx, latest.ID)
+			if err != nil {
+				return err
+			}
+
+			variables = make([]types.EnvironmentVariable, len(request))
+			for i, item := range request {
+				variables[i] = types.EnvironmentVariable{
+					Name:   strings.TrimSpace(item.Name),
+					Secret: item.Secret,
+					Value:  item.Value,
+				}
+				if variables[i].Secret && variables[i].Value == nil {
+					idx := slices.IndexFunc(previous, func(v types.EnvironmentVariable) bool {
+						return v.Secret && v.Name == variables[i].Name
+					})
+					if idx >= 0 {
+						variables[i].Value = previous[idx].Value
+					}
+				}
+			}
+			if validationErr = validateEnvironmentVariables(variables)(); validationErr != nil {
+				return validationErr
+			}
+
+			dr := *latest
+			dr.ID = uuid.Nil
+			dr.CreatedBy = user.ID
+
+			if err := db.CreateDeploymentRevision(ctx, &dr); err != nil {
+				return err
+			}
+
+			if variables, err = db.CreateEnvironmentVariables(ctx, dr.ID, variables); err != nil {
+				return err
+			}
+
+			if err := audit.RecordForProject(ctx, projectID, audit.Event{
+				Action:     types.AuditActionProjectEnvironmentUpdate,
+				TargetType: types.AuditTargetTypeProject,
+				TargetID:   projectID,
+				Before:     maskEnvironmentVariables(previous),
+				After:      maskEnvironmentVariables(variables),
+			}); err != nil {
+				return err
+			}
+
+			if err := notifications.Publish(ctx, notifications.DeploymentRevisionCreated(ps.Organization, ps.Project, dr)); err != nil {
+				return err
+			}
+
+			if _, err := db.AddDeploymentRevisionEvent(ctx, dr.ID, types.DeploymentRevisionEventTypeProgressing, nil); err != nil {
+				return err
+			}
+
+			return nil
+		})
+		switch {
+		case validationErr != nil:
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, validationErr.Error())
+		case errors.Is(err, apierrors.ErrNotFound):
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "Project has no deployment settings yet")
+		case errors.Is(err, errProjectNotHosted):
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest,
+				"Environment variables can only be set for hosted MCP servers")
+		case err != nil:
+			HandleInternalServerError(w, r, err, "failed to save environment variables")
+		default:
+			applyGatewayForProject(ctx, gatewayApplier, projectID)
+			RespondJSON(w, maskEnvironmentVariables(variables))
+		}
+	}
+}
+
+// maskEnvironmentVariables returns a copy of the variables without the values of secrets
+func maskEnvironmentVariables(variables []types.EnvironmentVariable) []types.EnvironmentVariable {
+	result := make([]types.EnvironmentVariable, len(variables))
+	for i, variable := range variables {
+		result[i] = variable
+		if variable.Secret {
+			result[i].Value = nil
+		}
+	}
+	return result
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/invitations"
+	"github.com/hyprmcp/jetski/internal/mailsending"
+	"github.com/hyprmcp/jetski/internal/notifications"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+const invitationValidity = 7 * 24 * time.Hour
+
+var errInvitationExpired = errors.New("invitation expired")
+
+// InvitationsRouter contains the endpoints for invited users, who are not yet members of the organization
+func InvitationsRouter(r chi.Router) {
+	r.Use(denyAPITokens)
+	r.Post("/accept", postInvitationAccept)
+}
+
+func organizationInvitationsRouter(r chi.Router) {
+	r.Get("/", getInvitations)
+	r.Post("/", postInvitation)
+	r.Route("/{invitationId}", func(r chi.Router) {
+		r.Delete("/", deleteInvitation)
+		r.Post("/resend", postInvitationResend)
+	})
+}
+
+func getInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageMembers)
+	if org == nil {
+		return
+	}
+
+	if result, err := db.GetPendingInvitationsForOrganization(ctx, org.ID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get invitations")
+	} else {
+		RespondJSON(w, result)
+	}
+}
+
+func postInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	org, role := getOrganizationAndRoleIfAllowed(w, r, pathParam, types.PermissionManageMembers)
+	if org == nil {
+		return
+	}
+
+	var req struct {
+		Email string                 `json:"email"`
+		Role  types.OrganizationRole `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if req.Role == "" {
+		req.Role = types.OrganizationRoleDeveloper
+	}
+	if ok := validate(w, validateEmail(req.Email), validateOrganizationRole(req.Role)); !ok {
+		return
+	} else if req.Role == types.OrganizationRoleOwner && !hasPermission(ctx, role, types.PermissionManageOwners) {
+		Handle4XXError(w, http.StatusForbidden)
+		return
+	}
+
+	invitation := types.Invitation{
+		CreatedBy:      &user.ID,
+		OrganizationID: org.ID,
+		Email:          req.Email,
+		Role:           req.Role,
+		ExpiresAt:      time.Now().Add(invitationValidity),
+	}
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if existing, err := db.GetUserByEmail(ctx, req.Email); err == nil {
+			if _, err := db.GetOrganizationMember(ctx, org.ID, existing.ID); err == nil {
+				return apierrors.ErrConflict
+			} else if !errors.Is(err, apierrors.ErrNotFound) {
+				return err
+			}
+		} else if !errors.Is(err, apierrors.ErrNotFound) {
+			return err
+		}
+
+		if err := db.CreateInvitation(ctx, &invitation); err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, audit.Event{
+			OrganizationID: &org.ID,
+			Action:         types.AuditActionInvitationCreate,
+			TargetType:     types.AuditTargetTypeInvitation,
+			TargetID:       invitation.ID,
+			After:          invitation,
+		}); err != nil {
+			return err
+		}
+		return mailsending.SendUserInviteMail(ctx, invitation, *org)
+	})
+	if errors.Is(err, apierrors.ErrConflict) {
+		Handle4XXErrorWithStatusText(w, http.StatusConflict, "This user is already a member of the organization.")
+	} else if errors.Is(err, apierrors.ErrAlreadyExists) {
+		Handle4XXErrorWithStatusText(w, http.StatusConflict, "This user has already been invited.")
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to create invitation")
+	} else {
+		RespondJSON(w, invitation)
+	}
+}
+
+func postInvitationResend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org, invitation := getInvitationIfAllowed(w, r)
+	if invitation == nil {
+		return
+	}
+
+	now := time.Now()
+	before := *invitation
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.RenewInvitation(ctx, invitation, now, now.Add(invitationValidity)); err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, audit.Event{
+			OrganizationID: &org.ID,
+			Action:         types.AuditActionInvitationResend,
+			TargetType:     types.AuditTargetTypeInvitation,
+			TargetID:       invitation.ID,
+			Before:         before,
+			After:          invitation,
+		}); err != nil {
+			return err
+		}
+		return mailsending.SendUserInviteMail(ctx, *invitation, *org)
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to resend invitation")
+	} else {
+		RespondJSON(w, invitation)
+	}
+}
+
+func deleteInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org, invitation := getInvitationIfAllowed(w, r)
+	if invitation == nil {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.RevokeInvitation(ctx, invitation.ID); err != nil {
+			return err
+		}
+		revoked := *invitation
+		revoked.Status = types.InvitationStatusRevoked
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: &org.ID,
+			Action:         types.AuditActionInvitationRevoke,
+			TargetType:     types.AuditTargetTypeInvitation,
+			TargetID:       invitation.ID,
+			Before:         invitation,
+			After:          revoked,
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to revoke invitation")
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// postInvitationAccept adds the current user to the organization of the invitation if the token is valid and the
+// email address of the user matches the invited email address
+func postInvitationAccept(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+	invitationID, err := invitations.ParseToken(req.Token)
+	if err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid token")
+		return
+	}
+
+	var org *types.Organization
+	err = db.RunTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		invitation, err := db.LockInvitation(ctx, invitationID)
+		if err != nil {
+			return err
+		} else if !invitations.VerifyToken(req.Token, *invitation) {
+			return apierrors.ErrNotFound
+		} else if invitation.Status != types.InvitationStatusPending {
+			return apierrors.ErrConflict
+		} else if invitation.IsExpired(now) {
+			return errInvitationExpired
+		} else if !strings.EqualFold(invitation.Email, user.Email) {
+			return apierrors.ErrForbidden
+		}
+
+		if org, err = db.GetOrganization(ctx, invitation.OrganizationID); err != nil {
+			return err
+		}
+
+		// users that already are members keep their current role
+		if err := db.AddUserToOrganization(ctx, user.ID, org.ID, invitation.Role); err == nil {
+			if err := audit.Record(ctx, audit.Event{
+				OrganizationID: &org.ID,
+				Action:         types.AuditActionMemberAdd,
+				TargetType:     types.AuditTargetTypeMember,
+				TargetID:       user.ID,
+				After:          types.OrganizationMember{UserAccount: *user, Role: invitation.Role},
+			}); err != nil {
+				return err
+			}
+			if err := notifications.Publish(ctx, notifications.MemberAdded(*org, *user)); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, apierrors.ErrAlreadyExists) {
+			return err
+		}
+
+		return db.AcceptInvitation(ctx, invitation.ID, user.ID, now)
+	})
+	switch {
+	case errors.Is(err, apierrors.ErrNotFound):
+		Handle4XXError(w, http.StatusNotFound)
+	case errors.Is(err, apierrors.ErrConflict):
+		Handle4XXErrorWithStatusText(w, http.StatusConflict, "This invitation is no longer valid.")
+	case errors.Is(err, errInvitationExpired):
+		Handle4XXErrorWithStatusText(w, http.StatusGone, "This invitation has expired.")
+	case errors.Is(err, apierrors.ErrForbidden):
+		Handle4XXErrorWithStatusText(w, http.StatusForbidden, "This invitation was sent to another email address.")
+	case err != nil:
+		HandleInternalServerError(w, r, err, "failed to accept invitation")
+	default:
+		RespondJSON(w, org)
+	}
+}
+
+// getInvitationIfAllowed returns the invitation if it belongs to the organization and the current user may manage
+// it. Only owners may manage invitations for the owner role.
+func getInvitationIfAllowed(w http.ResponseWriter, r *http.Request) (*types.Organization, *types.Invitation) {
+	ctx := r.Context()
+	org, role := getOrganizationAndRoleIfAllowed(w, r, pathParam, types.PermissionManageMembers)
+	if org == nil {
+		return nil, nil
+	}
+
+	if invitationID, err := uuid.Parse(r.PathValue("invitationId")); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid invitationId")
+		return nil, nil
+	} else if invitation, err := db.GetInvitation(ctx, invitationID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil, nil
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get invitation")
+		return nil, nil
+	} else if invitation.OrganizationID != org.ID {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil, nil
+	} else if invitation.Role == types.OrganizationRoleOwner && !hasPermission(ctx, role, types.PermissionManageOwners) {
+		Handle4XXError(w, http.StatusForbidden)
+		return nil, nil
+	} else {
+		return org, invitation
+	}
+}
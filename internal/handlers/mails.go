@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// getOutboxMails returns the mails that have been sent on behalf of the organization, including pending and failed
+// mails
+func getOutboxMails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageOrganization)
+	if org == nil {
+		return
+	}
+
+	pagination, err := lists.ParsePaginationOrDefault(r, lists.Pagination{Count: 20})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var filter db.OutboxMailFilter
+	if s := types.MailStatus(r.FormValue("status")); s != "" {
+		if s != types.MailStatusPending && s != types.MailStatusSent && s != types.MailStatusFailed {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		filter.Status = &s
+	}
+
+	if mails, err := db.GetOutboxMailsForOrganization(ctx, org.ID, pagination, filter); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get mails for organization")
+	} else {
+		RespondJSON(w, mails)
+	}
+}
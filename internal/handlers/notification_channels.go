@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func notificationChannelsRouter(r chi.Router) {
+	r.Get("/", getNotificationChannels)
+	r.Post("/", postNotificationChannel)
+	r.Route("/{notificationChannelId}", func(r chi.Router) {
+		r.Put("/", putNotificationChannel)
+		r.Delete("/", deleteNotificationChannel)
+		r.Get("/deliveries", getNotificationDeliveries)
+	})
+}
+
+type notificationChannelRequest struct {
+	Name       string                        `json:"name"`
+	Type       types.NotificationChannelType `json:"type"`
+	URL        string                        `json:"url"`
+	EventTypes []types.NotificationEventType `json:"eventTypes"`
+	Enabled    bool                          `json:"enabled"`
+}
+
+func (req *notificationChannelRequest) applyTo(channel *types.NotificationChannel) {
+	channel.Name = strings.TrimSpace(req.Name)
+	channel.URL = strings.TrimSpace(req.URL)
+	channel.EventTypes = req.EventTypes
+	channel.Enabled = req.Enabled
+}
+
+func getNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionRead)
+	if org == nil {
+		return
+	}
+
+	if channels, err := db.GetNotificationChannelsForOrganization(ctx, org.ID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get notification channels")
+	} else {
+		for i := range channels {
+			channels[i].Secret = ""
+		}
+		RespondJSON(w, channels)
+	}
+}
+
+func postNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageOrganization)
+	if org == nil {
+		return
+	}
+
+	var request notificationChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+
+	channel := types.NotificationChannel{OrganizationID: org.ID, Type: request.Type, Secret: rand.Text()}
+	request.applyTo(&channel)
+	if ok := validate(w, validateNotificationChannel(channel), validateNotificationChannelAddress(ctx, channel)); !ok {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.CreateNotificationChannel(ctx, &channel); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: &org.ID,
+			Action:         types.AuditActionNotificationChannelCreate,
+			TargetType:     types.AuditTargetTypeNotificationChannel,
+			TargetID:       channel.ID,
+			After:          withoutSecret(channel),
+		})
+	})
+	if err != nil {
+		HandleInternalServerError(w, r, err, "failed to create notification channel")
+	} else {
+		// the secret is only returned once
+		RespondJSON(w, channel)
+	}
+}
+
+func putNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getNotificationChannelIfAllowed(w, r)
+	if channel == nil {
+		return
+	}
+
+	var request notificationChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+
+	before := withoutSecret(*channel)
+	request.applyTo(channel)
+	if ok := validate(w, validateNotificationChannel(*channel), validateNotificationChannelAddress(ctx, *channel)); !ok {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.UpdateNotificationChannel(ctx, channel); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: &channel.OrganizationID,
+			Action:         types.AuditActionNotificationChannelUpdate,
+			TargetType:     types.AuditTargetTypeNotificationChannel,
+			TargetID:       channel.ID,
+			Before:         before,
+			After:          withoutSecret(*channel),
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to update notification channel")
+	} else {
+		channel.Secret = ""
+		RespondJSON(w, channel)
+	}
+}
+
+func deleteNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getNotificationChannelIfAllowed(w, r)
+	if channel == nil {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if err := db.DeleteNotificationChannel(ctx, channel.ID); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: &channel.OrganizationID,
+			Action:         types.AuditActionNotificationChannelDelete,
+			TargetType:     types.AuditTargetTypeNotificationChannel,
+			TargetID:       channel.ID,
+			Before:         withoutSecret(*channel),
+		})
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to delete notification channel")
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func getNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getNotificationChannelIfAllowed(w, r)
+	if channel == nil {
+		return
+	}
+
+	pagination, err := lists.ParsePaginationOrDefault(r, lists.Pagination{Count: 20})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if deliveries, err := db.GetNotificationDeliveriesForChannel(ctx, channel.ID, pagination); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get notification deliveries")
+	} else {
+		RespondJSON(w, deliveries)
+	}
+}
+
+func getNotificationChannelIfAllowed(w http.ResponseWriter, r *http.Request) *types.NotificationChannel {
+	ctx := r.Context()
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageOrganization)
+	if org == nil {
+		return nil
+	}
+
+	if channelID, err := uuid.Parse(r.PathValue("notificationChannelId")); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid notificationChannelId")
+		return nil
+	} else if channel, err := db.GetNotificationChannel(ctx, channelID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get notification channel")
+		return nil
+	} else if channel.OrganizationID != org.ID {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else {
+		return channel
+	}
+}
+
+// withoutSecret returns a copy of the channel without its signing secret, which must not end up in the audit log
+func withoutSecret(channel types.NotificationChannel) types.NotificationChannel {
+	channel.Secret = ""
+	return channel
+}
@@ -2,18 +2,27 @@ package handlers
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
-	"github.com/getsentry/sentry-go"
 	"github.com/google/uuid"
 	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	"github.com/hyprmcp/jetski/internal/domainverification"
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
 	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
-	"github.com/hyprmcp/jetski/internal/mailsending"
 	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
 	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/go-chi/chi/v5"
@@ -21,32 +30,57 @@ import (
 	"github.com/hyprmcp/jetski/internal/db"
 )
 
-func OrganizationsRouter(k8sClient client.Client) func(r chi.Router) {
+func OrganizationsRouter(k8sClient client.Client, domainResolver domainverification.Resolver) func(r chi.Router) {
 	return func(r chi.Router) {
-		r.Get("/", getOrganizations)
-		r.Post("/", postOrganizationHandler())
+		r.Get("/", getOrganizationsHandler(k8sClient))
+		r.With(denyAPITokens).Post("/", postOrganizationHandler())
 		r.Route("/{organizationId}", func(r chi.Router) {
 			r.Put("/", putOrganizationHandler(k8sClient))
+			r.Get("/gateway-sync", getGatewaySyncStatus)
+			r.Post("/custom-domain/verify", verifyOrganizationCustomDomain(k8sClient, domainResolver))
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", getOrganizationMembers)
-				r.Put("/", putOrganizationMember())
 				r.Delete("/{userId}", deleteOrganizationMember())
+				r.Put("/{userId}/role", putOrganizationMemberRole)
+			})
+			r.Route("/invitations", organizationInvitationsRouter)
+			r.Route("/service-accounts", serviceAccountsRouter)
+			r.Route("/notification-channels", notificationChannelsRouter)
+			r.Get("/mails", getOutboxMails)
+			r.Route("/audit-log", auditLogRouter)
+			r.Route("/preferences", func(r chi.Router) {
+				r.Get("/", getMemberPreferences)
+				r.Put("/", putMemberPreferences)
 			})
 		})
 	}
 }
 
-func getOrganizations(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	user := internalctx.GetUser(ctx)
+// organizationResponse is an organization together with the state of the certificate of its custom domain
+type organizationResponse struct {
+	types.Organization
+	// Certificate is only set if the organization has a verified custom domain and certificates are managed by
+	// cert-manager
+	Certificate *types.OrganizationCertificateStatus `json:"certificate,omitempty"`
+}
 
-	orgs, err := db.GetOrganizationsOfUser(ctx, user.ID)
-	if err != nil {
-		HandleInternalServerError(w, r, err, "could not get orgs for user")
-		return
-	}
+func getOrganizationsHandler(k8sClient client.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := internalctx.GetUser(ctx)
 
-	RespondJSON(w, orgs)
+		orgs, err := db.GetOrganizationsOfUser(ctx, user.ID)
+		if err != nil {
+			HandleInternalServerError(w, r, err, "could not get orgs for user")
+			return
+		}
+
+		result := make([]organizationResponse, len(orgs))
+		for i, org := range orgs {
+			result[i] = newOrganizationResponse(ctx, k8sClient, org)
+		}
+		RespondJSON(w, result)
+	}
 }
 
 func postOrganizationHandler() http.HandlerFunc {
@@ -66,13 +100,28 @@ func postOrganizationHandler() http.HandlerFunc {
 		if ok := validate(w, validateName(orgReq.Name)); !ok {
 			return
 		}
-		if org, err := db.CreateOrganization(ctx, orgReq.Name); errors.Is(err, apierrors.ErrAlreadyExists) {
+		var org *types.Organization
+		err := db.RunTx(ctx, func(ctx context.Context) error {
+			var err error
+			if org, err = db.CreateOrganization(ctx, orgReq.Name); err != nil {
+				return err
+			} else if err := db.AddUserToOrganization(ctx, user.ID, org.ID, types.OrganizationRoleOwner); err != nil {
+				return err
+			} else {
+				return audit.Record(ctx, audit.Event{
+					OrganizationID: &org.ID,
+					Action:         types.AuditActionOrganizationCreate,
+					TargetType:     types.AuditTargetTypeOrganization,
+					TargetID:       org.ID,
+					After:          org,
+				})
+			}
+		})
+		if errors.Is(err, apierrors.ErrAlreadyExists) {
 			Handle4XXErrorWithStatusText(w, http.StatusBadRequest,
 				"An organization with this name already exists. Please choose another name.")
 		} else if err != nil {
 			HandleInternalServerError(w, r, err, "create organization error")
-		} else if err := db.AddUserToOrganization(ctx, user.ID, org.ID); err != nil {
-			HandleInternalServerError(w, r, err, "create organization error")
 		} else {
 			RespondJSON(w, org)
 		}
@@ -81,7 +130,7 @@ func postOrganizationHandler() http.HandlerFunc {
 
 func getOrganizationMembers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	org := getOrganizationIfAllowed(w, r, pathParam)
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionRead)
 	if org == nil {
 		return
 	}
@@ -94,6 +143,68 @@ func getOrganizationMembers(w http.ResponseWriter, r *http.Request) {
 	RespondJSON(w, users)
 }
 
+// getMemberPreferences returns the preferences of the current user for the organization
+func getMemberPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionRead)
+	if org == nil {
+		return
+	}
+
+	if preferences, err := db.GetMemberPreferences(ctx, org.ID, user.ID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "could not get member preferences")
+	} else {
+		RespondJSON(w, preferences)
+	}
+}
+
+// putMemberPreferences updates the preferences of the current user for the organization
+func putMemberPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := internalctx.GetUser(ctx)
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionRead)
+	if org == nil {
+		return
+	}
+
+	var preferences types.MemberPreferences
+	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+
+	if ok := validate(w, validateMemberPreferences(preferences)); !ok {
+		return
+	}
+
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		if before, err := db.GetMemberPreferences(ctx, org.ID, user.ID); err != nil {
+			return err
+		} else if err := db.UpdateMemberPreferences(ctx, org.ID, user.ID, &preferences); err != nil {
+			return err
+		} else {
+			return audit.Record(ctx, audit.Event{
+				OrganizationID: &org.ID,
+				Action:         types.AuditActionMemberPreferencesUpdate,
+				TargetType:     types.AuditTargetTypeMember,
+				TargetID:       user.ID,
+				Before:         before,
+				After:          preferences,
+			})
+		}
+	})
+	if errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "could not update member preferences")
+	} else {
+		RespondJSON(w, preferences)
+	}
+}
+
 func putOrganizationHandler(k8sClient client.Client) http.HandlerFunc {
 	gatewayApplier := apply.MCPGateway(k8sClient)
 
@@ -101,7 +212,7 @@ func putOrganizationHandler(k8sClient client.Client) http.HandlerFunc {
 		ctx := r.Context()
 		log := internalctx.GetLogger(ctx)
 
-		org := getOrganizationIfAllowed(w, r, pathParam)
+		org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageOrganization)
 		if org == nil {
 			return
 		}
@@ -110,6 +221,7 @@ func putOrganizationHandler(k8sClient client.Client) http.HandlerFunc {
 			Settings struct {
 				CustomDomain  *string
 				Authorization *types.OrganizationAuthorizationSettings
+				Gateway       *types.OrganizationGatewaySettings
 			}
 		}
 		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -117,27 +229,62 @@ func putOrganizationHandler(k8sClient client.Client) http.HandlerFunc {
 			return
 		}
 
-		updateNeeded := false
-
-		if request.Settings.CustomDomain != nil {
-			updateNeeded = true
-			if *request.Settings.CustomDomain != "" {
-				if ok := validate(w, validateDomainName(*request.Settings.CustomDomain)); !ok {
-					return
-				}
-				org.Settings.CustomDomain = request.Settings.CustomDomain
-			} else {
-				org.Settings.CustomDomain = nil
+		if customDomain := request.Settings.CustomDomain; customDomain != nil && *customDomain != "" {
+			if ok := validate(w, validateDomainName(*customDomain)); !ok {
+				return
 			}
 		}
-
-		if request.Settings.Authorization != nil {
-			updateNeeded = true
-			org.Settings.Authorization = *request.Settings.Authorization
+		if request.Settings.Gateway != nil {
+			if ok := validate(w, validateOrganizationGatewaySettings(*request.Settings.Gateway)); !ok {
+				return
+			}
 		}
 
+		updateNeeded := request.Settings.CustomDomain != nil || request.Settings.Authorization != nil ||
+			request.Settings.Gateway != nil
 		if updateNeeded {
-			if err := db.UpdateOrganization(ctx, org); err != nil {
+			// the organization is read again with a lock, so that concurrent changes, e.g. the verification of the
+			// custom domain, are not overwritten
+			err := db.RunTx(ctx, func(ctx context.Context) (err error) {
+				if org, err = db.LockOrganization(ctx, org.ID); err != nil {
+					return err
+				}
+				before := *org
+
+				if customDomain := request.Settings.CustomDomain; customDomain != nil {
+					if *customDomain != "" {
+						// a changed domain has to be verified again with a new token
+						if !util.PtrEq(org.Settings.CustomDomain, customDomain) {
+							org.Settings.CustomDomainVerification = types.OrganizationCustomDomainVerification{
+								Token: util.PtrTo(rand.Text()),
+							}
+						}
+						org.Settings.CustomDomain = customDomain
+					} else {
+						org.Settings.CustomDomain = nil
+						org.Settings.CustomDomainVerification = types.OrganizationCustomDomainVerification{}
+					}
+				}
+				if request.Settings.Authorization != nil {
+					org.Settings.Authorization = *request.Settings.Authorization
+				}
+				if request.Settings.Gateway != nil {
+					org.Settings.Gateway = *request.Settings.Gateway
+				}
+
+				if err := db.UpdateOrganization(ctx, org); err != nil {
+					return err
+				}
+				return audit.Record(ctx, audit.Event{
+					OrganizationID: &org.ID,
+					Action:         types.AuditActionOrganizationUpdate,
+					TargetType:     types.AuditTargetTypeOrganization,
+					TargetID:       org.ID,
+					Before:         before,
+					After:          org,
+				})
+			})
+			if err != nil {
 				HandleInternalServerError(w, r, err, "error updating organization")
 				return
 			}
@@ -147,47 +294,177 @@ func putOrganizationHandler(k8sClient client.Client) http.HandlerFunc {
 			log.Error("failed to create MCPGateway resource", zap.Error(err))
 		}
 
-		RespondJSON(w, org)
+		RespondJSON(w, newOrganizationResponse(ctx, k8sClient, *org))
+	}
+}
+
+// newOrganizationResponse adds the state of the certificate of the custom domain from the status of the gateway.
+// If the gateway can not be read, the certificate is omitted, so that the organization can still be shown.
+func newOrganizationResponse(ctx context.Context, k8sClient client.Client, org types.Organization) organizationResponse {
+	response := organizationResponse{Organization: org}
+	if env.GatewayCertManagerIssuer() == nil || !org.Settings.HasVerifiedCustomDomain() {
+		return response
+	}
+
+	status := types.OrganizationCertificateStatus{
+		Host:    *org.Settings.CustomDomain,
+		Reason:  "NotFound",
+		Message: "The gateway has not been created yet",
+	}
+	var gateway v1alpha1.MCPGateway
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: env.GatewayNamespace(), Name: org.Name}, &gateway)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		internalctx.GetLogger(ctx).Error("failed to get MCPGateway", zap.Error(err), zap.Stringer("orgId", org.ID))
+		return response
+	} else if err == nil {
+		condition := meta.FindStatusCondition(gateway.Status.Conditions, v1alpha1.ConditionTypeCertificateReady)
+		if condition == nil {
+			status.Reason = "Pending"
+			status.Message = "The certificate has not been observed yet"
+		} else {
+			status.Ready = condition.Status == metav1.ConditionTrue
+			status.Reason = condition.Reason
+			status.Message = condition.Message
+		}
+	}
+
+	response.Certificate = &status
+	return response
+}
+
+func getGatewaySyncStatus(w http.ResponseWriter, r *http.Request) {
+	org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionRead)
+	if org == nil {
+		return
+	}
+
+	if status, err := db.GetGatewaySyncStatus(r.Context(), org.ID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get gateway sync status")
+	} else {
+		RespondJSON(w, status)
 	}
 }
 
-func putOrganizationMember() http.HandlerFunc {
+var errCustomDomainChanged = errors.New("custom domain changed")
+
+// verifyOrganizationCustomDomain looks up the TXT record of the custom domain immediately instead of waiting for the
+// next periodic check
+func verifyOrganizationCustomDomain(k8sClient client.Client, resolver domainverification.Resolver) http.HandlerFunc {
+	gatewayApplier := apply.MCPGateway(k8sClient)
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
-		log := internalctx.GetLogger(ctx)
-		org := getOrganizationIfAllowed(w, r, pathParam)
+		org := getOrganizationIfAllowed(w, r, pathParam, types.PermissionManageOrganization)
 		if org == nil {
 			return
+		} else if org.Settings.CustomDomain == nil {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "Organization has no custom domain")
+			return
 		}
 
-		var req struct {
-			Email string `json:"email"`
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			Handle4XXError(w, http.StatusBadRequest)
+		// the lookup can take a while, so it is done before the organization is locked
+		found, err := domainverification.Lookup(ctx, resolver, org.Settings)
+		if err != nil {
+			internalctx.GetLogger(ctx).Warn("failed to look up TXT record", zap.Error(err))
+			http.Error(w, "Failed to look up the TXT record of the domain", http.StatusBadGateway)
 			return
 		}
-		req.Email = strings.TrimSpace(req.Email)
-		var user *types.UserAccount
-		err := db.RunTx(ctx, func(ctx context.Context) error {
-			var err error
-			if user, err = db.GetUserByEmailOrCreate(ctx, req.Email); err != nil {
+
+		lookedUp := org.Settings
+		var changed bool
+		err = db.RunTx(ctx, func(ctx context.Context) (err error) {
+			if org, err = db.LockOrganization(ctx, org.ID); err != nil {
 				return err
-			} else if err = db.AddUserToOrganization(ctx, user.ID, org.ID); err != nil && !errors.Is(err, apierrors.ErrAlreadyExists) {
+			} else if !domainverification.SameDomain(org.Settings, lookedUp) {
+				return errCustomDomainChanged
+			}
+
+			before := *org
+			changed = domainverification.Update(&org.Settings, found, time.Now().UTC(), env.DomainVerificationGracePeriod())
+			if err := db.UpdateOrganization(ctx, org); err != nil {
 				return err
-			} else {
+			} else if !changed {
 				return nil
 			}
+			return audit.Record(ctx, audit.Event{
+				OrganizationID: &org.ID,
+				Action:         types.AuditActionOrganizationUpdate,
+				TargetType:     types.AuditTargetTypeOrganization,
+				TargetID:       org.ID,
+				Before:         before,
+				After:          org,
+			})
 		})
-		if err != nil {
-			HandleInternalServerError(w, r, err, "failed to add user to org")
-		} else {
-			if err := mailsending.SendUserInviteMail(ctx, *user, *org); err != nil {
-				log.Error("failed to send invite mail", zap.Error(err))
-				sentry.GetHubFromContext(ctx).CaptureException(err)
+		if errors.Is(err, errCustomDomainChanged) {
+			Handle4XXErrorWithStatusText(w, http.StatusConflict, "The custom domain has been changed, please try again.")
+			return
+		} else if errors.Is(err, apierrors.ErrNotFound) {
+			Handle4XXError(w, http.StatusNotFound)
+			return
+		} else if err != nil {
+			HandleInternalServerError(w, r, err, "error updating organization")
+			return
+		}
+
+		if changed {
+			if err := gatewayApplier.Apply(ctx, *org); err != nil {
+				internalctx.GetLogger(ctx).Error("failed to create MCPGateway resource", zap.Error(err))
 			}
-			RespondJSON(w, user)
 		}
+
+		RespondJSON(w, newOrganizationResponse(ctx, k8sClient, *org))
+	}
+}
+
+func putOrganizationMemberRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	org, role := getOrganizationAndRoleIfAllowed(w, r, pathParam, types.PermissionManageMembers)
+	if org == nil {
+		return
+	}
+	userID := getUserID(w, r)
+	if userID == uuid.Nil {
+		return
+	}
+
+	var req struct {
+		Role types.OrganizationRole `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	}
+	if ok := validate(w, validateOrganizationRole(req.Role)); !ok {
+		return
+	}
+
+	var member *types.OrganizationMember
+	err := db.RunTx(ctx, func(ctx context.Context) error {
+		var err error
+		if member, err = db.GetOrganizationMember(ctx, org.ID, userID); err != nil {
+			return err
+		} else if err := authorizeMemberChange(ctx, org.ID, role, *member, &req.Role); err != nil {
+			return err
+		} else if err := db.UpdateOrganizationMemberRole(ctx, userID, org.ID, req.Role); err != nil {
+			return err
+		}
+		before := *member
+		member.Role = req.Role
+		return audit.Record(ctx, audit.Event{
+			OrganizationID: &org.ID,
+			Action:         types.AuditActionMemberRoleUpdate,
+			TargetType:     types.AuditTargetTypeMember,
+			TargetID:       member.ID,
+			Before:         before,
+			After:          member,
+		})
+	})
+	if err != nil {
+		handleMemberChangeError(w, r, err, "failed to update role of member")
+	} else {
+		RespondJSON(w, member)
 	}
 }
 
@@ -195,7 +472,7 @@ func deleteOrganizationMember() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		user := internalctx.GetUser(ctx)
-		org := getOrganizationIfAllowed(w, r, pathParam)
+		org, role := getOrganizationAndRoleIfAllowed(w, r, pathParam, types.PermissionManageMembers)
 		if org == nil {
 			return
 		}
@@ -207,30 +484,120 @@ func deleteOrganizationMember() http.HandlerFunc {
 			return
 		}
 
-		if err := db.RemoveUserFromOrganization(ctx, toBeRemovedID, org.ID); err != nil {
-			HandleInternalServerError(w, r, err, "failed to remove user from org")
+		err := db.RunTx(ctx, func(ctx context.Context) error {
+			if member, err := db.GetOrganizationMember(ctx, org.ID, toBeRemovedID); err != nil {
+				return err
+			} else if err := authorizeMemberChange(ctx, org.ID, role, *member, nil); err != nil {
+				return err
+			} else if err := db.RemoveUserFromOrganization(ctx, toBeRemovedID, org.ID); err != nil {
+				return err
+			} else {
+				return audit.Record(ctx, audit.Event{
+					OrganizationID: &org.ID,
+					Action:         types.AuditActionMemberRemove,
+					TargetType:     types.AuditTargetTypeMember,
+					TargetID:       member.ID,
+					Before:         member,
+				})
+			}
+		})
+		if err != nil {
+			handleMemberChangeError(w, r, err, "failed to remove user from org")
 		} else {
 			w.WriteHeader(http.StatusAccepted)
 		}
 	}
 }
 
-func getOrganizationIfAllowed(w http.ResponseWriter, r *http.Request, getter paramGetter) *types.Organization {
+// authorizeMemberChange checks if a member with the role callerRole may change the role of member to newRole or
+// remove the member if newRole is nil. Only owners may grant or revoke the owner role, and the last owner of an
+// organization can be neither demoted nor removed.
+var errServiceAccountOwner = errors.New("service accounts can not be owners")
+
+func authorizeMemberChange(
+	ctx context.Context,
+	orgID uuid.UUID,
+	callerRole types.OrganizationRole,
+	member types.OrganizationMember,
+	newRole *types.OrganizationRole,
+) error {
+	revokesOwner := member.Role == types.OrganizationRoleOwner &&
+		(newRole == nil || *newRole != types.OrganizationRoleOwner)
+	grantsOwner := newRole != nil && *newRole == types.OrganizationRoleOwner
+
+	if grantsOwner && member.IsServiceAccount() {
+		return errServiceAccountOwner
+	}
+
+	if (revokesOwner || grantsOwner) && !hasPermission(ctx, callerRole, types.PermissionManageOwners) {
+		return apierrors.ErrForbidden
+	}
+
+	if revokesOwner {
+		if owners, err := db.LockOrganizationOwners(ctx, orgID); err != nil {
+			return err
+		} else if !slices.ContainsFunc(owners, func(id uuid.UUID) bool { return id != member.ID }) {
+			return apierrors.ErrConflict
+		}
+	}
+
+	return nil
+}
+
+func handleMemberChangeError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	switch {
+	case errors.Is(err, apierrors.ErrNotFound):
+		Handle4XXError(w, http.StatusNotFound)
+	case errors.Is(err, apierrors.ErrForbidden):
+		Handle4XXError(w, http.StatusForbidden)
+	case errors.Is(err, apierrors.ErrConflict):
+		Handle4XXErrorWithStatusText(w, http.StatusConflict, "An organization must have at least one owner.")
+	case errors.Is(err, errServiceAccountOwner):
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "Service accounts can not be owners.")
+	default:
+		HandleInternalServerError(w, r, err, msg)
+	}
+}
+
+// getOrganizationIfAllowed returns the organization if the current user is a member with the given permission. It
+// responds with 404 if the user is not a member and with 403 if the role of the user lacks the permission.
+func getOrganizationIfAllowed(
+	w http.ResponseWriter,
+	r *http.Request,
+	getter paramGetter,
+	permission types.Permission,
+) *types.Organization {
+	org, _ := getOrganizationAndRoleIfAllowed(w, r, getter, permission)
+	return org
+}
+
+func getOrganizationAndRoleIfAllowed(
+	w http.ResponseWriter,
+	r *http.Request,
+	getter paramGetter,
+	permission types.Permission,
+) (*types.Organization, types.OrganizationRole) {
 	ctx := r.Context()
 	user := internalctx.GetUser(ctx)
 	if orgIDStr := getter(r, "organizationId"); orgIDStr == "" {
-		return nil
+		return nil, ""
 	} else if orgID, err := uuid.Parse(orgIDStr); err != nil {
 		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid organizationId")
-		return nil
-	} else if ok, org, err := db.IsUserPartOfOrg(ctx, user.ID, orgID); err != nil {
+		return nil, ""
+	} else if role, org, err := db.GetOrganizationRole(ctx, user.ID, orgID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil, ""
+	} else if err != nil {
 		HandleInternalServerError(w, r, err, "failed to check if user is part of org")
-		return nil
-	} else if !ok {
+		return nil, ""
+	} else if role == nil {
 		Handle4XXError(w, http.StatusNotFound)
-		return nil
+		return nil, ""
+	} else if !hasPermission(ctx, *role, permission) {
+		Handle4XXError(w, http.StatusForbidden)
+		return nil, ""
 	} else {
-		return org
+		return org, *role
 	}
 }
 
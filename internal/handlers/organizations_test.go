@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestAuthorizeMemberChangeServiceAccountOwner(t *testing.T) {
+	id := uuid.New()
+	member := types.OrganizationMember{
+		UserAccount: types.UserAccount{ID: id, Email: id.String() + "@" + types.ServiceAccountEmailDomain},
+		Role:        types.OrganizationRoleAdmin,
+	}
+	owner := types.OrganizationRoleOwner
+
+	ctx := context.Background()
+	err := authorizeMemberChange(ctx, uuid.New(), types.OrganizationRoleOwner, member, &owner)
+	if !errors.Is(err, errServiceAccountOwner) {
+		t.Errorf("expected promoting a service account to owner to fail but got %v", err)
+	}
+
+	member.Email = "user@example.com"
+	if err := authorizeMemberChange(ctx, uuid.New(), types.OrganizationRoleOwner, member, &owner); err != nil {
+		t.Errorf("expected promoting a user to owner to succeed but got %v", err)
+	}
+}
@@ -8,34 +8,50 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/hyprmcp/jetski/internal/analytics"
 	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
 	internalctx "github.com/hyprmcp/jetski/internal/context"
 	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/env"
 	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
 	"github.com/hyprmcp/jetski/internal/lists"
+	"github.com/hyprmcp/jetski/internal/notifications"
+	"github.com/hyprmcp/jetski/internal/oidc"
 	"github.com/hyprmcp/jetski/internal/types"
 	"go.uber.org/zap"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func ProjectsRouter(k8sClient client.Client) func(r chi.Router) {
+var errServerMissing = errors.New("server missing")
+
+func ProjectsRouter(k8sClient client.Client, gatewayTokenVerifier *oidc.Verifier) func(r chi.Router) {
 	return func(r chi.Router) {
 		r.Get("/", getProjects)
 		r.Post("/", postProjectHandler(k8sClient))
 		r.Route("/{projectId}", func(r chi.Router) {
 			r.Get("/", getProjectSummary)
+			r.Put("/", putProjectHandler(k8sClient))
 			r.Delete("/", deleteProjectHandler(k8sClient))
 			r.Get("/status", getProjectStatusHandler())
 			r.Get("/logs", getLogsForProject)
 			r.Get("/prompts", getPromptsForProject)
+			r.Get("/prompts/intents", getPromptIntentsForProject)
 			r.Get("/deployment-revisions", getDeploymentRevisionsForProject)
 			r.Get("/analytics", getAnalytics)
+			r.Route("/alert-rules", alertRulesRouter)
+			r.Get("/alerts", getAlertIncidentsForProject)
+			r.Route("/context-properties", contextPropertiesRouter)
+			r.Route("/context", contextValuesRouter(gatewayTokenVerifier))
+			r.Route("/tool-policies", toolPoliciesRouter(k8sClient))
 			r.Put("/settings", putProjectSettings(k8sClient))
+			r.Route("/environment", environmentRouter(k8sClient))
+			r.Route("/upstream-auth", upstreamAuthRouter(k8sClient))
 		})
 	}
 }
@@ -74,13 +90,19 @@ func postProjectHandler(k8sClient client.Client) http.HandlerFunc {
 			return
 		}
 
-		userInOrg, org, err := db.IsUserPartOfOrg(ctx, user.ID, projectReq.OrganizationID)
-		if err != nil {
+		role, org, err := db.GetOrganizationRole(ctx, user.ID, projectReq.OrganizationID)
+		if errors.Is(err, apierrors.ErrNotFound) {
+			Handle4XXError(w, http.StatusBadRequest)
+			return
+		} else if err != nil {
 			HandleInternalServerError(w, r, err, "check user org error")
 			return
-		} else if !userInOrg {
+		} else if role == nil {
 			Handle4XXError(w, http.StatusBadRequest)
 			return
+		} else if !hasPermission(ctx, *role, types.PermissionWriteProjects) {
+			Handle4XXError(w, http.StatusForbidden)
+			return
 		}
 
 		var project *types.Project
@@ -90,6 +112,16 @@ func postProjectHandler(k8sClient client.Client) http.HandlerFunc {
 				return err
 			}
 
+			if err := audit.Record(ctx, audit.Event{
+				OrganizationID: &org.ID,
+				Action:         types.AuditActionProjectCreate,
+				TargetType:     types.AuditTargetTypeProject,
+				TargetID:       project.ID,
+				After:          project,
+			}); err != nil {
+				return err
+			}
+
 			if projectReq.ProxyURL != nil {
 				dr := types.DeploymentRevision{
 					ProjectID:     project.ID,
@@ -109,6 +141,20 @@ func postProjectHandler(k8sClient client.Client) http.HandlerFunc {
 				if err := db.CreateDeploymentRevision(ctx, &dr); err != nil {
 					return err
 				}
+
+				if err := audit.Record(ctx, audit.Event{
+					OrganizationID: &org.ID,
+					Action:         types.AuditActionProjectSettingsUpdate,
+					TargetType:     types.AuditTargetTypeProject,
+					TargetID:       project.ID,
+					After:          newAuditDeploymentSettings(&dr),
+				}); err != nil {
+					return err
+				}
+
+				if err := notifications.Publish(ctx, notifications.DeploymentRevisionCreated(*org, *project, dr)); err != nil {
+					return err
+				}
 			}
 			return nil
 		})
@@ -130,7 +176,7 @@ func postProjectHandler(k8sClient client.Client) http.HandlerFunc {
 
 func getProjectSummary(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	projectID := getProjectIDIfAllowed(w, r, pathParam)
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
 	if projectID == uuid.Nil {
 		return
 	}
@@ -141,9 +187,102 @@ func getProjectSummary(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func putProjectHandler(k8sClient client.Client) http.HandlerFunc {
+	gatewayApplier := apply.MCPGateway(k8sClient)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+		if projectID == uuid.Nil {
+			return
+		}
+
+		var request struct {
+			Settings struct {
+				Analytics *struct {
+					// ExcludedArguments and PromptArgumentName are optional, the stored values are kept if they are omitted
+					ExcludedArguments  *[]string `json:"excludedArguments"`
+					PromptArgumentName *string   `json:"promptArgumentName"`
+				} `json:"analytics"`
+			} `json:"settings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			Handle4XXError(w, http.StatusBadRequest)
+			return
+		}
+
+		ps, err := db.GetProjectSummary(ctx, projectID)
+		if err != nil {
+			HandleInternalServerError(w, r, err, "failed to get project")
+			return
+		}
+
+		project := ps.Project
+		if analytics := request.Settings.Analytics; analytics != nil {
+			excludedArguments := project.Settings.Analytics.ExcludedArguments
+			if analytics.ExcludedArguments != nil {
+				excludedArguments = *analytics.ExcludedArguments
+			}
+			promptArgumentName := project.Settings.Analytics.PromptArgumentName
+			if analytics.PromptArgumentName != nil {
+				promptArgumentName = strings.TrimSpace(*analytics.PromptArgumentName)
+			}
+			if ok := validate(
+				w,
+				validateGlobPatterns(excludedArguments),
+				validateArgumentName(promptArgumentName),
+			); !ok {
+				return
+			}
+
+			promptArgumentNameChanged := promptArgumentName != project.Settings.Analytics.PromptArgumentName
+			if promptArgumentNameChanged && promptArgumentName != types.DefaultPromptArgumentName &&
+				!env.GatewayPromptArgumentNameEnabled() {
+				Handle4XXErrorWithStatusText(w, http.StatusConflict,
+					"Custom prompt argument names are not supported by the MCP gateway.")
+				return
+			}
+			before := project
+			project.Settings.Analytics = types.ProjectAnalyticsSettings{
+				ExcludedArguments:  excludedArguments,
+				PromptArgumentName: promptArgumentName,
+			}
+			err := db.RunTx(ctx, func(ctx context.Context) error {
+				if err := db.UpdateProject(ctx, &project); err != nil {
+					return err
+				}
+				// the prompts of all logs are extracted again by the prompt reindexer in the background
+				if promptArgumentNameChanged {
+					if err := db.RequestPromptReindex(ctx, project.ID); err != nil {
+						return err
+					}
+				}
+				return audit.Record(ctx, audit.Event{
+					OrganizationID: &project.OrganizationID,
+					Action:         types.AuditActionProjectUpdate,
+					TargetType:     types.AuditTargetTypeProject,
+					TargetID:       project.ID,
+					Before:         before,
+					After:          project,
+				})
+			})
+			if err != nil {
+				HandleInternalServerError(w, r, err, "failed to update project")
+				return
+			}
+
+			if promptArgumentNameChanged {
+				applyGatewayForProject(ctx, gatewayApplier, project.ID)
+			}
+		}
+
+		RespondJSON(w, project)
+	}
+}
+
 func getLogsForProject(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	projectID := getProjectIDIfAllowed(w, r, pathParam)
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
 	if projectID == uuid.Nil {
 		return
 	}
@@ -182,7 +321,7 @@ func getLogsForProject(w http.ResponseWriter, r *http.Request) {
 
 func getPromptsForProject(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	projectID := getProjectIDIfAllowed(w, r, pathParam)
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
 	if projectID == uuid.Nil {
 		return
 	}
@@ -197,21 +336,68 @@ func getPromptsForProject(w http.ResponseWriter, r *http.Request) {
 		AllowedSortBy:    []string{"started_at", "tool_name", "prompt"},
 	})
 
-	var mcpSessionID *string
+	var filter db.PromptFilter
 	if s := r.FormValue("mcpSessionId"); s != "" {
-		mcpSessionID = &s
+		filter.MCPSessionID = &s
+	}
+	if s := strings.TrimSpace(r.FormValue("q")); s != "" {
+		filter.Query = &s
+	}
+	if s := r.FormValue("toolName"); s != "" {
+		filter.ToolName = &s
+	}
+	if s := r.FormValue("userAccountId"); s != "" {
+		if u, err := uuid.Parse(s); err != nil {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid userAccountId")
+			return
+		} else {
+			filter.UserAccountID = &u
+		}
+	}
+	if filter.StartedAfter, err = parseUnixTimestampParam(r, "startedAfter"); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid startedAfter timestamp")
+		return
+	}
+	if filter.StartedBefore, err = parseUnixTimestampParam(r, "startedBefore"); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid startedBefore timestamp")
+		return
 	}
 
-	if prompts, err := db.GetPromptsForProject(ctx, projectID, pagination, sorting, mcpSessionID); err != nil {
+	if prompts, err := db.GetPromptsForProject(ctx, projectID, pagination, sorting, filter); err != nil {
 		HandleInternalServerError(w, r, err, "failed to get prompts for project")
 	} else {
 		RespondJSON(w, prompts)
 	}
 }
 
+func getPromptIntentsForProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	startedAfter, err := parseUnixTimestampParam(r, "startedAfter")
+	if err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid startedAfter timestamp")
+		return
+	}
+	startedBefore, err := parseUnixTimestampParam(r, "startedBefore")
+	if err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid startedBefore timestamp")
+		return
+	}
+
+	if intents, err := analytics.GetPromptIntents(ctx, projectID, startedAfter, startedBefore); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get prompt intents for project")
+	} else {
+		RespondJSON(w, intents)
+	}
+}
+
 func getDeploymentRevisionsForProject(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	projectID := getProjectIDIfAllowed(w, r, pathParam)
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
 	if projectID == uuid.Nil {
 		return
 	}
@@ -249,6 +435,9 @@ func putProjectSettings(k8sClient client.Client) http.HandlerFunc {
 			if req.Port == nil {
 				Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "Port is required if OCI URL is set")
 				return
+			} else if *req.Port < 1 || *req.Port > 65535 {
+				Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "Port must be between 1 and 65535")
+				return
 			}
 		} else if req.ProxyURL != nil {
 			if req.Port != nil {
@@ -260,12 +449,13 @@ func putProjectSettings(k8sClient client.Client) http.HandlerFunc {
 			}
 		}
 
-		projectID := getProjectIDIfAllowed(w, r, pathParam)
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
 		if projectID == uuid.Nil {
 			return
 		}
 
-		err := db.RunTx(ctx, func(ctx context.Context) error {
+		var ps *types.ProjectSummary
+		err := db.RunTx(ctx, func(ctx context.Context) (err error) {
 			dr := types.DeploymentRevision{
 				ProjectID:     projectID,
 				CreatedBy:     user.ID,
@@ -273,60 +463,77 @@ func putProjectSettings(k8sClient client.Client) http.HandlerFunc {
 				Telemetry:     req.Telemetry,
 			}
 
-			ps, err := db.GetProjectSummary(ctx, projectID)
+			ps, err = db.GetProjectSummary(ctx, projectID)
 			if err != nil {
 				return err
 			}
 
+			// a project is either hosted or proxied, so setting one of them replaces the other
 			if req.OCIURL != nil {
 				dr.OCIURL = req.OCIURL
-			} else if ps.LatestDeploymentRevision != nil {
-				dr.OCIURL = ps.LatestDeploymentRevision.OCIURL
-			}
-
-			if req.Port != nil {
 				dr.Port = req.Port
-			} else if ps.LatestDeploymentRevision != nil {
-				dr.Port = ps.LatestDeploymentRevision.Port
-			}
-
-			if req.ProxyURL != nil {
+			} else if req.ProxyURL != nil {
 				dr.ProxyURL = req.ProxyURL
 			} else if ps.LatestDeploymentRevision != nil {
+				dr.OCIURL = ps.LatestDeploymentRevision.OCIURL
+				dr.Port = ps.LatestDeploymentRevision.Port
 				dr.ProxyURL = ps.LatestDeploymentRevision.ProxyURL
 			}
 
 			if dr.OCIURL == nil && dr.ProxyURL == nil {
-				Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "One of Proxy URL and OCI URL is required")
-				return nil
+				return errServerMissing
 			}
 
 			if err := db.CreateDeploymentRevision(ctx, &dr); err != nil {
 				return err
 			}
 
-			if dr.OCIURL != nil {
-				if err := db.AddDeploymentRevisionEvent(ctx, dr.ID, types.DeploymentRevisionEventTypeProgressing, nil); err != nil {
+			if ps.LatestDeploymentRevision != nil {
+				if err := db.CopyEnvironmentVariables(ctx, ps.LatestDeploymentRevision.ID, dr.ID); err != nil {
 					return err
 				}
 			}
 
-			ps.LatestDeploymentRevisionID = &dr.ID
-			ps.LatestDeploymentRevision = &dr
+			if err := audit.Record(ctx, audit.Event{
+				OrganizationID: &ps.Organization.ID,
+				Action:         types.AuditActionProjectSettingsUpdate,
+				TargetType:     types.AuditTargetTypeProject,
+				TargetID:       ps.ID,
+				Before:         newAuditDeploymentSettings(ps.LatestDeploymentRevision),
+				After:          newAuditDeploymentSettings(&dr),
+			}); err != nil {
+				return err
+			}
 
-			if err := gatewayApplier.Apply(ctx, ps.Organization); err != nil {
-				log.Error("failed to create MCPGateway resource", zap.Error(err))
+			if err := notifications.Publish(ctx, notifications.DeploymentRevisionCreated(ps.Organization, ps.Project, dr)); err != nil {
+				return err
 			}
 
-			RespondJSON(w, ps)
+			if dr.OCIURL != nil {
+				if _, err := db.AddDeploymentRevisionEvent(ctx, dr.ID, types.DeploymentRevisionEventTypeProgressing, nil); err != nil {
+					return err
+				}
+			}
 
+			ps.LatestDeploymentRevisionID = &dr.ID
+			ps.LatestDeploymentRevision = &dr
 			return nil
 		})
 
-		if err != nil {
+		if errors.Is(err, errServerMissing) {
+			Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "One of Proxy URL and OCI URL is required")
+			return
+		} else if err != nil {
 			HandleInternalServerError(w, r, err, "failed to save settings of project")
 			return
 		}
+
+		// the gateway is only applied after the commit, so that the sync hook sees the copied environment variables
+		if err := gatewayApplier.Apply(ctx, ps.Organization); err != nil {
+			log.Error("failed to create MCPGateway resource", zap.Error(err))
+		}
+
+		RespondJSON(w, ps)
 	}
 }
 
@@ -335,24 +542,35 @@ func deleteProjectHandler(k8sClient client.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		log := internalctx.GetLogger(ctx)
-		projectID := getProjectIDIfAllowed(w, r, pathParam)
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionDeleteProjects)
 		if projectID == uuid.Nil {
 			return
 		}
 
-		var org types.Organization
-		if project, err := db.GetProjectSummary(ctx, projectID); err != nil {
+		ps, err := db.GetProjectSummary(ctx, projectID)
+		if err != nil {
 			if errors.Is(err, apierrors.ErrNotFound) {
 				Handle4XXErrorWithStatusText(w, http.StatusNotFound, "project not found")
 			} else {
 				HandleInternalServerError(w, r, err, "failed to get project summary")
 			}
 			return
-		} else {
-			org = project.Organization
 		}
+		org := ps.Organization
 
-		if err := db.DeleteProject(ctx, projectID); err != nil {
+		err = db.RunTx(ctx, func(ctx context.Context) error {
+			if err := db.DeleteProject(ctx, projectID); err != nil {
+				return err
+			}
+			return audit.Record(ctx, audit.Event{
+				OrganizationID: &org.ID,
+				Action:         types.AuditActionProjectDelete,
+				TargetType:     types.AuditTargetTypeProject,
+				TargetID:       projectID,
+				Before:         ps.Project,
+			})
+		})
+		if err != nil {
 			HandleInternalServerError(w, r, err, "failed to delete project")
 			return
 		}
@@ -369,7 +587,7 @@ func getProjectStatusHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		log := internalctx.GetLogger(ctx)
-		projectID := getProjectIDIfAllowed(w, r, pathParam)
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
 		if projectID == uuid.Nil {
 			return
 		}
@@ -411,7 +629,15 @@ func getProjectStatusHandler() http.HandlerFunc {
 	}
 }
 
-func getProjectIDIfAllowed(w http.ResponseWriter, r *http.Request, getter paramGetter) uuid.UUID {
+// getProjectIDIfAllowed returns the project ID if the current user is a member of the organization of the project
+// with the given permission. It responds with 404 if the user can not access the project and with 403 if the role of
+// the user lacks the permission.
+func getProjectIDIfAllowed(
+	w http.ResponseWriter,
+	r *http.Request,
+	getter paramGetter,
+	permission types.Permission,
+) uuid.UUID {
 	ctx := r.Context()
 	user := internalctx.GetUser(ctx)
 	if projectIDStr := getter(r, "projectId"); projectIDStr == "" {
@@ -419,12 +645,15 @@ func getProjectIDIfAllowed(w http.ResponseWriter, r *http.Request, getter paramG
 	} else if projectID, err := uuid.Parse(projectIDStr); err != nil {
 		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid projectId")
 		return uuid.Nil
-	} else if ok, err := db.CanUserAccessProject(ctx, user.ID, projectID); err != nil {
+	} else if role, err := db.GetProjectRole(ctx, user.ID, projectID); err != nil {
 		HandleInternalServerError(w, r, err, "failed to check if user can access project")
 		return uuid.Nil
-	} else if !ok {
+	} else if role == nil {
 		Handle4XXError(w, http.StatusNotFound)
 		return uuid.Nil
+	} else if !hasPermission(ctx, *role, permission) {
+		Handle4XXError(w, http.StatusForbidden)
+		return uuid.Nil
 	} else {
 		return projectID
 	}
@@ -432,7 +661,7 @@ func getProjectIDIfAllowed(w http.ResponseWriter, r *http.Request, getter paramG
 
 func getAnalytics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	projectID := getProjectIDIfAllowed(w, r, pathParam)
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
 	if projectID == uuid.Nil {
 		return
 	}
@@ -455,3 +684,25 @@ func getAnalytics(w http.ResponseWriter, r *http.Request) {
 		RespondJSON(w, analyticsData)
 	}
 }
+
+// auditDeploymentSettings contains the fields of a deployment revision that can be changed in the project settings
+type auditDeploymentSettings struct {
+	OCIURL        *string `json:"ociUrl"`
+	Port          *int    `json:"port"`
+	ProxyURL      *string `json:"proxyUrl"`
+	Authenticated bool    `json:"authenticated"`
+	Telemetry     bool    `json:"telemetry"`
+}
+
+func newAuditDeploymentSettings(dr *types.DeploymentRevision) *auditDeploymentSettings {
+	if dr == nil {
+		return nil
+	}
+	return &auditDeploymentSettings{
+		OCIURL:        dr.OCIURL,
+		Port:          dr.Port,
+		ProxyURL:      dr.ProxyURL,
+		Authenticated: dr.Authenticated,
+		Telemetry:     dr.Telemetry,
+	}
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/gatewayconfig"
+	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func toolPoliciesRouter(k8sClient client.Client) func(r chi.Router) {
+	gatewayApplier := apply.MCPGateway(k8sClient)
+	return func(r chi.Router) {
+		r.Get("/", getToolPolicies)
+		r.With(requireGatewayFeature(env.GatewayToolPoliciesEnabled, "Tool policies")).
+			Post("/", postToolPolicy(gatewayApplier))
+		r.Post("/evaluate", postToolPolicyEvaluation)
+		r.Route("/{toolPolicyId}", func(r chi.Router) {
+			r.With(requireGatewayFeature(env.GatewayToolPoliciesEnabled, "Tool policies")).
+				Put("/", putToolPolicy(gatewayApplier))
+			r.Delete("/", deleteToolPolicy(gatewayApplier))
+		})
+	}
+}
+
+// requireGatewayFeature rejects requests that configure a feature which is not rendered into the gateway
+// configuration, because the gateway would silently ignore it
+func requireGatewayFeature(enabled func() bool, name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !enabled() {
+				Handle4XXErrorWithStatusText(w, http.StatusConflict, name+" are not supported by the MCP gateway.")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type mcpGatewayApplier interface {
+	Apply(ctx context.Context, org types.Organization) error
+}
+
+type toolPolicyRequest struct {
+	Name     string                 `json:"name"`
+	Priority int                    `json:"priority"`
+	Effect   types.ToolPolicyEffect `json:"effect"`
+	Tools    []string               `json:"tools"`
+	Emails   []string               `json:"emails"`
+	Domains  []string               `json:"domains"`
+	Claims   map[string]string      `json:"claims"`
+}
+
+func (req *toolPolicyRequest) applyTo(policy *types.ToolPolicy) {
+	policy.Name = strings.TrimSpace(req.Name)
+	policy.Priority = req.Priority
+	policy.Effect = req.Effect
+	policy.Tools = req.Tools
+	policy.Emails = make([]string, len(req.Emails))
+	for i, email := range req.Emails {
+		policy.Emails[i] = strings.ToLower(strings.TrimSpace(email))
+	}
+	policy.Domains = make([]string, len(req.Domains))
+	for i, domain := range req.Domains {
+		policy.Domains[i] = strings.ToLower(strings.TrimSpace(domain))
+	}
+	policy.Claims = req.Claims
+	if policy.Claims == nil {
+		policy.Claims = map[string]string{}
+	}
+}
+
+// toolPolicyResponse is a tool policy together with the information whether it is enforced by the gateway. Stored
+// policies are not enforced while tool policies are not rendered into the gateway configuration.
+type toolPolicyResponse struct {
+	types.ToolPolicy
+	Enforced bool `json:"enforced"`
+}
+
+func newToolPolicyResponse(policy types.ToolPolicy) toolPolicyResponse {
+	return toolPolicyResponse{ToolPolicy: policy, Enforced: env.GatewayToolPoliciesEnabled()}
+}
+
+func getToolPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	if policies, err := db.GetToolPoliciesForProject(ctx, projectID); err != nil {
+		HandleInternalServerError(w, r, err, "failed to get tool policies for project")
+	} else {
+		result := make([]toolPolicyResponse, len(policies))
+		for i, policy := range policies {
+			result[i] = newToolPolicyResponse(policy)
+		}
+		RespondJSON(w, result)
+	}
+}
+
+func postToolPolicy(gatewayApplier mcpGatewayApplier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user := internalctx.GetUser(ctx)
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+		if projectID == uuid.Nil {
+			return
+		}
+
+		var request toolPolicyRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			Handle4XXError(w, http.StatusBadRequest)
+			return
+		}
+
+		policy := types.ToolPolicy{ProjectID: projectID, CreatedBy: &user.ID}
+		request.applyTo(&policy)
+		if ok := validate(w, validateToolPolicy(policy)); !ok {
+			return
+		}
+
+		err := db.RunTx(ctx, func(ctx context.Context) error {
+			if err := db.CreateToolPolicy(ctx, &policy); err != nil {
+				return err
+			}
+			return audit.RecordForProject(ctx, projectID, audit.Event{
+				Action:     types.AuditActionToolPolicyCreate,
+				TargetType: types.AuditTargetTypeToolPolicy,
+				TargetID:   policy.ID,
+				After:      policy,
+			})
+		})
+		if err != nil {
+			HandleInternalServerError(w, r, err, "failed to create tool policy")
+		} else {
+			applyGatewayForProject(ctx, gatewayApplier, projectID)
+			RespondJSON(w, newToolPolicyResponse(policy))
+		}
+	}
+}
+
+func putToolPolicy(gatewayApplier mcpGatewayApplier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		policy := getToolPolicyIfAllowed(w, r)
+		if policy == nil {
+			return
+		}
+
+		var request toolPolicyRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			Handle4XXError(w, http.StatusBadRequest)
+			return
+		}
+
+		before := *policy
+		request.applyTo(policy)
+		if ok := validate(w, validateToolPolicy(*policy)); !ok {
+			return
+		}
+
+		err := db.RunTx(ctx, func(ctx context.Context) error {
+			if err := db.UpdateToolPolicy(ctx, policy); err != nil {
+				return err
+			}
+			return audit.RecordForProject(ctx, policy.ProjectID, audit.Event{
+				Action:     types.AuditActionToolPolicyUpdate,
+				TargetType: types.AuditTargetTypeToolPolicy,
+				TargetID:   policy.ID,
+				Before:     before,
+				After:      policy,
+			})
+		})
+		if errors.Is(err, apierrors.ErrNotFound) {
+			Handle4XXError(w, http.StatusNotFound)
+		} else if err != nil {
+			HandleInternalServerError(w, r, err, "failed to update tool policy")
+		} else {
+			applyGatewayForProject(ctx, gatewayApplier, policy.ProjectID)
+			RespondJSON(w, newToolPolicyResponse(*policy))
+		}
+	}
+}
+
+func deleteToolPolicy(gatewayApplier mcpGatewayApplier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		policy := getToolPolicyIfAllowed(w, r)
+		if policy == nil {
+			return
+		}
+
+		err := db.RunTx(ctx, func(ctx context.Context) error {
+			if err := db.DeleteToolPolicy(ctx, policy.ID); err != nil {
+				return err
+			}
+			return audit.RecordForProject(ctx, policy.ProjectID, audit.Event{
+				Action:     types.AuditActionToolPolicyDelete,
+				TargetType: types.AuditTargetTypeToolPolicy,
+				TargetID:   policy.ID,
+				Before:     policy,
+			})
+		})
+		if errors.Is(err, apierrors.ErrNotFound) {
+			Handle4XXError(w, http.StatusNotFound)
+		} else if err != nil {
+			HandleInternalServerError(w, r, err, "failed to delete tool policy")
+		} else {
+			applyGatewayForProject(ctx, gatewayApplier, policy.ProjectID)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+// postToolPolicyEvaluation evaluates the stored policies of the project for the given user and tool without calling
+// the tool. It uses the same evaluation as the gateway. The result is only applied by the gateway if it is enforced.
+func postToolPolicyEvaluation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	var request struct {
+		Email  string         `json:"email"`
+		Claims map[string]any `json:"claims"`
+		Tool   string         `json:"tool"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		Handle4XXError(w, http.StatusBadRequest)
+		return
+	} else if request.Tool == "" {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "tool is required")
+		return
+	}
+
+	policies, err := db.GetToolPoliciesForProject(ctx, projectID)
+	if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get tool policies for project")
+		return
+	}
+
+	gatewayPolicies := make([]gatewayconfig.ToolPolicy, len(policies))
+	for i, policy := range policies {
+		gatewayPolicies[i] = gatewayconfig.ToolPolicy{
+			Effect:  gatewayconfig.ToolPolicyEffect(policy.Effect),
+			Tools:   policy.Tools,
+			Emails:  policy.Emails,
+			Domains: policy.Domains,
+			Claims:  policy.Claims,
+		}
+	}
+
+	subject := gatewayconfig.ToolPolicySubject{Email: request.Email, Claims: request.Claims}
+	allowed, idx := gatewayconfig.EvaluateToolPolicies(gatewayPolicies, subject, request.Tool)
+	response := struct {
+		Allowed  bool              `json:"allowed"`
+		Enforced bool              `json:"enforced"`
+		Policy   *types.ToolPolicy `json:"policy,omitempty"`
+	}{Allowed: allowed, Enforced: env.GatewayToolPoliciesEnabled()}
+	if idx >= 0 {
+		response.Policy = &policies[idx]
+	}
+	RespondJSON(w, response)
+}
+
+func getToolPolicyIfAllowed(w http.ResponseWriter, r *http.Request) *types.ToolPolicy {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+	if projectID == uuid.Nil {
+		return nil
+	}
+
+	if policyID, err := uuid.Parse(r.PathValue("toolPolicyId")); err != nil {
+		Handle4XXErrorWithStatusText(w, http.StatusBadRequest, "invalid toolPolicyId")
+		return nil
+	} else if policy, err := db.GetToolPolicy(ctx, policyID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get tool policy")
+		return nil
+	} else if policy.ProjectID != projectID {
+		Handle4XXError(w, http.StatusNotFound)
+		return nil
+	} else {
+		return policy
+	}
+}
+
+// applyGatewayForProject updates the MCPGateway of the organization of the project. Errors are only logged, because
+// the change has already been saved.
+func applyGatewayForProject(ctx context.Context, gatewayApplier mcpGatewayApplier, projectID uuid.UUID) {
+	log := internalctx.GetLogger(ctx)
+	if ps, err := db.GetProjectSummary(ctx, projectID); err != nil {
+		log.Error("failed to get project summary", zap.Error(err))
+	} else if err := gatewayApplier.Apply(ctx, ps.Organization); err != nil {
+		log.Error("failed to update MCPGateway resource", zap.Error(err))
+	}
+}
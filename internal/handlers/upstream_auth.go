@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/audit"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
+	"github.com/hyprmcp/jetski/internal/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// upstreamAuthRouter contains the endpoints to manage the credentials the gateway uses to authenticate to the
+// upstream server of a proxied project. The secret is never returned.
+func upstreamAuthRouter(k8sClient client.Client) func(r chi.Router) {
+	gatewayApplier := apply.MCPGateway(k8sClient)
+	return func(r chi.Router) {
+		r.Get("/", getUpstreamAuth)
+		r.With(requireGatewayFeature(env.GatewayUpstreamAuthEnabled, "Upstream credentials")).
+			Put("/", putUpstreamAuth(gatewayApplier))
+		r.Delete("/", deleteUpstreamAuth(gatewayApplier))
+	}
+}
+
+type upstreamAuthRequest struct {
+	Type       types.UpstreamAuthType `json:"type"`
+	HeaderName *string                `json:"headerName"`
+	TokenURL   *string                `json:"tokenUrl"`
+	ClientID   *string                `json:"clientId"`
+	Scopes     []string               `json:"scopes"`
+	Secret     *string                `json:"secret"`
+}
+
+// applyTo sets only the fields that are used by the type. The secret of the previous settings is kept if no secret
+// is passed and the type did not change.
+func (req *upstreamAuthRequest) applyTo(auth *types.UpstreamAuth) {
+	previous := *auth
+	auth.Type = req.Type
+	auth.HeaderName = nil
+	auth.TokenURL = nil
+	auth.ClientID = nil
+	auth.Scopes = []string{}
+	switch req.Type {
+	case types.UpstreamAuthTypeHeader:
+		auth.HeaderName = trimSpacePtr(req.HeaderName)
+	case types.UpstreamAuthTypeOAuth2ClientCredentials:
+		auth.TokenURL = trimSpacePtr(req.TokenURL)
+		auth.ClientID = trimSpacePtr(req.ClientID)
+		if req.Scopes != nil {
+			auth.Scopes = req.Scopes
+		}
+	}
+	if req.Secret != nil {
+		auth.Secret = req.Secret
+	} else if previous.Type != req.Type {
+		auth.Secret = nil
+	}
+}
+
+// upstreamAuthResponse is the upstream auth settings together with the information whether they are used by the
+// gateway. Stored settings are not used while upstream auth is not rendered into the gateway configuration.
+type upstreamAuthResponse struct {
+	types.UpstreamAuth
+	Enforced bool `json:"enforced"`
+}
+
+func newUpstreamAuthResponse(auth types.UpstreamAuth) upstreamAuthResponse {
+	return upstreamAuthResponse{UpstreamAuth: auth, Enforced: env.GatewayUpstreamAuthEnabled()}
+}
+
+func getUpstreamAuth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionRead)
+	if projectID == uuid.Nil {
+		return
+	}
+
+	if auth, err := db.GetUpstreamAuth(ctx, projectID); errors.Is(err, apierrors.ErrNotFound) {
+		Handle4XXError(w, http.StatusNotFound)
+	} else if err != nil {
+		HandleInternalServerError(w, r, err, "failed to get upstream auth")
+	} else {
+		RespondJSON(w, newUpstreamAuthResponse(*auth))
+	}
+}
+
+func putUpstreamAuth(gatewayApplier mcpGatewayApplier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+		if projectID == uuid.Nil {
+			return
+		}
+
+		var request upstreamAuthRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			Handle4XXError(w, http.StatusBadRequest)
+			return
+		}
+
+		var before *types.UpstreamAuth
+		auth := types.UpstreamAuth{ProjectID: projectID}
+		if previous, err := db.GetUpstreamAuth(ctx, projectID); err == nil {
+			before = previous
+			auth = *previous
+		} else if !errors.Is(err, apierrors.ErrNotFound) {
+			HandleInternalServerError(w, r, err, "failed to get upstream auth")
+			return
+		}
+
+		request.applyTo(&auth)
+		if ok := validate(w, validateUpstreamAuth(auth)); !ok {
+			return
+		}
+
+		err := db.RunTx(ctx, func(ctx context.Context) error {
+			if err := db.SetUpstreamAuth(ctx, &auth); err != nil {
+				return err
+			}
+			return audit.RecordForProject(ctx, projectID, audit.Event{
+				Action:     types.AuditActionProjectUpstreamAuthUpdate,
+				TargetType: types.AuditTargetTypeProject,
+				TargetID:   projectID,
+				Before:     before,
+				After:      auth,
+			})
+		})
+		if err != nil {
+			HandleInternalServerError(w, r, err, "failed to save upstream auth")
+		} else {
+			applyGatewayForProject(ctx, gatewayApplier, projectID)
+			RespondJSON(w, newUpstreamAuthResponse(auth))
+		}
+	}
+}
+
+func deleteUpstreamAuth(gatewayApplier mcpGatewayApplier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		projectID := getProjectIDIfAllowed(w, r, pathParam, types.PermissionWriteProjects)
+		if projectID == uuid.Nil {
+			return
+		}
+
+		err := db.RunTx(ctx, func(ctx context.Context) error {
+			if err := db.DeleteUpstreamAuth(ctx, projectID); err != nil {
+				return err
+			}
+			return audit.RecordForProject(ctx, projectID, audit.Event{
+				Action:     types.AuditActionProjectUpstreamAuthDelete,
+				TargetType: types.AuditTargetTypeProject,
+				TargetID:   projectID,
+			})
+		})
+		if errors.Is(err, apierrors.ErrNotFound) {
+			Handle4XXError(w, http.StatusNotFound)
+		} else if err != nil {
+			HandleInternalServerError(w, r, err, "failed to delete upstream auth")
+		} else {
+			applyGatewayForProject(ctx, gatewayApplier, projectID)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+func trimSpacePtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	return &trimmed
+}
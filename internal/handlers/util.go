@@ -3,9 +3,12 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/getsentry/sentry-go"
 	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/util"
 	"go.uber.org/zap"
 )
 
@@ -41,3 +44,14 @@ func pathParam(r *http.Request, param string) string {
 func queryParam(r *http.Request, param string) string {
 	return r.URL.Query().Get(param)
 }
+
+// parseUnixTimestampParam parses an optional query parameter containing a unix timestamp (in seconds)
+func parseUnixTimestampParam(r *http.Request, param string) (*time.Time, error) {
+	if s := r.URL.Query().Get(param); s == "" {
+		return nil, nil
+	} else if i, err := strconv.ParseInt(s, 10, 64); err != nil {
+		return nil, err
+	} else {
+		return util.PtrTo(time.Unix(i, 0).UTC()), nil
+	}
+}
@@ -1,10 +1,22 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/mail"
+	"net/url"
+	"path"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
+	"github.com/hyprmcp/jetski/internal/notifications"
+	"github.com/hyprmcp/jetski/internal/types"
 )
 
 type validationFunc func() error
@@ -33,6 +45,15 @@ func validateName(name string) validationFunc {
 	}
 }
 
+func validateEmail(email string) validationFunc {
+	return func() error {
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			return errors.New("email address is invalid")
+		}
+		return nil
+	}
+}
+
 func validateDomainName(domain string) validationFunc {
 	return func() error {
 		if matched, _ := regexp.MatchString(`^([a-z0-9]+\.)+[a-z0-9]+$`, domain); !matched {
@@ -42,3 +63,339 @@ func validateDomainName(domain string) validationFunc {
 		return nil
 	}
 }
+
+func validateGlobPatterns(patterns []string) validationFunc {
+	return func() error {
+		for _, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return errors.New("empty pattern is not allowed")
+			}
+
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("pattern %q is invalid", pattern)
+			}
+		}
+
+		return nil
+	}
+}
+
+func validateArgumentName(name string) validationFunc {
+	return func() error {
+		if name == "" {
+			return errors.New("empty argument name is not allowed")
+		}
+
+		if matched, _ := regexp.MatchString(`^[A-Za-z0-9_.-]+$`, name); !matched {
+			return errors.New("argument name is invalid")
+		}
+
+		return nil
+	}
+}
+
+// validateEvaluationWindowMinutes validates the evaluation window of an alert rule request before it is converted to a
+// time.Duration, which could overflow for large values
+func validateEvaluationWindowMinutes(minutes int) validationFunc {
+	return func() error {
+		if minutes < 1 || minutes > 24*60 {
+			return errors.New("evaluation window must be between 1 and 1440 minutes")
+		}
+		return nil
+	}
+}
+
+func validateAlertRule(rule types.AlertRule) validationFunc {
+	return func() error {
+		if rule.Name == "" {
+			return errors.New("empty name is not allowed")
+		}
+
+		switch rule.Metric {
+		case types.AlertMetricErrorRate, types.AlertMetricRequestVolumeDrop:
+			if rule.Threshold <= 0 || rule.Threshold > 1 {
+				return errors.New("threshold must be between 0 and 1")
+			}
+		case types.AlertMetricP95Latency:
+			if rule.Threshold <= 0 {
+				return errors.New("threshold must be positive")
+			}
+		case types.AlertMetricNewErrorGroup:
+			if rule.Threshold < 1 {
+				return errors.New("threshold must be at least 1")
+			}
+		default:
+			return errors.New("metric is invalid")
+		}
+
+		if rule.EvaluationWindow < time.Minute || rule.EvaluationWindow > 24*time.Hour {
+			return errors.New("evaluation window must be between 1 minute and 24 hours")
+		}
+
+		if rule.MinRequestCount < 0 {
+			return errors.New("minimum request count must not be negative")
+		}
+
+		return nil
+	}
+}
+
+func validateNotificationChannel(channel types.NotificationChannel) validationFunc {
+	return func() error {
+		if channel.Name == "" {
+			return errors.New("empty name is not allowed")
+		}
+
+		if channel.Type != types.NotificationChannelTypeWebhook && channel.Type != types.NotificationChannelTypeSlack {
+			return errors.New("type is invalid")
+		}
+
+		if u, err := url.Parse(channel.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("url must be a valid https URL")
+		}
+
+		for _, eventType := range channel.EventTypes {
+			if !slices.Contains(types.NotificationEventTypes, eventType) {
+				return fmt.Errorf("event type %q is invalid", eventType)
+			}
+		}
+
+		return nil
+	}
+}
+
+// validateNotificationChannelAddress resolves the host of the channel URL and rejects private and reserved addresses.
+// The dispatcher checks the address again for every delivery.
+func validateNotificationChannelAddress(ctx context.Context, channel types.NotificationChannel) validationFunc {
+	return func() error {
+		if err := notifications.CheckURL(ctx, net.DefaultResolver, channel.URL); errors.Is(err, notifications.ErrForbiddenAddress) {
+			return errors.New("url must not point to a private or reserved address")
+		} else if err != nil {
+			return errors.New("url host could not be resolved")
+		}
+		return nil
+	}
+}
+
+func validateMemberPreferences(preferences types.MemberPreferences) validationFunc {
+	return func() error {
+		switch preferences.DigestFrequency {
+		case types.DigestFrequencyNever, types.DigestFrequencyDaily, types.DigestFrequencyWeekly:
+			return nil
+		default:
+			return errors.New("digest frequency is invalid")
+		}
+	}
+}
+
+func validateOrganizationRole(role types.OrganizationRole) validationFunc {
+	return func() error {
+		if !slices.Contains(types.OrganizationRoles, role) {
+			return errors.New("role is invalid")
+		}
+		return nil
+	}
+}
+
+func validateServiceAccountRole(role types.OrganizationRole) validationFunc {
+	return func() error {
+		if role == types.OrganizationRoleOwner {
+			return errors.New("service accounts can not be owners")
+		}
+		return validateOrganizationRole(role)()
+	}
+}
+
+func validateAPITokenName(name string) validationFunc {
+	return func() error {
+		if name == "" {
+			return errors.New("empty name is not allowed")
+		} else if len(name) > 100 {
+			return errors.New("name must not be longer than 100 characters")
+		}
+		return nil
+	}
+}
+
+func validateAPITokenScopes(scopes []types.Permission) validationFunc {
+	return func() error {
+		if len(scopes) == 0 {
+			return errors.New("at least one scope is required")
+		}
+		for i, scope := range scopes {
+			if !slices.Contains(types.Permissions, scope) {
+				return fmt.Errorf("scope %v is invalid", scope)
+			} else if slices.Contains(scopes[:i], scope) {
+				return fmt.Errorf("scope %v is duplicated", scope)
+			}
+		}
+		return nil
+	}
+}
+
+func validateAPITokenExpiry(expiresAt, now time.Time, maxValidity time.Duration) validationFunc {
+	return func() error {
+		if !expiresAt.After(now) {
+			return errors.New("expiresAt must be in the future")
+		} else if expiresAt.After(now.Add(maxValidity)) {
+			return fmt.Errorf("expiresAt must not be more than %v days in the future", int(maxValidity.Hours()/24))
+		}
+		return nil
+	}
+}
+
+func validateContextProperty(property types.ContextProperty) validationFunc {
+	return func() error {
+		if property.Name == "" {
+			return errors.New("empty name is not allowed")
+		}
+
+		// property names are used in header names when values are passed to the MCP server
+		if matched, _ := regexp.MatchString(`^[A-Za-z0-9]+([_-][A-Za-z0-9]+)*$`, property.Name); !matched {
+			return errors.New("name is invalid")
+		}
+
+		switch property.Type {
+		case types.ContextPropertyTypeString, types.ContextPropertyTypeNumber, types.ContextPropertyTypeBoolean:
+			return nil
+		default:
+			return errors.New("type is invalid")
+		}
+	}
+}
+
+func validateContextValues(properties []types.ContextProperty, values map[string]any) validationFunc {
+	return func() error {
+		for name, value := range values {
+			idx := slices.IndexFunc(properties, func(p types.ContextProperty) bool { return p.Name == name })
+			if idx < 0 {
+				return fmt.Errorf("property %v does not exist", name)
+			}
+
+			var ok bool
+			switch properties[idx].Type {
+			case types.ContextPropertyTypeString:
+				// string values are passed to the MCP server as header values
+				if s, isString := value.(string); isString {
+					ok = !strings.ContainsAny(s, "\r\n")
+				}
+			case types.ContextPropertyTypeNumber:
+				_, ok = value.(float64)
+			case types.ContextPropertyTypeBoolean:
+				_, ok = value.(bool)
+			}
+			if !ok {
+				return fmt.Errorf("value of property %v must be a %v", name, properties[idx].Type)
+			}
+		}
+
+		for _, property := range properties {
+			if _, ok := values[property.Name]; property.Required && !ok {
+				return fmt.Errorf("property %v is required", property.Name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func validateToolPolicy(policy types.ToolPolicy) validationFunc {
+	return func() error {
+		if policy.Name == "" {
+			return errors.New("empty name is not allowed")
+		}
+
+		if policy.Effect != types.ToolPolicyEffectAllow && policy.Effect != types.ToolPolicyEffectDeny {
+			return errors.New("effect is invalid")
+		}
+
+		if len(policy.Tools) == 0 {
+			return errors.New("at least one tool pattern is required")
+		} else if err := validateGlobPatterns(policy.Tools)(); err != nil {
+			return err
+		}
+
+		for _, email := range policy.Emails {
+			if err := validateEmail(email)(); err != nil {
+				return err
+			}
+		}
+
+		for _, domain := range policy.Domains {
+			if err := validateDomainName(domain)(); err != nil {
+				return err
+			}
+		}
+
+		for name := range policy.Claims {
+			if strings.TrimSpace(name) == "" {
+				return errors.New("empty claim name is not allowed")
+			}
+		}
+
+		return nil
+	}
+}
+
+func validateEnvironmentVariables(variables []types.EnvironmentVariable) validationFunc {
+	return func() error {
+		names := make(map[string]struct{}, len(variables))
+		for _, variable := range variables {
+			if matched, _ := regexp.MatchString("^[A-Za-z_][A-Za-z0-9_]*$", variable.Name); !matched {
+				return fmt.Errorf("environment variable name %q is invalid", variable.Name)
+			} else if _, exists := names[variable.Name]; exists {
+				return fmt.Errorf("environment variable %v is set more than once", variable.Name)
+			} else if variable.Value == nil {
+				return fmt.Errorf("value of environment variable %v is required", variable.Name)
+			}
+			names[variable.Name] = struct{}{}
+		}
+		return nil
+	}
+}
+
+func validateUpstreamAuth(auth types.UpstreamAuth) validationFunc {
+	return func() error {
+		if auth.Secret == nil || *auth.Secret == "" {
+			return errors.New("secret is required")
+		} else if strings.ContainsAny(*auth.Secret, "\r\n") {
+			return errors.New("secret must not contain line breaks")
+		}
+
+		switch auth.Type {
+		case types.UpstreamAuthTypeHeader:
+			if auth.HeaderName == nil {
+				return errors.New("header name is required")
+			} else if matched, _ := regexp.MatchString("^[A-Za-z0-9-]+$", *auth.HeaderName); !matched {
+				return errors.New("header name is invalid")
+			}
+		case types.UpstreamAuthTypeBearer:
+		case types.UpstreamAuthTypeOAuth2ClientCredentials:
+			if auth.TokenURL == nil {
+				return errors.New("token URL is required")
+			} else if u, err := url.Parse(*auth.TokenURL); err != nil ||
+				(u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.New("token URL is invalid")
+			}
+			if auth.ClientID == nil || strings.TrimSpace(*auth.ClientID) == "" {
+				return errors.New("client ID is required")
+			}
+			for _, scope := range auth.Scopes {
+				if scope == "" || strings.ContainsAny(scope, " \t\r\n") {
+					return fmt.Errorf("scope %q is invalid", scope)
+				}
+			}
+		default:
+			return errors.New("type is invalid")
+		}
+
+		return nil
+	}
+}
+
+func validateOrganizationGatewaySettings(settings types.OrganizationGatewaySettings) validationFunc {
+	return func() error {
+		_, err := apply.GatewayScaling(settings)
+		return err
+	}
+}
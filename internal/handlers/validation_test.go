@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"math"
 	"testing"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
 )
 
 func TestValidateNameE(t *testing.T) {
@@ -51,3 +56,320 @@ func TestValidateDomainE(t *testing.T) {
 	expectErr("foo.Bar")
 	expectErr("Foo.bar")
 }
+
+func TestValidateGlobPatternsE(t *testing.T) {
+	expectNil := func(arg ...string) {
+		if err := validateGlobPatterns(arg)(); err != nil {
+			t.Errorf(`validateGlobPatternsE(%q) expected nil but found error: %v`, arg, err)
+		}
+	}
+
+	expectErr := func(arg ...string) {
+		if err := validateGlobPatterns(arg)(); err == nil {
+			t.Errorf(`validateGlobPatternsE(%q) expected error but found nil`, arg)
+		}
+	}
+
+	expectNil()
+	expectNil("apiKey")
+	expectNil("*token*", "password")
+
+	expectErr("")
+	expectErr(" ")
+	expectErr("[")
+	expectErr("apiKey", "secret[")
+}
+
+func TestValidateArgumentNameE(t *testing.T) {
+	expectNil := func(arg string) {
+		if err := validateArgumentName(arg)(); err != nil {
+			t.Errorf(`validateArgumentNameE("%v") expected nil but found error: %v`, arg, err)
+		}
+	}
+
+	expectErr := func(arg string) {
+		if err := validateArgumentName(arg)(); err == nil {
+			t.Errorf(`validateArgumentNameE("%v") expected error but found nil`, arg)
+		}
+	}
+
+	expectNil("hyprmcpPromptAnalytics")
+	expectNil("user_prompt")
+	expectNil("prompt-1")
+
+	expectErr("")
+	expectErr("user prompt")
+	expectErr("prompt\n")
+}
+
+func TestValidateAlertRuleE(t *testing.T) {
+	valid := types.AlertRule{
+		Name:             "High error rate",
+		Metric:           types.AlertMetricErrorRate,
+		Threshold:        0.05,
+		EvaluationWindow: 5 * time.Minute,
+	}
+
+	expectNil := func(modify func(rule *types.AlertRule)) {
+		rule := valid
+		modify(&rule)
+		if err := validateAlertRule(rule)(); err != nil {
+			t.Errorf(`validateAlertRuleE(%+v) expected nil but found error: %v`, rule, err)
+		}
+	}
+
+	expectErr := func(modify func(rule *types.AlertRule)) {
+		rule := valid
+		modify(&rule)
+		if err := validateAlertRule(rule)(); err == nil {
+			t.Errorf(`validateAlertRuleE(%+v) expected error but found nil`, rule)
+		}
+	}
+
+	expectNil(func(rule *types.AlertRule) {})
+	expectNil(func(rule *types.AlertRule) { rule.Metric = types.AlertMetricP95Latency; rule.Threshold = 1500 })
+	expectNil(func(rule *types.AlertRule) { rule.Metric = types.AlertMetricNewErrorGroup; rule.Threshold = 1 })
+	expectNil(func(rule *types.AlertRule) { rule.Metric = types.AlertMetricRequestVolumeDrop; rule.Threshold = 0.5 })
+
+	expectErr(func(rule *types.AlertRule) { rule.Name = "" })
+	expectErr(func(rule *types.AlertRule) { rule.Metric = "unknown" })
+	expectErr(func(rule *types.AlertRule) { rule.Threshold = 1.5 })
+	expectErr(func(rule *types.AlertRule) { rule.Metric = types.AlertMetricNewErrorGroup; rule.Threshold = 0.5 })
+	expectErr(func(rule *types.AlertRule) { rule.EvaluationWindow = time.Second })
+	expectErr(func(rule *types.AlertRule) { rule.MinRequestCount = -1 })
+}
+
+func TestValidateEvaluationWindowMinutes(t *testing.T) {
+	for _, minutes := range []int{1, 60, 1440} {
+		if err := validateEvaluationWindowMinutes(minutes)(); err != nil {
+			t.Errorf(`validateEvaluationWindowMinutes(%v) expected nil but found error: %v`, minutes, err)
+		}
+	}
+	for _, minutes := range []int{0, -1, 1441, math.MaxInt} {
+		if err := validateEvaluationWindowMinutes(minutes)(); err == nil {
+			t.Errorf(`validateEvaluationWindowMinutes(%v) expected error but found nil`, minutes)
+		}
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	expectNil := func(arg string) {
+		if err := validateEmail(arg)(); err != nil {
+			t.Errorf(`validateEmail("%v") expected nil but found error: %v`, arg, err)
+		}
+	}
+
+	expectErr := func(arg string) {
+		if err := validateEmail(arg)(); err == nil {
+			t.Errorf(`validateEmail("%v") expected error but found nil`, arg)
+		}
+	}
+
+	expectNil("test@example.com")
+	expectNil("test+tag@sub.example.com")
+
+	expectErr("")
+	expectErr("test")
+	expectErr("test@")
+	expectErr("Test <test@example.com>")
+}
+
+func TestValidateAPITokenScopes(t *testing.T) {
+	expectNil := func(arg ...types.Permission) {
+		if err := validateAPITokenScopes(arg)(); err != nil {
+			t.Errorf(`validateAPITokenScopes(%v) expected nil but found error: %v`, arg, err)
+		}
+	}
+
+	expectErr := func(arg ...types.Permission) {
+		if err := validateAPITokenScopes(arg)(); err == nil {
+			t.Errorf(`validateAPITokenScopes(%v) expected error but found nil`, arg)
+		}
+	}
+
+	expectNil(types.PermissionRead)
+	expectNil(types.PermissionRead, types.PermissionWriteProjects)
+
+	expectErr()
+	expectErr("unknown")
+	expectErr(types.PermissionRead, types.PermissionRead)
+}
+
+func TestValidateAPITokenExpiry(t *testing.T) {
+	now := time.Now()
+	if err := validateAPITokenExpiry(now.Add(time.Hour), now, 24*time.Hour)(); err != nil {
+		t.Errorf("expected nil but found error: %v", err)
+	}
+	if err := validateAPITokenExpiry(now, now, 24*time.Hour)(); err == nil {
+		t.Error("expected error for expiry in the past but found nil")
+	}
+	if err := validateAPITokenExpiry(now.Add(25*time.Hour), now, 24*time.Hour)(); err == nil {
+		t.Error("expected error for expiry exceeding the maximum validity but found nil")
+	}
+}
+
+func TestValidateContextProperty(t *testing.T) {
+	expectNil := func(name string, propertyType types.ContextPropertyType) {
+		property := types.ContextProperty{Name: name, Type: propertyType}
+		if err := validateContextProperty(property)(); err != nil {
+			t.Errorf(`validateContextProperty(%v, %v) expected nil but found error: %v`, name, propertyType, err)
+		}
+	}
+
+	expectErr := func(name string, propertyType types.ContextPropertyType) {
+		property := types.ContextProperty{Name: name, Type: propertyType}
+		if err := validateContextProperty(property)(); err == nil {
+			t.Errorf(`validateContextProperty(%v, %v) expected error but found nil`, name, propertyType)
+		}
+	}
+
+	expectNil("tenant", types.ContextPropertyTypeString)
+	expectNil("Tenant-ID", types.ContextPropertyTypeNumber)
+	expectNil("dry_run", types.ContextPropertyTypeBoolean)
+
+	expectErr("", types.ContextPropertyTypeString)
+	expectErr("tenant id", types.ContextPropertyTypeString)
+	expectErr("-tenant", types.ContextPropertyTypeString)
+	expectErr("tenant.id", types.ContextPropertyTypeString)
+	expectErr("tenant", "object")
+}
+
+func TestValidateContextValues(t *testing.T) {
+	properties := []types.ContextProperty{
+		{Name: "tenant", Type: types.ContextPropertyTypeString, Required: true},
+		{Name: "limit", Type: types.ContextPropertyTypeNumber},
+		{Name: "dry-run", Type: types.ContextPropertyTypeBoolean},
+	}
+
+	expectNil := func(values map[string]any) {
+		if err := validateContextValues(properties, values)(); err != nil {
+			t.Errorf(`validateContextValues(%v) expected nil but found error: %v`, values, err)
+		}
+	}
+
+	expectErr := func(values map[string]any) {
+		if err := validateContextValues(properties, values)(); err == nil {
+			t.Errorf(`validateContextValues(%v) expected error but found nil`, values)
+		}
+	}
+
+	expectNil(map[string]any{"tenant": "acme"})
+	expectNil(map[string]any{"tenant": "acme", "limit": 10.0, "dry-run": true})
+
+	expectErr(map[string]any{})
+	expectErr(map[string]any{"tenant": 1.0})
+	expectErr(map[string]any{"tenant": "acme\r\nX-Injected: true"})
+	expectErr(map[string]any{"tenant": "acme", "limit": "10"})
+	expectErr(map[string]any{"tenant": "acme", "dry-run": "true"})
+	expectErr(map[string]any{"tenant": "acme", "unknown": "value"})
+}
+
+func TestValidateToolPolicy(t *testing.T) {
+	valid := types.ToolPolicy{
+		Name:    "admins may delete",
+		Effect:  types.ToolPolicyEffectAllow,
+		Tools:   []string{"delete_*"},
+		Emails:  []string{"admin@example.com"},
+		Domains: []string{"example.com"},
+		Claims:  map[string]string{"groups": "admins"},
+	}
+
+	expectNil := func(policy types.ToolPolicy) {
+		if err := validateToolPolicy(policy)(); err != nil {
+			t.Errorf(`validateToolPolicy(%v) expected nil but found error: %v`, policy, err)
+		}
+	}
+
+	expectErr := func(modify func(policy *types.ToolPolicy)) {
+		policy := valid
+		modify(&policy)
+		if err := validateToolPolicy(policy)(); err == nil {
+			t.Errorf(`validateToolPolicy(%v) expected error but found nil`, policy)
+		}
+	}
+
+	expectNil(valid)
+	expectNil(types.ToolPolicy{Name: "deny all", Effect: types.ToolPolicyEffectDeny, Tools: []string{"*"}})
+
+	expectErr(func(policy *types.ToolPolicy) { policy.Name = "" })
+	expectErr(func(policy *types.ToolPolicy) { policy.Effect = "maybe" })
+	expectErr(func(policy *types.ToolPolicy) { policy.Tools = nil })
+	expectErr(func(policy *types.ToolPolicy) { policy.Tools = []string{"["} })
+	expectErr(func(policy *types.ToolPolicy) { policy.Emails = []string{"admin"} })
+	expectErr(func(policy *types.ToolPolicy) { policy.Domains = []string{"not a domain"} })
+	expectErr(func(policy *types.ToolPolicy) { policy.Claims = map[string]string{"": "admins"} })
+}
+
+func TestValidateEnvironmentVariables(t *testing.T) {
+	value := "value"
+
+	expectNil := func(variables ...types.EnvironmentVariable) {
+		if err := validateEnvironmentVariables(variables)(); err != nil {
+			t.Errorf(`validateEnvironmentVariables(%v) expected nil but found error: %v`, variables, err)
+		}
+	}
+
+	expectErr := func(variables ...types.EnvironmentVariable) {
+		if err := validateEnvironmentVariables(variables)(); err == nil {
+			t.Errorf(`validateEnvironmentVariables(%v) expected error but found nil`, variables)
+		}
+	}
+
+	expectNil()
+	expectNil(
+		types.EnvironmentVariable{Name: "API_KEY", Secret: true, Value: &value},
+		types.EnvironmentVariable{Name: "_debug", Value: &value},
+	)
+
+	expectErr(types.EnvironmentVariable{Name: "", Value: &value})
+	expectErr(types.EnvironmentVariable{Name: "1KEY", Value: &value})
+	expectErr(types.EnvironmentVariable{Name: "API-KEY", Value: &value})
+	expectErr(types.EnvironmentVariable{Name: "API_KEY", Secret: true})
+	expectErr(
+		types.EnvironmentVariable{Name: "API_KEY", Value: &value},
+		types.EnvironmentVariable{Name: "API_KEY", Secret: true, Value: &value},
+	)
+}
+
+func TestValidateUpstreamAuth(t *testing.T) {
+	valid := types.UpstreamAuth{
+		Type:     types.UpstreamAuthTypeOAuth2ClientCredentials,
+		TokenURL: util.PtrTo("https://auth.example.com/token"),
+		ClientID: util.PtrTo("gateway"),
+		Scopes:   []string{"mcp:read", "mcp:write"},
+		Secret:   util.PtrTo("client-secret"),
+	}
+
+	expectNil := func(auth types.UpstreamAuth) {
+		if err := validateUpstreamAuth(auth)(); err != nil {
+			t.Errorf(`validateUpstreamAuth(%v) expected nil but found error: %v`, auth, err)
+		}
+	}
+
+	expectErr := func(modify func(auth *types.UpstreamAuth)) {
+		auth := valid
+		modify(&auth)
+		if err := validateUpstreamAuth(auth)(); err == nil {
+			t.Errorf(`validateUpstreamAuth(%v) expected error but found nil`, auth)
+		}
+	}
+
+	expectNil(valid)
+	expectNil(types.UpstreamAuth{Type: types.UpstreamAuthTypeBearer, Secret: util.PtrTo("token")})
+	expectNil(types.UpstreamAuth{
+		Type:       types.UpstreamAuthTypeHeader,
+		HeaderName: util.PtrTo("X-Api-Key"),
+		Secret:     util.PtrTo("key"),
+	})
+
+	expectErr(func(auth *types.UpstreamAuth) { auth.Type = "basic" })
+	expectErr(func(auth *types.UpstreamAuth) { auth.Secret = nil })
+	expectErr(func(auth *types.UpstreamAuth) { auth.Secret = util.PtrTo("secret\r\nX-Injected: true") })
+	expectErr(func(auth *types.UpstreamAuth) { auth.TokenURL = util.PtrTo("/token") })
+	expectErr(func(auth *types.UpstreamAuth) { auth.ClientID = nil })
+	expectErr(func(auth *types.UpstreamAuth) { auth.Scopes = []string{"mcp read"} })
+	expectErr(func(auth *types.UpstreamAuth) {
+		auth.Type = types.UpstreamAuthTypeHeader
+		auth.HeaderName = util.PtrTo("X Api Key")
+	})
+}
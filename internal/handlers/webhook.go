@@ -7,4 +7,5 @@ import (
 
 func WebhookRouter(r chi.Router) {
 	r.Post("/proxy/{deploymentRevisionID}", gateway.NewHandler())
+	r.Post("/context/{projectID}", gateway.NewContextHandler())
 }
@@ -0,0 +1,119 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/gatewayconfig"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/webhooktoken"
+	"go.uber.org/zap"
+)
+
+const contextHeaderPrefix = "X-Jetski-Context-"
+
+// NewContextHandler returns the handler that resolves the context values of the user for the auth token that is used
+// to connect to the MCP server of a project. If a required property has no value, the request is rejected with
+// status 412, so that the gateway does not forward it to the upstream server. The webhook URL contains a token that
+// has been signed for the project, requests without a valid token are rejected with status 401.
+func NewContextHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := internalctx.GetLogger(ctx)
+
+		projectID, err := uuid.Parse(r.PathValue("projectID"))
+		if err != nil {
+			http.Error(w, "projectID must be a UUID", http.StatusBadRequest)
+			return
+		} else if !webhooktoken.Verify(r.URL.Query().Get(webhooktoken.QueryParam), webhooktoken.PurposeContext, projectID.String()) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		var payload gatewayconfig.ContextRequest
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		properties, err := db.GetContextPropertiesForProject(ctx, projectID)
+		if err != nil {
+			log.Error("failed to get context properties", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var values []types.Context
+		if len(properties) > 0 {
+			if user, err := db.GetUserByEmail(ctx, payload.SubjectEmail); errors.Is(err, apierrors.ErrNotFound) {
+				log.Warn("user not found", zap.Error(err))
+			} else if err != nil {
+				log.Error("failed to get user", zap.Error(err))
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			} else if values, err = db.GetContextValues(ctx, projectID, user.ID, &payload.AuthTokenDigest); err != nil {
+				log.Error("failed to get context values", zap.Error(err))
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		response, missing := getContextResponse(properties, values)
+		if len(missing) > 0 {
+			http.Error(
+				w,
+				fmt.Sprintf("required context properties are not set: %v", strings.Join(missing, ", ")),
+				http.StatusPreconditionFailed,
+			)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Warn("failed to write response", zap.Error(err))
+		}
+	}
+}
+
+// getContextResponse maps the values to headers and metadata keyed by the property name. It also returns the names
+// of all required properties that have no value.
+func getContextResponse(
+	properties []types.ContextProperty,
+	values []types.Context,
+) (response gatewayconfig.ContextResponse, missing []string) {
+	response.Headers = map[string]string{}
+	response.Metadata = map[string]any{}
+	for _, property := range properties {
+		idx := slices.IndexFunc(values, func(v types.Context) bool { return v.ContextPropertyID == property.ID })
+		if idx < 0 || values[idx].ContextPropertyValue == nil {
+			if property.Required {
+				missing = append(missing, property.Name)
+			}
+			continue
+		}
+
+		value := values[idx].ContextPropertyValue
+		response.Metadata[property.Name] = value
+		header := http.CanonicalHeaderKey(contextHeaderPrefix + property.Name)
+		switch v := value.(type) {
+		case string:
+			response.Headers[header] = v
+		case float64:
+			response.Headers[header] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			response.Headers[header] = strconv.FormatBool(v)
+		default:
+			response.Headers[header] = fmt.Sprint(v)
+		}
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestGetContextResponse(t *testing.T) {
+	tenant := types.ContextProperty{ID: uuid.New(), Name: "tenant", Type: types.ContextPropertyTypeString, Required: true}
+	limit := types.ContextProperty{ID: uuid.New(), Name: "limit", Type: types.ContextPropertyTypeNumber}
+	dryRun := types.ContextProperty{ID: uuid.New(), Name: "dry-run", Type: types.ContextPropertyTypeBoolean}
+	properties := []types.ContextProperty{tenant, limit, dryRun}
+
+	response, missing := getContextResponse(properties, []types.Context{
+		{ContextPropertyID: tenant.ID, ContextPropertyValue: "acme"},
+		{ContextPropertyID: limit.ID, ContextPropertyValue: 2.5},
+	})
+	if len(missing) != 0 {
+		t.Errorf("expected no missing properties but found %v", missing)
+	}
+	if v := response.Headers["X-Jetski-Context-Tenant"]; v != "acme" {
+		t.Errorf(`expected tenant header "acme" but found %q`, v)
+	}
+	if v := response.Headers["X-Jetski-Context-Limit"]; v != "2.5" {
+		t.Errorf(`expected limit header "2.5" but found %q`, v)
+	}
+	if _, ok := response.Headers["X-Jetski-Context-Dry-Run"]; ok {
+		t.Error("expected no header for property without value")
+	}
+	if v := response.Metadata["tenant"]; v != "acme" {
+		t.Errorf(`expected tenant metadata "acme" but found %v`, v)
+	}
+
+	_, missing = getContextResponse(properties, []types.Context{
+		{ContextPropertyID: dryRun.ID, ContextPropertyValue: true},
+	})
+	if !slices.Equal(missing, []string{"tenant"}) {
+		t.Errorf("expected tenant to be missing but found %v", missing)
+	}
+}
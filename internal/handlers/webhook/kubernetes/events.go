@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
+	"github.com/hyprmcp/jetski/internal/notifications"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// recordDeploymentRevisionEvents adds an event with the type for the project to the current deployment revision of
+// every project, unless the type did not change since the last event. Notifications are published for errors.
+func recordDeploymentRevisionEvents(
+	ctx context.Context,
+	projects []v1alpha1.ProjectSpec,
+	eventTypes map[string]types.DeploymentRevisionEventType,
+) error {
+	var errs []error
+	for _, project := range projects {
+		eventType, ok := eventTypes[project.ProjectID]
+		if !ok {
+			continue
+		}
+		if err := recordDeploymentRevisionEvent(ctx, project, eventType); err != nil {
+			errs = append(errs, fmt.Errorf("project %v: %w", project.ProjectID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func recordDeploymentRevisionEvent(
+	ctx context.Context,
+	project v1alpha1.ProjectSpec,
+	eventType types.DeploymentRevisionEventType,
+) error {
+	revisionID, err := uuid.Parse(project.DeploymentRevisionID)
+	if err != nil {
+		return err
+	}
+
+	return db.RunTx(ctx, func(ctx context.Context) error {
+		var latestType *types.DeploymentRevisionEventType
+		if latest, err := db.GetLatestDeploymentRevisionEvent(ctx, revisionID); err == nil {
+			latestType = &latest.Type
+		} else if !errors.Is(err, apierrors.ErrNotFound) {
+			return err
+		}
+
+		if !shouldRecordDeploymentRevisionEvent(latestType, eventType) {
+			return nil
+		}
+
+		event, err := db.AddDeploymentRevisionEvent(ctx, revisionID, eventType, nil)
+		if err != nil {
+			return err
+		}
+
+		if eventType == types.DeploymentRevisionEventTypeError {
+			if projectID, err := uuid.Parse(project.ProjectID); err != nil {
+				return err
+			} else if ps, err := db.GetProjectSummary(ctx, projectID); err != nil {
+				return err
+			} else {
+				return notifications.Publish(ctx, notifications.DeploymentRevisionEventError(ps.Organization, ps.Project, *event))
+			}
+		}
+
+		return nil
+	})
+}
+
+// shouldRecordDeploymentRevisionEvent returns true if the event type changed. A revision that has been rolled out
+// successfully does not go back to progressing, because rollouts caused by changes of other projects of the same
+// gateway are not relevant for it.
+func shouldRecordDeploymentRevisionEvent(latest *types.DeploymentRevisionEventType, next types.DeploymentRevisionEventType) bool {
+	if latest == nil {
+		return true
+	} else if *latest == next {
+		return false
+	} else {
+		return *latest != types.DeploymentRevisionEventTypeOK || next != types.DeploymentRevisionEventTypeProgressing
+	}
+}
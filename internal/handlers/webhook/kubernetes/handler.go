@@ -3,10 +3,15 @@ package kubernetes
 import (
 	"encoding/json"
 	"net/http"
+
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"go.uber.org/zap"
 )
 
 func NewHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := internalctx.GetLogger(ctx)
 		var req request
 
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -14,10 +19,19 @@ func NewHandler() http.HandlerFunc {
 			return
 		}
 
-		resp := response{
-			Status: req.GetStatus(),
+		// children must not be computed without the secrets, because that would delete them
+		if err := req.LoadEnvironment(ctx); err != nil {
+			log.Error("failed to load environment of hosted MCP servers", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if err := req.LoadUpstreamAuth(ctx); err != nil {
+			log.Error("failed to load upstream auth of proxied MCP servers", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 
+		var resp response
+
 		if desired, err := req.GetDesiredChildren(); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -25,6 +39,16 @@ func NewHandler() http.HandlerFunc {
 			resp.Children = desired
 		}
 
+		// failing to compute the status must not block the rollout of the children
+		if status, eventTypes, err := req.GetStatus(); err != nil {
+			log.Error("failed to compute MCPGateway status", zap.Error(err))
+		} else {
+			resp.Status = status
+			if err := recordDeploymentRevisionEvents(ctx, req.Parent.Spec.Projects, eventTypes); err != nil {
+				log.Error("failed to record deployment revision events", zap.Error(err))
+			}
+		}
+
 		_ = json.NewEncoder(w).Encode(resp)
 	}
 }
@@ -0,0 +1,366 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
+	"github.com/hyprmcp/jetski/internal/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	reasonNotFound                 = "NotFound"
+	reasonConfigOutdated           = "ConfigOutdated"
+	reasonRolloutInProgress        = "RolloutInProgress"
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	reasonReplicaFailure           = "ReplicaFailure"
+	reasonAvailable                = "Available"
+	reasonAddressPending           = "AddressPending"
+	reasonAddressAssigned          = "AddressAssigned"
+	reasonRoutePending             = "RoutePending"
+	reasonAccepted                 = "Accepted"
+	reasonCertificatePending       = "CertificatePending"
+	reasonReady                    = "Ready"
+)
+
+// GetStatus computes the status of the gateway from the observed children. It also returns the state of the rollout
+// of the current spec for each project, keyed by the project ID. The state of a hosted project also depends on the
+// deployment of its MCP server.
+func (req *request) GetStatus() (*v1alpha1.MCPGatewayStatus, map[string]types.DeploymentRevisionEventType, error) {
+	_, configHash, err := req.getGatewayConfigYAML()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deployment, err := getObservedChild[appsv1.Deployment](req, "Deployment.apps/v1", req.GetGatewayName())
+	if err != nil {
+		return nil, nil, err
+	}
+	var ingress *networkingv1.Ingress
+	var route *httpRoute
+	if env.GatewayRoutingMode() == env.GatewayRoutingModeHTTPRoute {
+		route, err = getObservedChild[httpRoute](req, "HTTPRoute.gateway.networking.k8s.io/v1", req.Parent.Name)
+	} else {
+		ingress, err = getObservedChild[networkingv1.Ingress](req, "Ingress.networking.k8s.io/v1", req.Parent.Name)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := v1alpha1.MCPGatewayStatus{
+		ObservedGeneration: req.Parent.Generation,
+		Conditions:         slices.Clone(req.Parent.Status.Conditions),
+	}
+
+	if deployment != nil {
+		status.Replicas = deployment.Status.Replicas
+		status.UpdatedReplicas = deployment.Status.UpdatedReplicas
+		status.ReadyReplicas = deployment.Status.ReadyReplicas
+		status.AvailableReplicas = deployment.Status.AvailableReplicas
+	}
+	if ingress != nil {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if lb.Hostname != "" {
+				status.Address = lb.Hostname
+			} else if lb.IP != "" {
+				status.Address = lb.IP
+			}
+			if status.Address != "" {
+				break
+			}
+		}
+	}
+
+	deploymentCondition := getDeploymentCondition(
+		req.GetGatewayName(),
+		deployment,
+		gatewayConfigHashAnnotation,
+		configHash,
+	)
+	deploymentCondition.Type = v1alpha1.ConditionTypeDeploymentAvailable
+	gatewayEventType := getDeploymentRevisionEventType(deploymentCondition)
+	eventTypes := make(map[string]types.DeploymentRevisionEventType, len(req.Parent.Spec.Projects))
+	serversCondition := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeServersAvailable,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonAvailable,
+		Message: "All hosted MCP servers are available",
+	}
+
+	for _, project := range req.Parent.Spec.Projects {
+		if !isHosted(project) {
+			eventTypes[project.ProjectID] = gatewayEventType
+			continue
+		}
+
+		serverName := req.GetServerName(project)
+		serverDeployment, err := getObservedChild[appsv1.Deployment](req, "Deployment.apps/v1", serverName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		serverCondition := getDeploymentCondition(
+			serverName,
+			serverDeployment,
+			deploymentRevisionAnnotation,
+			project.DeploymentRevisionID,
+		)
+		serverStatus := v1alpha1.ServerStatus{
+			ProjectID:            project.ProjectID,
+			DeploymentRevisionID: project.DeploymentRevisionID,
+			Available:            serverCondition.Status == metav1.ConditionTrue,
+			Reason:               serverCondition.Reason,
+			Message:              serverCondition.Message,
+		}
+		if serverDeployment != nil {
+			serverStatus.AvailableReplicas = serverDeployment.Status.AvailableReplicas
+		}
+		status.Servers = append(status.Servers, serverStatus)
+
+		if !serverStatus.Available && serversCondition.Status == metav1.ConditionTrue {
+			serversCondition.Status = serverCondition.Status
+			serversCondition.Reason = serverCondition.Reason
+			serversCondition.Message = serverCondition.Message
+		}
+
+		eventTypes[project.ProjectID] = combineDeploymentRevisionEventTypes(
+			gatewayEventType,
+			getDeploymentRevisionEventType(serverCondition),
+		)
+	}
+
+	conditions := []metav1.Condition{deploymentCondition, serversCondition}
+	if env.GatewayCertManagerIssuer() != nil && req.Parent.Spec.CustomDomain != nil {
+		certificate, err := getObservedChild[certManagerCertificate](req, "Certificate.cert-manager.io/v1", req.GetCertificateName())
+		if err != nil {
+			return nil, nil, err
+		}
+		conditions = append(conditions, getCertificateCondition(certificate))
+	} else {
+		meta.RemoveStatusCondition(&status.Conditions, v1alpha1.ConditionTypeCertificateReady)
+	}
+
+	var ingressCondition metav1.Condition
+	if env.GatewayRoutingMode() == env.GatewayRoutingModeHTTPRoute {
+		ingressCondition = getHTTPRouteCondition(route)
+	} else {
+		ingressCondition = getIngressCondition(ingress, status.Address)
+	}
+	readyCondition := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonReady,
+		Message: "The gateway is ready",
+	}
+	conditions = append(conditions, ingressCondition)
+	for _, condition := range conditions {
+		if condition.Status != metav1.ConditionTrue {
+			readyCondition.Status = condition.Status
+			readyCondition.Reason = condition.Reason
+			readyCondition.Message = condition.Message
+			break
+		}
+	}
+
+	for _, condition := range append(conditions, readyCondition) {
+		condition.ObservedGeneration = req.Parent.Generation
+		meta.SetStatusCondition(&status.Conditions, condition)
+	}
+
+	return &status, eventTypes, nil
+}
+
+// getObservedChild decodes the observed child with the given metacontroller key ("Kind.apiVersion") and name. It
+// returns nil if the child does not exist.
+func getObservedChild[T any](req *request, key, name string) (*T, error) {
+	if obj, ok := req.Children[key][name]; !ok {
+		return nil, nil
+	} else if obj, ok := obj.(map[string]any); !ok {
+		return nil, fmt.Errorf("observed %v %v is not an object", key, name)
+	} else {
+		var result T
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode observed %v %v: %w", key, name, err)
+		}
+		return &result, nil
+	}
+}
+
+// getDeploymentCondition returns whether the deployment with the given name runs the pod template with the expected
+// annotation value on all replicas. The type of the condition must be set by the caller.
+func getDeploymentCondition(
+	name string,
+	deployment *appsv1.Deployment,
+	annotation string,
+	expectedValue string,
+) metav1.Condition {
+	condition := metav1.Condition{Status: metav1.ConditionFalse}
+
+	if deployment == nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonNotFound
+		condition.Message = fmt.Sprintf("The deployment %v has not been created yet", name)
+		return condition
+	}
+
+	for _, c := range deployment.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse &&
+			c.Reason == "ProgressDeadlineExceeded" {
+			condition.Reason = reasonProgressDeadlineExceeded
+			condition.Message = c.Message
+			return condition
+		} else if c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue {
+			condition.Reason = reasonReplicaFailure
+			condition.Message = c.Message
+			return condition
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	switch {
+	case deployment.Spec.Template.Annotations[annotation] != expectedValue:
+		condition.Reason = reasonConfigOutdated
+		condition.Message = fmt.Sprintf("The deployment %v has not been updated with the current config yet", name)
+	case deployment.Status.ObservedGeneration < deployment.Generation,
+		deployment.Status.UpdatedReplicas < replicas,
+		deployment.Status.Replicas > deployment.Status.UpdatedReplicas,
+		deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas:
+		condition.Reason = reasonRolloutInProgress
+		condition.Message = fmt.Sprintf("%v of %v updated replicas of the deployment %v are available",
+			deployment.Status.AvailableReplicas, replicas, name)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonAvailable
+		condition.Message = fmt.Sprintf("%v of %v updated replicas of the deployment %v are available",
+			deployment.Status.AvailableReplicas, replicas, name)
+	}
+
+	return condition
+}
+
+func getIngressCondition(ingress *networkingv1.Ingress, address string) metav1.Condition {
+	condition := metav1.Condition{Type: v1alpha1.ConditionTypeIngressReady}
+	switch {
+	case ingress == nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonNotFound
+		condition.Message = "The gateway ingress has not been created yet"
+	case address == "":
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonAddressPending
+		condition.Message = "The gateway ingress has not been assigned an address yet"
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonAddressAssigned
+		condition.Message = fmt.Sprintf("The gateway ingress is reachable at %v", address)
+	}
+	return condition
+}
+
+// httpRoute contains the fields of a Gateway API HTTPRoute that are needed to compute the status
+type httpRoute struct {
+	Status struct {
+		Parents []httpRouteParentStatus `json:"parents"`
+	} `json:"status"`
+}
+
+type httpRouteParentStatus struct {
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+// getHTTPRouteCondition returns whether the HTTPRoute has been accepted by its parent Gateway. The route does not have
+// an address, so the condition does not depend on it.
+func getHTTPRouteCondition(route *httpRoute) metav1.Condition {
+	condition := metav1.Condition{Type: v1alpha1.ConditionTypeIngressReady}
+	if route == nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonNotFound
+		condition.Message = "The gateway route has not been created yet"
+		return condition
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = reasonRoutePending
+	condition.Message = "The gateway route has not been accepted by the parent gateway yet"
+	for _, parent := range route.Status.Parents {
+		if accepted := meta.FindStatusCondition(parent.Conditions, "Accepted"); accepted == nil {
+			continue
+		} else if accepted.Status == metav1.ConditionTrue {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = reasonAccepted
+			condition.Message = "The gateway route has been accepted by the parent gateway"
+			break
+		} else {
+			condition.Reason = accepted.Reason
+			condition.Message = accepted.Message
+		}
+	}
+	return condition
+}
+
+// certManagerCertificate contains the fields of a cert-manager Certificate that are needed to compute the status
+type certManagerCertificate struct {
+	Status struct {
+		Conditions []metav1.Condition `json:"conditions"`
+	} `json:"status"`
+}
+
+// getCertificateCondition returns whether cert-manager has issued the certificate
+func getCertificateCondition(certificate *certManagerCertificate) metav1.Condition {
+	condition := metav1.Condition{Type: v1alpha1.ConditionTypeCertificateReady}
+	if certificate == nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonNotFound
+		condition.Message = "The certificate has not been created yet"
+	} else if ready := meta.FindStatusCondition(certificate.Status.Conditions, "Ready"); ready == nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonCertificatePending
+		condition.Message = "The certificate has not been issued yet"
+	} else {
+		condition.Status = ready.Status
+		condition.Reason = ready.Reason
+		condition.Message = ready.Message
+		if condition.Reason == "" {
+			condition.Reason = reasonCertificatePending
+		}
+	}
+	return condition
+}
+
+// getDeploymentRevisionEventType maps the deployment condition to the state of the deployment revisions. The ingress
+// is not considered, because some clusters never assign an address to ingresses.
+func getDeploymentRevisionEventType(deploymentCondition metav1.Condition) types.DeploymentRevisionEventType {
+	switch deploymentCondition.Reason {
+	case reasonAvailable:
+		return types.DeploymentRevisionEventTypeOK
+	case reasonProgressDeadlineExceeded, reasonReplicaFailure:
+		return types.DeploymentRevisionEventTypeError
+	default:
+		return types.DeploymentRevisionEventTypeProgressing
+	}
+}
+
+// combineDeploymentRevisionEventTypes returns the state of a revision that depends on multiple deployments. It is an
+// error if any deployment failed and ok only if all deployments are available.
+func combineDeploymentRevisionEventTypes(eventTypes ...types.DeploymentRevisionEventType) types.DeploymentRevisionEventType {
+	result := types.DeploymentRevisionEventTypeOK
+	for _, eventType := range eventTypes {
+		if eventType == types.DeploymentRevisionEventTypeError {
+			return eventType
+		} else if eventType != types.DeploymentRevisionEventTypeOK {
+			result = eventType
+		}
+	}
+	return result
+}
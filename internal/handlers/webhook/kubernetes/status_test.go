@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDeploymentCondition(t *testing.T) {
+	newDeployment := func(hash string, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: util.PtrTo(int32(2))},
+			Status:     status,
+		}
+		deployment.Spec.Template.Annotations = map[string]string{gatewayConfigHashAnnotation: hash}
+		return deployment
+	}
+
+	expect := func(deployment *appsv1.Deployment, reason string, eventType types.DeploymentRevisionEventType) {
+		condition := getDeploymentCondition("gateway", deployment, gatewayConfigHashAnnotation, "current")
+		if condition.Reason != reason {
+			t.Errorf("expected reason %v but found %v", reason, condition.Reason)
+		}
+		if et := getDeploymentRevisionEventType(condition); et != eventType {
+			t.Errorf("expected event type %v but found %v", eventType, et)
+		}
+	}
+
+	available := appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	expect(nil, reasonNotFound, types.DeploymentRevisionEventTypeProgressing)
+	expect(newDeployment("current", available), reasonAvailable, types.DeploymentRevisionEventTypeOK)
+	expect(newDeployment("previous", available), reasonConfigOutdated, types.DeploymentRevisionEventTypeProgressing)
+	expect(
+		newDeployment("current", appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}),
+		reasonRolloutInProgress,
+		types.DeploymentRevisionEventTypeProgressing,
+	)
+	expect(
+		newDeployment("current", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2}),
+		reasonRolloutInProgress,
+		types.DeploymentRevisionEventTypeProgressing,
+	)
+	expect(
+		newDeployment("current", appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           2,
+			UpdatedReplicas:    1,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionFalse,
+				Reason: "ProgressDeadlineExceeded",
+			}},
+		}),
+		reasonProgressDeadlineExceeded,
+		types.DeploymentRevisionEventTypeError,
+	)
+}
+
+func TestCombineDeploymentRevisionEventTypes(t *testing.T) {
+	ok := types.DeploymentRevisionEventTypeOK
+	progressing := types.DeploymentRevisionEventTypeProgressing
+	failed := types.DeploymentRevisionEventTypeError
+
+	expect := func(expected types.DeploymentRevisionEventType, eventTypes ...types.DeploymentRevisionEventType) {
+		if actual := combineDeploymentRevisionEventTypes(eventTypes...); actual != expected {
+			t.Errorf("combineDeploymentRevisionEventTypes(%v) expected %v but found %v", eventTypes, expected, actual)
+		}
+	}
+
+	expect(ok, ok, ok)
+	expect(progressing, ok, progressing)
+	expect(progressing, progressing, ok)
+	expect(failed, progressing, failed)
+	expect(failed, failed, ok)
+}
+
+func TestShouldRecordDeploymentRevisionEvent(t *testing.T) {
+	ok := types.DeploymentRevisionEventTypeOK
+	progressing := types.DeploymentRevisionEventTypeProgressing
+	failed := types.DeploymentRevisionEventTypeError
+
+	expect := func(latest *types.DeploymentRevisionEventType, next types.DeploymentRevisionEventType, expected bool) {
+		if actual := shouldRecordDeploymentRevisionEvent(latest, next); actual != expected {
+			t.Errorf("shouldRecordDeploymentRevisionEvent(%v, %v) expected %v but found %v",
+				latest, next, expected, actual)
+		}
+	}
+
+	expect(nil, progressing, true)
+	expect(&progressing, progressing, false)
+	expect(&progressing, ok, true)
+	expect(&ok, progressing, false)
+	expect(&ok, failed, true)
+	expect(&failed, ok, true)
+}
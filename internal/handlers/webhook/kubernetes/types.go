@@ -1,50 +1,150 @@
 package kubernetes
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
+	"strings"
 
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/db"
 	"github.com/hyprmcp/jetski/internal/env"
 	"github.com/hyprmcp/jetski/internal/gatewayconfig"
 	"github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
+	"github.com/hyprmcp/jetski/internal/types"
 	"github.com/hyprmcp/jetski/internal/util"
+	"github.com/hyprmcp/jetski/internal/webhooktoken"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxDNSLabelLength is the maximum length of names that must be RFC 1035 labels, e.g. of Services
+const maxDNSLabelLength = 63
+
+var (
+	privateIPv4Ranges = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "169.254.0.0/16", "100.64.0.0/10"}
+	privateIPv6Ranges = []string{"fc00::/7", "fe80::/10"}
+)
+
+const (
+	gatewayConfigHashAnnotation  = "gatewayConfigHash"
+	deploymentRevisionAnnotation = "deploymentRevisionId"
+	upstreamAuthMountPath        = "/opt/upstream-auth"
+)
+
 type request struct {
 	Parent   v1alpha1.MCPGateway       `json:"parent"`
 	Children map[string]map[string]any `json:"children"`
+
+	// environment contains the decrypted environment variables of the hosted MCP servers, keyed by the project ID
+	environment map[string][]types.EnvironmentVariable
+	// upstreamAuthSecrets contains the decrypted secrets of the upstream auth settings, keyed by the project ID
+	upstreamAuthSecrets map[string]string
+}
+
+// LoadEnvironment loads the environment variables of the deployment revisions of all hosted MCP servers
+func (req *request) LoadEnvironment(ctx context.Context) error {
+	req.environment = map[string][]types.EnvironmentVariable{}
+	for _, project := range req.Parent.Spec.Projects {
+		if !isHosted(project) {
+			continue
+		}
+		if revisionID, err := uuid.Parse(project.DeploymentRevisionID); err != nil {
+			return err
+		} else if variables, err := db.GetEnvironmentVariables(ctx, revisionID); err != nil {
+			return fmt.Errorf("project %v: %w", project.ProjectID, err)
+		} else {
+			req.environment[project.ProjectID] = variables
+		}
+	}
+	return nil
+}
+
+// LoadUpstreamAuth loads the secrets of the upstream auth settings of all proxied projects. Upstream auth is only
+// rendered into the gateway configuration for projects with a secret, so no secrets are loaded if it is disabled.
+func (req *request) LoadUpstreamAuth(ctx context.Context) error {
+	req.upstreamAuthSecrets = map[string]string{}
+	if !env.GatewayUpstreamAuthEnabled() {
+		return nil
+	}
+	for _, project := range req.Parent.Spec.Projects {
+		if isHosted(project) || project.ProxyURL == nil || project.UpstreamAuth == nil {
+			continue
+		}
+		if projectID, err := uuid.Parse(project.ProjectID); err != nil {
+			return err
+		} else if auth, err := db.GetUpstreamAuth(ctx, projectID); errors.Is(err, apierrors.ErrNotFound) {
+			// the settings have been deleted after the spec was applied
+		} else if err != nil {
+			return fmt.Errorf("project %v: %w", project.ProjectID, err)
+		} else {
+			req.upstreamAuthSecrets[project.ProjectID] = *auth.Secret
+		}
+	}
+	return nil
 }
 
 func (req *request) GetDesiredChildren() ([]client.Object, error) {
 	configName := fmt.Sprintf("%v-config", req.Parent.Name)
-	gatewayName := fmt.Sprintf("%v-gateway", req.Parent.Name)
+	gatewayName := req.GetGatewayName()
 	gatewayLabels := map[string]string{
 		"app":                "mcp-gateway",
 		"jetskiOrganization": req.Parent.Spec.OrganizationID,
 	}
 
-	gatewayConfig, err := req.GetGatewayConfig()
+	gatewayConfigStr, gatewayConfigHash, err := req.getGatewayConfigYAML()
 	if err != nil {
 		return nil, err
 	}
 
-	gatewayConfigStr, err := gatewayConfig.YAMLString()
-	if err != nil {
-		return nil, err
+	gatewayAnnotations := map[string]string{
+		gatewayConfigHashAnnotation: gatewayConfigHash,
 	}
 
-	gatewayConfigHash := sha256.Sum256([]byte(gatewayConfigStr))
-	gatewayAnnotations := map[string]string{
-		"gatewayConfigHash": hex.EncodeToString(gatewayConfigHash[:]),
+	gatewayDeployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: req.Parent.Namespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: req.Parent.Spec.Replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: gatewayLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: gatewayLabels, Annotations: gatewayAnnotations},
+				Spec: corev1.PodSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "image-pull-secret"}},
+					Containers: []corev1.Container{{
+						Name:            "gateway",
+						Image:           env.GatewayContainerImageTag(),
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Args:            []string{"--config", "/opt/config.yaml"},
+						Resources:       req.getGatewayResources(),
+						Ports:           []corev1.ContainerPort{{Name: "http", ContainerPort: 9000}},
+						VolumeMounts:    []corev1.VolumeMount{{Name: "config", SubPath: "config.yaml", MountPath: "/opt/config.yaml"}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: configName},
+							},
+						},
+					}},
+				},
+			},
+		},
 	}
 
 	// When adding resources, make sure that the resource type is also registered in the CompositeController
@@ -55,40 +155,191 @@ func (req *request) GetDesiredChildren() ([]client.Object, error) {
 			ObjectMeta: metav1.ObjectMeta{Name: configName, Namespace: req.Parent.Namespace},
 			Data:       map[string]string{"config.yaml": gatewayConfigStr},
 		},
+		gatewayDeployment,
+		&corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: req.Parent.Namespace},
+			Spec: corev1.ServiceSpec{
+				Selector: gatewayLabels,
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 9000}},
+			},
+		},
+	}
+
+	// the replicas are managed by the HorizontalPodAutoscaler and must not be overwritten on every sync
+	if autoscaling := req.Parent.Spec.Autoscaling; autoscaling != nil {
+		gatewayDeployment.Spec.Replicas = nil
+		result = append(result, &autoscalingv2.HorizontalPodAutoscaler{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler"},
+			ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: req.Parent.Namespace},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       gatewayName,
+				},
+				MinReplicas: &autoscaling.MinReplicas,
+				MaxReplicas: autoscaling.MaxReplicas,
+				Metrics: []autoscalingv2.MetricSpec{{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: &autoscaling.TargetCPUUtilizationPercentage,
+						},
+					},
+				}},
+			},
+		})
+	}
+
+	if pdb := req.Parent.Spec.PodDisruptionBudget; pdb != nil {
+		result = append(result, &policyv1.PodDisruptionBudget{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "policy/v1", Kind: "PodDisruptionBudget"},
+			ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: req.Parent.Namespace},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector:     &metav1.LabelSelector{MatchLabels: gatewayLabels},
+				MinAvailable: util.PtrTo(intstr.FromInt32(pdb.MinAvailable)),
+			},
+		})
+	}
+
+	if len(req.upstreamAuthSecrets) > 0 {
+		upstreamAuthName := fmt.Sprintf("%v-upstream-auth", req.Parent.Name)
+		secret := &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: upstreamAuthName, Namespace: req.Parent.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       make(map[string][]byte, len(req.upstreamAuthSecrets)),
+		}
+		for projectID, value := range req.upstreamAuthSecrets {
+			secret.Data[projectID] = []byte(value)
+		}
+		result = append(result, secret)
+
+		// the secret is not mounted with a sub path, so that rotated secrets are updated in the running pods
+		podSpec := &gatewayDeployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "upstream-auth",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: upstreamAuthName},
+			},
+		})
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "upstream-auth",
+			MountPath: upstreamAuthMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	// with HTTPRoutes, TLS is terminated by the parent Gateway, so certificates are only created for Ingresses
+	if env.GatewayRoutingMode() == env.GatewayRoutingModeHTTPRoute {
+		result = append(result, req.getHTTPRoute())
+	} else {
+		ingress := req.getIngress()
+		if certificate := req.getCertificate(env.GatewayCertManagerIssuer()); certificate != nil {
+			ingress.Spec.TLS = []networkingv1.IngressTLS{{
+				Hosts:      []string{req.GetEffectiveGatewayHost()},
+				SecretName: req.GetCertificateName(),
+			}}
+			result = append(result, certificate)
+		}
+		result = append(result, ingress)
+	}
+
+	var hasHostedServers bool
+	for _, project := range req.Parent.Spec.Projects {
+		if isHosted(project) {
+			hasHostedServers = true
+			result = append(result, req.getServerChildren(project)...)
+		}
+	}
+	if hasHostedServers {
+		result = append(result, req.getServerNetworkPolicy(gatewayLabels))
+	}
+
+	return result, nil
+}
+
+// getServerChildren returns the Deployment and Service of a hosted MCP server and a Secret with its environment
+// variables. The deployment revision is added to the pod template, so that every new revision causes a rollout and
+// the rollout can be attributed to the revision. Environment variables can only change with a new revision.
+func (req *request) getServerChildren(project v1alpha1.ProjectSpec) []client.Object {
+	serverName := req.GetServerName(project)
+	serverLabels := map[string]string{
+		"app":                "mcp-server",
+		"jetskiOrganization": req.Parent.Spec.OrganizationID,
+		"jetskiProject":      project.ProjectID,
+	}
+
+	var result []client.Object
+	var envFrom []corev1.EnvFromSource
+	if variables := req.environment[project.ProjectID]; len(variables) > 0 {
+		secretName := fmt.Sprintf("%v-env", serverName)
+		secret := &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: req.Parent.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       make(map[string][]byte, len(variables)),
+		}
+		for _, variable := range variables {
+			if variable.Value != nil {
+				secret.Data[variable.Name] = []byte(*variable.Value)
+			}
+		}
+		result = append(result, secret)
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}},
+		})
+	}
+
+	return append(
+		result,
 		&appsv1.Deployment{
 			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
-			ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: req.Parent.Namespace},
+			ObjectMeta: metav1.ObjectMeta{Name: serverName, Namespace: req.Parent.Namespace},
 			Spec: appsv1.DeploymentSpec{
-				Selector: &metav1.LabelSelector{MatchLabels: gatewayLabels},
+				Selector: &metav1.LabelSelector{MatchLabels: serverLabels},
 				Template: corev1.PodTemplateSpec{
-					ObjectMeta: metav1.ObjectMeta{Labels: gatewayLabels, Annotations: gatewayAnnotations},
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      serverLabels,
+						Annotations: map[string]string{deploymentRevisionAnnotation: project.DeploymentRevisionID},
+					},
 					Spec: corev1.PodSpec{
-						ImagePullSecrets: []corev1.LocalObjectReference{{Name: "image-pull-secret"}},
+						// the server runs arbitrary code of the organization, so it gets no access to the Kubernetes API
+						AutomountServiceAccountToken: util.PtrTo(false),
+						SecurityContext: &corev1.PodSecurityContext{
+							RunAsNonRoot:   util.PtrTo(true),
+							SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+						},
 						Containers: []corev1.Container{{
-							Name:            "gateway",
-							Image:           env.GatewayContainerImageTag(),
+							Name:            "server",
+							Image:           strings.TrimPrefix(*project.OCIURL, "oci://"),
 							ImagePullPolicy: corev1.PullIfNotPresent,
-							Args:            []string{"--config", "/opt/config.yaml"},
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: util.PtrTo(false),
+								ReadOnlyRootFilesystem:   util.PtrTo(true),
+								Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+							},
 							Resources: corev1.ResourceRequirements{
 								Limits: corev1.ResourceList{
-									corev1.ResourceMemory: resource.MustParse("128Mi"),
+									corev1.ResourceMemory: resource.MustParse("256Mi"),
 									corev1.ResourceCPU:    resource.MustParse("500m"),
 								},
 								Requests: corev1.ResourceList{
-									corev1.ResourceMemory: resource.MustParse("16Mi"),
+									corev1.ResourceMemory: resource.MustParse("32Mi"),
 									corev1.ResourceCPU:    resource.MustParse("5m"),
 								},
 							},
-							Ports:        []corev1.ContainerPort{{Name: "http", ContainerPort: 9000}},
-							VolumeMounts: []corev1.VolumeMount{{Name: "config", SubPath: "config.yaml", MountPath: "/opt/config.yaml"}},
+							Ports:        []corev1.ContainerPort{{Name: "http", ContainerPort: *project.Port}},
+							EnvFrom:      envFrom,
+							VolumeMounts: []corev1.VolumeMount{{Name: "tmp", MountPath: "/tmp"}},
 						}},
+						// the root filesystem is read-only, so servers can only write temporary files to /tmp
 						Volumes: []corev1.Volume{{
-							Name: "config",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{Name: configName},
-								},
-							},
+							Name:         "tmp",
+							VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 						}},
 					},
 				},
@@ -96,14 +347,47 @@ func (req *request) GetDesiredChildren() ([]client.Object, error) {
 		},
 		&corev1.Service{
 			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
-			ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: req.Parent.Namespace},
+			ObjectMeta: metav1.ObjectMeta{Name: serverName, Namespace: req.Parent.Namespace},
 			Spec: corev1.ServiceSpec{
-				Selector: gatewayLabels,
-				Ports:    []corev1.ServicePort{{Name: "http", Port: 9000}},
+				Selector: serverLabels,
+				Ports:    []corev1.ServicePort{{Name: "http", Port: *project.Port}},
 			},
 		},
+	)
+}
+
+// getServerNetworkPolicy returns a NetworkPolicy for all hosted MCP servers of the organization. Servers only accept
+// connections from the gateway of their organization and can only connect to DNS and public addresses, so that they
+// can neither reach servers of other organizations nor internal endpoints like the webhooks of the platform.
+func (req *request) getServerNetworkPolicy(gatewayLabels map[string]string) *networkingv1.NetworkPolicy {
+	dnsPorts := []networkingv1.NetworkPolicyPort{
+		{Protocol: util.PtrTo(corev1.ProtocolUDP), Port: util.PtrTo(intstr.FromInt32(53))},
+		{Protocol: util.PtrTo(corev1.ProtocolTCP), Port: util.PtrTo(intstr.FromInt32(53))},
 	}
+	return &networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%v-servers", req.Parent.Name), Namespace: req.Parent.Namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{
+				"app":                "mcp-server",
+				"jetskiOrganization": req.Parent.Spec.OrganizationID,
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: gatewayLabels}}},
+			}},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{Ports: dnsPorts},
+				{To: []networkingv1.NetworkPolicyPeer{
+					{IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0", Except: privateIPv4Ranges}},
+					{IPBlock: &networkingv1.IPBlock{CIDR: "::/0", Except: privateIPv6Ranges}},
+				}},
+			},
+		},
+	}
+}
 
+func (req *request) getIngress() *networkingv1.Ingress {
 	ingress := &networkingv1.Ingress{
 		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
 		ObjectMeta: metav1.ObjectMeta{
@@ -121,7 +405,7 @@ func (req *request) GetDesiredChildren() ([]client.Object, error) {
 							PathType: util.PtrTo(networkingv1.PathTypePrefix),
 							Backend: networkingv1.IngressBackend{
 								Service: &networkingv1.IngressServiceBackend{
-									Name: gatewayName,
+									Name: req.GetGatewayName(),
 									Port: networkingv1.ServiceBackendPort{Number: 9000},
 								},
 							},
@@ -136,9 +420,161 @@ func (req *request) GetDesiredChildren() ([]client.Object, error) {
 		ingress.Spec.IngressClassName = &ingressClass
 	}
 
-	result = append(result, ingress)
+	return ingress
+}
 
-	return result, nil
+// getHTTPRoute returns a Gateway API HTTPRoute with the same rules as the Ingress returned by getIngress. The Gateway
+// API types are not part of the Kubernetes API, so the route is built as an unstructured object.
+func (req *request) getHTTPRoute() *unstructured.Unstructured {
+	parent := env.GatewayHTTPRouteParent()
+	parentRef := map[string]any{"name": parent.Name}
+	if parent.Namespace != "" {
+		parentRef["namespace"] = parent.Namespace
+	}
+	if parent.SectionName != "" {
+		parentRef["sectionName"] = parent.SectionName
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata": map[string]any{
+				"name":      req.Parent.Name,
+				"namespace": req.Parent.Namespace,
+			},
+			"spec": map[string]any{
+				"parentRefs": []any{parentRef},
+				"hostnames":  []any{req.GetEffectiveGatewayHost()},
+				"rules": []any{
+					map[string]any{
+						"matches": []any{
+							map[string]any{"path": map[string]any{"type": "PathPrefix", "value": "/"}},
+						},
+						"backendRefs": []any{
+							map[string]any{"name": req.GetGatewayName(), "port": int64(9000)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getCertificate returns a cert-manager Certificate for the custom domain of the gateway. It returns nil if the
+// gateway has no custom domain or no issuer is configured. Like HTTPRoutes, certificates are built as unstructured
+// objects.
+func (req *request) getCertificate(issuer *env.GatewayCertManagerIssuerConfig) *unstructured.Unstructured {
+	if issuer == nil || req.Parent.Spec.CustomDomain == nil {
+		return nil
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]any{
+				"name":      req.GetCertificateName(),
+				"namespace": req.Parent.Namespace,
+			},
+			"spec": map[string]any{
+				"secretName": req.GetCertificateName(),
+				"dnsNames":   []any{req.GetEffectiveGatewayHost()},
+				"issuerRef": map[string]any{
+					"group": "cert-manager.io",
+					"kind":  issuer.Kind,
+					"name":  issuer.Name,
+				},
+			},
+		},
+	}
+}
+
+// getGatewayResources returns the resources of the gateway container. Gateways that were applied before the resources
+// were part of the spec use the platform defaults.
+func (req *request) getGatewayResources() corev1.ResourceRequirements {
+	if resources := req.Parent.Spec.Resources; len(resources.Limits) > 0 || len(resources.Requests) > 0 {
+		return resources
+	}
+	defaults := env.GatewayScaling()
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: defaults.MemoryLimit,
+			corev1.ResourceCPU:    defaults.CPULimit,
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: defaults.MemoryRequest,
+			corev1.ResourceCPU:    defaults.CPURequest,
+		},
+	}
+}
+
+func (req *request) GetGatewayName() string {
+	return fmt.Sprintf("%v-gateway", req.Parent.Name)
+}
+
+// GetCertificateName is the name of the Certificate and of the Secret that contains the TLS certificate
+func (req *request) GetCertificateName() string {
+	return fmt.Sprintf("%v-tls", req.Parent.Name)
+}
+
+// GetServerName is the name of the Deployment and Service of a hosted MCP server. Service names must be DNS labels,
+// so names that are too long are shortened and made unique with a hash of the full name.
+func (req *request) GetServerName(project v1alpha1.ProjectSpec) string {
+	name := fmt.Sprintf("%v-server-%v", req.Parent.Name, project.ProjectName)
+	if len(name) <= maxDNSLabelLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(hash[:])[:8]
+	return strings.TrimRight(name[:maxDNSLabelLength-len(suffix)-1], "-") + "-" + suffix
+}
+
+// getServerURL returns the in-cluster URL of the MCP endpoint of a hosted MCP server
+func (req *request) getServerURL(project v1alpha1.ProjectSpec) *gatewayconfig.URL {
+	return &gatewayconfig.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("%v.%v.svc:%v", req.GetServerName(project), req.Parent.Namespace, *project.Port),
+		Path:   env.HostedServerPath(),
+	}
+}
+
+func getProxyHttpAuth(project v1alpha1.ProjectSpec) (*gatewayconfig.ProxyHttpAuth, error) {
+	auth := &gatewayconfig.ProxyHttpAuth{
+		Type:       gatewayconfig.ProxyHttpAuthType(project.UpstreamAuth.Type),
+		Header:     project.UpstreamAuth.Header,
+		SecretFile: path.Join(upstreamAuthMountPath, project.ProjectID),
+	}
+	if auth.Type == gatewayconfig.ProxyHttpAuthTypeOAuth2ClientCredentials {
+		if tokenURL, err := url.Parse(project.UpstreamAuth.TokenURL); err != nil {
+			return nil, err
+		} else {
+			auth.OAuth2 = &gatewayconfig.ProxyHttpOAuth2{
+				TokenUrl: gatewayconfig.URL(*tokenURL),
+				ClientID: project.UpstreamAuth.ClientID,
+				Scopes:   project.UpstreamAuth.Scopes,
+			}
+		}
+	}
+	return auth, nil
+}
+
+// isHosted returns true if the MCP server of the project is deployed as a child of the gateway
+func isHosted(project v1alpha1.ProjectSpec) bool {
+	return project.OCIURL != nil && project.Port != nil
+}
+
+// getGatewayConfigYAML returns the rendered gateway config and its hash. The hash is added to the pod template of the
+// gateway, so that config changes cause a rollout.
+func (req *request) getGatewayConfigYAML() (string, string, error) {
+	if gatewayConfig, err := req.GetGatewayConfig(); err != nil {
+		return "", "", err
+	} else if gatewayConfigStr, err := gatewayConfig.YAMLString(); err != nil {
+		return "", "", err
+	} else {
+		gatewayConfigHash := sha256.Sum256([]byte(gatewayConfigStr))
+		return gatewayConfigStr, hex.EncodeToString(gatewayConfigHash[:]), nil
+	}
 }
 
 func (req *request) GetGatewayConfig() (*gatewayconfig.Config, error) {
@@ -167,7 +603,8 @@ func (req *request) GetGatewayConfig() (*gatewayconfig.Config, error) {
 				Enabled: project.Authenticated,
 			},
 			Telemetry: gatewayconfig.ProxyTelemetry{
-				Enabled: project.Telemetry,
+				Enabled:            project.Telemetry,
+				PromptArgumentName: project.PromptArgumentName,
 			},
 			Webhook: &gatewayconfig.Webhook{
 				Method: http.MethodPost,
@@ -179,7 +616,38 @@ func (req *request) GetGatewayConfig() (*gatewayconfig.Config, error) {
 			},
 		}
 
-		if project.ProxyURL != nil {
+		// context values are set per user, so they can only be resolved for authenticated requests
+		if project.Authenticated && env.GatewayContextEnabled() {
+			proxy.Context = &gatewayconfig.ProxyContext{
+				Webhook: gatewayconfig.Webhook{
+					Method: http.MethodPost,
+					Url: gatewayconfig.URL{
+						Scheme: env.HostScheme(),
+						Host:   env.Host(),
+						Path:   fmt.Sprintf("/webhook/context/%v", project.ProjectID),
+						RawQuery: url.Values{
+							webhooktoken.QueryParam: {webhooktoken.New(webhooktoken.PurposeContext, project.ProjectID)},
+						}.Encode(),
+					},
+				},
+			}
+		}
+
+		if env.GatewayToolPoliciesEnabled() {
+			for _, policy := range project.ToolPolicies {
+				proxy.ToolPolicies = append(proxy.ToolPolicies, gatewayconfig.ToolPolicy{
+					Effect:  gatewayconfig.ToolPolicyEffect(policy.Effect),
+					Tools:   policy.Tools,
+					Emails:  policy.Emails,
+					Domains: policy.Domains,
+					Claims:  policy.Claims,
+				})
+			}
+		}
+
+		if isHosted(project) {
+			proxy.Http = &gatewayconfig.ProxyHttp{Url: req.getServerURL(project)}
+		} else if project.ProxyURL != nil {
 			if proxyURL, err := url.Parse(*project.ProxyURL); err != nil {
 				return nil, err
 			} else {
@@ -187,6 +655,14 @@ func (req *request) GetGatewayConfig() (*gatewayconfig.Config, error) {
 					Url: (*gatewayconfig.URL)(proxyURL),
 				}
 			}
+
+			if _, ok := req.upstreamAuthSecrets[project.ProjectID]; ok {
+				if auth, err := getProxyHttpAuth(project); err != nil {
+					return nil, err
+				} else {
+					proxy.Http.Auth = auth
+				}
+			}
 		}
 
 		cfg.Proxy = append(cfg.Proxy, proxy)
@@ -204,10 +680,6 @@ func (req *request) GetEffectiveGatewayHost() string {
 
 }
 
-func (req *request) GetStatus() *v1alpha1.MCPGatewayStatus {
-	return nil
-}
-
 type response struct {
 	Status   *v1alpha1.MCPGatewayStatus `json:"status,omitempty"`
 	Children []client.Object            `json:"children,omitempty"`
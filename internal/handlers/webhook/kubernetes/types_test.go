@@ -0,0 +1,334 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestHostedServer(t *testing.T) {
+	req := request{
+		Parent: v1alpha1.MCPGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "org", Namespace: "jetski"},
+			Spec: v1alpha1.MCPGatewaySpec{
+				OrganizationName: "org",
+				Projects: []v1alpha1.ProjectSpec{
+					{
+						ProjectID:            "hosted-id",
+						ProjectName:          "hosted",
+						DeploymentRevisionID: "revision",
+						OCIURL:               util.PtrTo("oci://ghcr.io/example/server:1.0.0"),
+						Port:                 util.PtrTo(int32(8080)),
+					},
+					{
+						ProjectID:            "proxied-id",
+						ProjectName:          "proxied",
+						DeploymentRevisionID: "revision",
+						ProxyURL:             util.PtrTo("https://example.com/mcp"),
+					},
+				},
+			},
+		},
+		environment: map[string][]types.EnvironmentVariable{
+			"hosted-id": {{Name: "API_KEY", Secret: true, Value: util.PtrTo("secret")}},
+		},
+	}
+
+	children, err := req.GetDesiredChildren()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var deployment *appsv1.Deployment
+	var service *corev1.Service
+	var secret *corev1.Secret
+	var networkPolicy *networkingv1.NetworkPolicy
+	for _, child := range children {
+		switch obj := child.(type) {
+		case *networkingv1.NetworkPolicy:
+			networkPolicy = obj
+		case *appsv1.Deployment:
+			if obj.Name == "org-server-hosted" {
+				deployment = obj
+			}
+		case *corev1.Service:
+			if obj.Name == "org-server-hosted" {
+				service = obj
+			}
+		case *corev1.Secret:
+			secret = obj
+		}
+	}
+
+	if deployment == nil || service == nil || secret == nil {
+		t.Fatalf("expected deployment, service and secret for hosted project")
+	}
+	if value := string(secret.Data["API_KEY"]); value != "secret" {
+		t.Errorf("unexpected secret value %v", value)
+	}
+	if envFrom := deployment.Spec.Template.Spec.Containers[0].EnvFrom; len(envFrom) != 1 ||
+		envFrom[0].SecretRef == nil || envFrom[0].SecretRef.Name != secret.Name {
+		t.Errorf("expected the server container to reference secret %v", secret.Name)
+	}
+	if image := deployment.Spec.Template.Spec.Containers[0].Image; image != "ghcr.io/example/server:1.0.0" {
+		t.Errorf("unexpected image %v", image)
+	}
+	if rev := deployment.Spec.Template.Annotations[deploymentRevisionAnnotation]; rev != "revision" {
+		t.Errorf("unexpected deployment revision annotation %v", rev)
+	}
+	if port := service.Spec.Ports[0].Port; port != 8080 {
+		t.Errorf("unexpected service port %v", port)
+	}
+	if podSpec := deployment.Spec.Template.Spec; podSpec.AutomountServiceAccountToken == nil ||
+		*podSpec.AutomountServiceAccountToken {
+		t.Errorf("expected the service account token not to be mounted")
+	}
+	if sc := deployment.Spec.Template.Spec.Containers[0].SecurityContext; sc == nil ||
+		sc.ReadOnlyRootFilesystem == nil || !*sc.ReadOnlyRootFilesystem ||
+		sc.Capabilities == nil || len(sc.Capabilities.Drop) != 1 || sc.Capabilities.Drop[0] != "ALL" {
+		t.Errorf("unexpected security context %v", sc)
+	}
+	if networkPolicy == nil {
+		t.Fatalf("expected network policy for hosted servers")
+	}
+	if from := networkPolicy.Spec.Ingress[0].From; len(from) != 1 || from[0].PodSelector == nil ||
+		from[0].PodSelector.MatchLabels["app"] != "mcp-gateway" {
+		t.Errorf("expected ingress to be limited to the gateway but found %v", from)
+	}
+	if len(children) != 8 {
+		t.Errorf("expected 8 children but found %v", len(children))
+	}
+
+	cfg, err := req.GetGatewayConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url := cfg.Proxy[0].Http.Url.String(); url != "http://org-server-hosted.jetski.svc:8080/mcp" {
+		t.Errorf("unexpected proxy url %v for hosted project", url)
+	}
+	if url := cfg.Proxy[1].Http.Url.String(); url != "https://example.com/mcp" {
+		t.Errorf("unexpected proxy url %v for proxied project", url)
+	}
+}
+
+func TestServerNameLength(t *testing.T) {
+	req := request{Parent: v1alpha1.MCPGateway{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("o", 40)}}}
+	short := req.GetServerName(v1alpha1.ProjectSpec{ProjectName: "p"})
+	if short != strings.Repeat("o", 40)+"-server-p" {
+		t.Errorf("unexpected name %v", short)
+	}
+
+	long1 := req.GetServerName(v1alpha1.ProjectSpec{ProjectName: strings.Repeat("a", 30) + "-1"})
+	long2 := req.GetServerName(v1alpha1.ProjectSpec{ProjectName: strings.Repeat("a", 30) + "-2"})
+	if len(long1) > 63 || len(long2) > 63 {
+		t.Errorf("expected names to be at most 63 characters but found %v and %v", len(long1), len(long2))
+	}
+	if long1 == long2 {
+		t.Errorf("expected shortened names to be unique")
+	}
+}
+
+func TestUpstreamAuth(t *testing.T) {
+	req := request{
+		Parent: v1alpha1.MCPGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "org", Namespace: "jetski"},
+			Spec: v1alpha1.MCPGatewaySpec{
+				OrganizationName: "org",
+				Projects: []v1alpha1.ProjectSpec{{
+					ProjectID:            "proxied-id",
+					ProjectName:          "proxied",
+					DeploymentRevisionID: "revision",
+					ProxyURL:             util.PtrTo("https://example.com/mcp"),
+					UpstreamAuth: &v1alpha1.UpstreamAuthSpec{
+						Type:     "oauth2ClientCredentials",
+						TokenURL: "https://auth.example.com/token",
+						ClientID: "gateway",
+						Scopes:   []string{"mcp"},
+					},
+				}},
+			},
+		},
+		upstreamAuthSecrets: map[string]string{"proxied-id": "client-secret"},
+	}
+
+	cfg, err := req.GetGatewayConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := cfg.Proxy[0].Http.Auth
+	if auth == nil {
+		t.Fatal("expected upstream auth in gateway config")
+	} else if err := auth.Validate(); err != nil {
+		t.Errorf("expected valid upstream auth but found error: %v", err)
+	} else if auth.SecretFile != "/opt/upstream-auth/proxied-id" {
+		t.Errorf("unexpected secret file %v", auth.SecretFile)
+	}
+
+	if str, _, err := req.getGatewayConfigYAML(); err != nil {
+		t.Fatal(err)
+	} else if strings.Contains(str, "client-secret") {
+		t.Error("expected gateway config not to contain the secret")
+	}
+
+	children, err := req.GetDesiredChildren()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var secret *corev1.Secret
+	var deployment *appsv1.Deployment
+	for _, child := range children {
+		switch obj := child.(type) {
+		case *corev1.Secret:
+			secret = obj
+		case *appsv1.Deployment:
+			deployment = obj
+		}
+	}
+	if secret == nil || string(secret.Data["proxied-id"]) != "client-secret" {
+		t.Fatal("expected secret with the upstream auth secret of the project")
+	}
+	if volumes := deployment.Spec.Template.Spec.Volumes; len(volumes) != 2 ||
+		volumes[1].Secret == nil || volumes[1].Secret.SecretName != secret.Name {
+		t.Errorf("expected the gateway to mount secret %v", secret.Name)
+	}
+
+	req.upstreamAuthSecrets = nil
+	if cfg, err := req.GetGatewayConfig(); err != nil {
+		t.Fatal(err)
+	} else if cfg.Proxy[0].Http.Auth != nil {
+		t.Error("expected no upstream auth in gateway config without secret")
+	}
+}
+
+func TestPromptArgumentName(t *testing.T) {
+	req := request{
+		Parent: v1alpha1.MCPGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "org", Namespace: "jetski"},
+			Spec: v1alpha1.MCPGatewaySpec{
+				OrganizationName: "org",
+				Projects: []v1alpha1.ProjectSpec{
+					{
+						ProjectID:            "custom-id",
+						ProjectName:          "custom",
+						DeploymentRevisionID: "revision",
+						ProxyURL:             util.PtrTo("https://example.com/mcp"),
+						Telemetry:            true,
+						PromptArgumentName:   "userPrompt",
+					},
+					{
+						ProjectID:            "default-id",
+						ProjectName:          "default",
+						DeploymentRevisionID: "revision",
+						ProxyURL:             util.PtrTo("https://example.com/mcp"),
+						Telemetry:            true,
+					},
+				},
+			},
+		},
+	}
+
+	if cfg, err := req.GetGatewayConfig(); err != nil {
+		t.Fatal(err)
+	} else if name := cfg.Proxy[0].Telemetry.PromptArgumentName; name != "userPrompt" {
+		t.Errorf("unexpected prompt argument name %v", name)
+	}
+
+	if str, _, err := req.getGatewayConfigYAML(); err != nil {
+		t.Fatal(err)
+	} else if strings.Count(str, "promptArgumentName") != 1 {
+		t.Error("expected the prompt argument name to be omitted for projects with the default name")
+	}
+}
+
+func TestHTTPRoute(t *testing.T) {
+	req := request{
+		Parent: v1alpha1.MCPGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "org", Namespace: "jetski"},
+			Spec: v1alpha1.MCPGatewaySpec{
+				OrganizationName: "org",
+				CustomDomain:     util.PtrTo("mcp.example.com"),
+			},
+		},
+	}
+
+	route := req.getHTTPRoute().DeepCopy()
+	if hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); len(hostnames) != 1 ||
+		hostnames[0] != req.GetEffectiveGatewayHost() {
+		t.Errorf("unexpected hostnames %v", hostnames)
+	}
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule but found %v", len(rules))
+	}
+	backendRefs, _, _ := unstructured.NestedSlice(rules[0].(map[string]any), "backendRefs")
+	if len(backendRefs) != 1 || backendRefs[0].(map[string]any)["name"] != req.GetGatewayName() {
+		t.Errorf("unexpected backend refs %v", backendRefs)
+	}
+
+	if condition := getHTTPRouteCondition(nil); condition.Status != metav1.ConditionUnknown {
+		t.Errorf("expected unknown condition without route but found %v", condition.Status)
+	}
+	var observed httpRoute
+	observed.Status.Parents = []httpRouteParentStatus{{
+		Conditions: []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionFalse, Reason: "NotAllowedByListeners"}},
+	}}
+	if condition := getHTTPRouteCondition(&observed); condition.Status != metav1.ConditionFalse ||
+		condition.Reason != "NotAllowedByListeners" {
+		t.Errorf("expected condition with reason of the parent but found %v", condition.Reason)
+	}
+	observed.Status.Parents[0].Conditions[0].Status = metav1.ConditionTrue
+	if condition := getHTTPRouteCondition(&observed); condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected true condition for accepted route but found %v", condition.Status)
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	req := request{
+		Parent: v1alpha1.MCPGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "org", Namespace: "jetski"},
+			Spec:       v1alpha1.MCPGatewaySpec{OrganizationName: "org"},
+		},
+	}
+	issuer := &env.GatewayCertManagerIssuerConfig{Name: "letsencrypt", Kind: "ClusterIssuer"}
+
+	if certificate := req.getCertificate(issuer); certificate != nil {
+		t.Error("expected no certificate without custom domain")
+	}
+	req.Parent.Spec.CustomDomain = util.PtrTo("mcp.example.com")
+	if certificate := req.getCertificate(nil); certificate != nil {
+		t.Error("expected no certificate without issuer")
+	}
+
+	certificate := req.getCertificate(issuer).DeepCopy()
+	if dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames"); len(dnsNames) != 1 ||
+		dnsNames[0] != "mcp.example.com" {
+		t.Errorf("unexpected dns names %v", dnsNames)
+	}
+	if name, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name"); name != "letsencrypt" {
+		t.Errorf("unexpected issuer %v", name)
+	}
+	if secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName"); secretName != "org-tls" {
+		t.Errorf("unexpected secret name %v", secretName)
+	}
+
+	if condition := getCertificateCondition(nil); condition.Status != metav1.ConditionUnknown {
+		t.Errorf("expected unknown condition without certificate but found %v", condition.Status)
+	}
+	var observed certManagerCertificate
+	if condition := getCertificateCondition(&observed); condition.Status != metav1.ConditionFalse {
+		t.Errorf("expected false condition for pending certificate but found %v", condition.Status)
+	}
+	observed.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"}}
+	if condition := getCertificateCondition(&observed); condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected true condition for issued certificate but found %v", condition.Status)
+	}
+}
@@ -0,0 +1,61 @@
+package invitations
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+var ErrInvalidToken = errors.New("invalid invitation token")
+
+// NewToken returns a token for the invitation that is signed with the invitation signing key. The signature covers
+// the expiry, so renewing an invitation invalidates all tokens that have been sent before.
+func NewToken(invitation types.Invitation) string {
+	return newToken(env.InvitationSigningKey(), invitation)
+}
+
+// ParseToken returns the ID of the invitation the token has been created for. The token must be verified with
+// VerifyToken once the invitation has been loaded.
+func ParseToken(token string) (uuid.UUID, error) {
+	if id, _, ok := strings.Cut(token, "."); !ok {
+		return uuid.Nil, ErrInvalidToken
+	} else if id, err := uuid.Parse(id); err != nil {
+		return uuid.Nil, ErrInvalidToken
+	} else {
+		return id, nil
+	}
+}
+
+// VerifyToken returns true if the token has been created for the current state of the invitation
+func VerifyToken(token string, invitation types.Invitation) bool {
+	return verifyToken(env.InvitationSigningKey(), token, invitation)
+}
+
+func newToken(key []byte, invitation types.Invitation) string {
+	return invitation.ID.String() + "." + base64.RawURLEncoding.EncodeToString(sign(key, invitation))
+}
+
+func verifyToken(key []byte, token string, invitation types.Invitation) bool {
+	if _, signature, ok := strings.Cut(token, "."); !ok {
+		return false
+	} else if signature, err := base64.RawURLEncoding.DecodeString(signature); err != nil {
+		return false
+	} else {
+		return hmac.Equal(signature, sign(key, invitation))
+	}
+}
+
+func sign(key []byte, invitation types.Invitation) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(invitation.ID.String()))
+	mac.Write([]byte("."))
+	mac.Write([]byte(strconv.FormatInt(invitation.ExpiresAt.Unix(), 10)))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,43 @@
+package invitations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestToken(t *testing.T) {
+	key := []byte("test-key")
+	invitation := types.Invitation{ID: uuid.New(), ExpiresAt: time.Unix(1700000000, 0)}
+	token := newToken(key, invitation)
+
+	if id, err := ParseToken(token); err != nil || id != invitation.ID {
+		t.Errorf("expected invitation ID %v, got %v (%v)", invitation.ID, id, err)
+	}
+	if !verifyToken(key, token, invitation) {
+		t.Error("expected token to be valid")
+	}
+	if verifyToken([]byte("other-key"), token, invitation) {
+		t.Error("expected token to be invalid with another key")
+	}
+
+	renewed := invitation
+	renewed.ExpiresAt = renewed.ExpiresAt.Add(time.Hour)
+	if verifyToken(key, token, renewed) {
+		t.Error("expected token to be invalid after the invitation has been renewed")
+	}
+
+	other := invitation
+	other.ID = uuid.New()
+	if verifyToken(key, token, other) {
+		t.Error("expected token to be invalid for another invitation")
+	}
+
+	for _, token := range []string{"", "invalid", "invalid.signature"} {
+		if _, err := ParseToken(token); err == nil {
+			t.Errorf("expected error for token %q", token)
+		}
+	}
+}
@@ -1,17 +1,50 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ProjectSpec struct {
 	// TODO: Use uuid.UUID instead but controller-gen does not like it when generating deep-copy functions
-	ProjectID            string  `json:"projectId"`
-	ProjectName          string  `json:"projectName"`
-	DeploymentRevisionID string  `json:"deploymentRevisionId"`
-	Authenticated        bool    `json:"authenticationEnabled"`
-	Telemetry            bool    `json:"telemetryEnabled"`
-	ProxyURL             *string `json:"proxyUrl,omitempty"`
+	ProjectID            string `json:"projectId"`
+	ProjectName          string `json:"projectName"`
+	DeploymentRevisionID string `json:"deploymentRevisionId"`
+	Authenticated        bool   `json:"authenticationEnabled"`
+	Telemetry            bool   `json:"telemetryEnabled"`
+	// PromptArgumentName is the name of the tool argument that is used to capture user prompts
+	PromptArgumentName string  `json:"promptArgumentName,omitempty"`
+	ProxyURL           *string `json:"proxyUrl,omitempty"`
+	// OCIURL is the image of the MCP server if it is hosted by jetski. Requests are then proxied to the server
+	// instead of ProxyURL.
+	OCIURL *string `json:"ociUrl,omitempty"`
+	// Port is the port the hosted MCP server listens on
+	Port *int32 `json:"port,omitempty"`
+	// ToolPolicies are evaluated in order, the first policy that matches the user and the tool decides
+	ToolPolicies []ToolPolicySpec `json:"toolPolicies,omitempty"`
+	// UpstreamAuth configures how the gateway authenticates to the upstream server at ProxyURL. The secret is loaded
+	// by the sync hook and is not part of the spec.
+	UpstreamAuth *UpstreamAuthSpec `json:"upstreamAuth,omitempty"`
+}
+
+type UpstreamAuthSpec struct {
+	// +kubebuilder:validation:Enum=header;bearer;oauth2ClientCredentials
+	Type     string   `json:"type"`
+	Header   string   `json:"header,omitempty"`
+	TokenURL string   `json:"tokenUrl,omitempty"`
+	ClientID string   `json:"clientId,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+	// UpdatedAt changes whenever the secret changes, so that the gateway is synced
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+type ToolPolicySpec struct {
+	// +kubebuilder:validation:Enum=allow;deny
+	Effect  string            `json:"effect"`
+	Tools   []string          `json:"tools"`
+	Emails  []string          `json:"emails,omitempty"`
+	Domains []string          `json:"domains,omitempty"`
+	Claims  map[string]string `json:"claims,omitempty"`
 }
 
 type DynamicClientRegistrationSpec struct {
@@ -22,6 +55,16 @@ type AuthorizationSpec struct {
 	DynamicClientRegistration DynamicClientRegistrationSpec `json:"dynamicClientRegistration,omitempty,omitzero"`
 }
 
+type AutoscalingSpec struct {
+	MinReplicas                    int32 `json:"minReplicas"`
+	MaxReplicas                    int32 `json:"maxReplicas"`
+	TargetCPUUtilizationPercentage int32 `json:"targetCPUUtilizationPercentage"`
+}
+
+type PodDisruptionBudgetSpec struct {
+	MinAvailable int32 `json:"minAvailable"`
+}
+
 // MCPGatewaySpec defines the desired state of MCPGateway
 type MCPGatewaySpec struct {
 	OrganizationID   string            `json:"organizationId"`
@@ -29,10 +72,53 @@ type MCPGatewaySpec struct {
 	CustomDomain     *string           `json:"customDomain,omitempty"`
 	Authorization    AuthorizationSpec `json:"authorization,omitempty,omitzero"`
 	Projects         []ProjectSpec     `json:"projects,omitempty"`
+	// Replicas is the number of gateway replicas. It is ignored if Autoscaling is set.
+	Replicas  *int32                      `json:"replicas,omitempty"`
+	Resources corev1.ResourceRequirements `json:"resources,omitempty,omitzero"`
+	// Autoscaling adds a HorizontalPodAutoscaler for the gateway deployment
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+	// PodDisruptionBudget adds a PodDisruptionBudget for the gateway pods
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+const (
+	// ConditionTypeReady is true if the gateway runs the configuration of the current spec and is reachable
+	ConditionTypeReady = "Ready"
+	// ConditionTypeDeploymentAvailable is true if all replicas of the gateway deployment are updated and available
+	ConditionTypeDeploymentAvailable = "DeploymentAvailable"
+	// ConditionTypeIngressReady is true if the ingress of the gateway has been assigned an address
+	ConditionTypeIngressReady = "IngressReady"
+	// ConditionTypeServersAvailable is true if the deployments of all hosted MCP servers are updated and available
+	ConditionTypeServersAvailable = "ServersAvailable"
+	// ConditionTypeCertificateReady is true if cert-manager has issued the certificate for the custom domain. It is
+	// only set if certificates are managed by cert-manager.
+	ConditionTypeCertificateReady = "CertificateReady"
+)
+
+// ServerStatus is the observed state of the deployment of a hosted MCP server
+type ServerStatus struct {
+	ProjectID            string `json:"projectId"`
+	DeploymentRevisionID string `json:"deploymentRevisionId"`
+	AvailableReplicas    int32  `json:"availableReplicas,omitempty"`
+	Available            bool   `json:"available"`
+	Reason               string `json:"reason,omitempty"`
+	Message              string `json:"message,omitempty"`
 }
 
 // MCPGatewayStatus defines the observed state of MCPGateway
-type MCPGatewayStatus struct{}
+type MCPGatewayStatus struct {
+	// ObservedGeneration is the generation of the MCPGateway the status has been computed for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	Replicas           int32 `json:"replicas,omitempty"`
+	UpdatedReplicas    int32 `json:"updatedReplicas,omitempty"`
+	ReadyReplicas      int32 `json:"readyReplicas,omitempty"`
+	AvailableReplicas  int32 `json:"availableReplicas,omitempty"`
+	// Address is the IP address or hostname of the ingress load balancer
+	Address string `json:"address,omitempty"`
+	// Servers contains the status of the hosted MCP servers of the gateway
+	Servers    []ServerStatus     `json:"servers,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
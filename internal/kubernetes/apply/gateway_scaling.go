@@ -0,0 +1,107 @@
+package apply
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GatewayScaling resolves the gateway settings of an organization against the platform defaults and returns an error
+// if the resulting configuration is invalid
+func GatewayScaling(settings types.OrganizationGatewaySettings) (*env.GatewayScalingConfig, error) {
+	config := env.GatewayScaling()
+
+	if settings.Replicas != nil {
+		config.Replicas = *settings.Replicas
+	}
+	if err := parseQuantityOverride(&config.CPURequest, settings.CPURequest, "cpu request"); err != nil {
+		return nil, err
+	}
+	if err := parseQuantityOverride(&config.CPULimit, settings.CPULimit, "cpu limit"); err != nil {
+		return nil, err
+	}
+	if err := parseQuantityOverride(&config.MemoryRequest, settings.MemoryRequest, "memory request"); err != nil {
+		return nil, err
+	}
+	if err := parseQuantityOverride(&config.MemoryLimit, settings.MemoryLimit, "memory limit"); err != nil {
+		return nil, err
+	}
+	if settings.Autoscaling.Enabled != nil {
+		config.AutoscalingEnabled = *settings.Autoscaling.Enabled
+	}
+	if settings.Autoscaling.MinReplicas != nil {
+		config.AutoscalingMinReplicas = *settings.Autoscaling.MinReplicas
+	}
+	if settings.Autoscaling.MaxReplicas != nil {
+		config.AutoscalingMaxReplicas = *settings.Autoscaling.MaxReplicas
+	}
+	if settings.Autoscaling.TargetCPUUtilization != nil {
+		config.AutoscalingTargetCPUUtilization = *settings.Autoscaling.TargetCPUUtilization
+	}
+	if settings.MinAvailable != nil {
+		config.MinAvailable = *settings.MinAvailable
+	}
+
+	if err := validateGatewayScaling(config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func parseQuantityOverride(target *resource.Quantity, value *string, name string) error {
+	if value == nil {
+		return nil
+	} else if quantity, err := resource.ParseQuantity(*value); err != nil {
+		return fmt.Errorf("%v is invalid", name)
+	} else if quantity.Sign() <= 0 {
+		return fmt.Errorf("%v must be positive", name)
+	} else {
+		*target = quantity
+		return nil
+	}
+}
+
+func validateGatewayScaling(config env.GatewayScalingConfig) error {
+	if config.CPURequest.Cmp(config.CPULimit) > 0 {
+		return errors.New("cpu request must not be greater than cpu limit")
+	}
+	if config.MemoryRequest.Cmp(config.MemoryLimit) > 0 {
+		return errors.New("memory request must not be greater than memory limit")
+	}
+	if config.CPULimit.Cmp(config.MaxCPU) > 0 {
+		return fmt.Errorf("cpu limit must not be greater than %v", config.MaxCPU.String())
+	}
+	if config.MemoryLimit.Cmp(config.MaxMemory) > 0 {
+		return fmt.Errorf("memory limit must not be greater than %v", config.MaxMemory.String())
+	}
+
+	minReplicas := config.Replicas
+	if config.AutoscalingEnabled {
+		minReplicas = config.AutoscalingMinReplicas
+		if config.AutoscalingMinReplicas < 1 {
+			return errors.New("autoscaling min replicas must be at least 1")
+		} else if config.AutoscalingMaxReplicas < config.AutoscalingMinReplicas {
+			return errors.New("autoscaling max replicas must not be less than min replicas")
+		} else if config.AutoscalingMaxReplicas > config.MaxReplicas {
+			return fmt.Errorf("autoscaling max replicas must not be greater than %v", config.MaxReplicas)
+		} else if config.AutoscalingTargetCPUUtilization < 1 || config.AutoscalingTargetCPUUtilization > 100 {
+			return errors.New("autoscaling target cpu utilization must be between 1 and 100")
+		}
+	} else if config.Replicas < 1 {
+		return errors.New("replicas must be at least 1")
+	} else if config.Replicas > config.MaxReplicas {
+		return fmt.Errorf("replicas must not be greater than %v", config.MaxReplicas)
+	}
+
+	// a disruption budget that requires all replicas to be available would block node drains
+	if config.MinAvailable < 0 {
+		return errors.New("min available must not be negative")
+	} else if config.MinAvailable > 0 && config.MinAvailable >= minReplicas {
+		return errors.New("min available must be less than the minimum number of replicas")
+	}
+
+	return nil
+}
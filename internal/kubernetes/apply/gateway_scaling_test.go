@@ -0,0 +1,74 @@
+package apply
+
+import (
+	"testing"
+
+	"github.com/hyprmcp/jetski/internal/env"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidateGatewayScaling(t *testing.T) {
+	valid := env.GatewayScalingConfig{
+		Replicas:                        2,
+		CPURequest:                      resource.MustParse("5m"),
+		CPULimit:                        resource.MustParse("500m"),
+		MemoryRequest:                   resource.MustParse("16Mi"),
+		MemoryLimit:                     resource.MustParse("128Mi"),
+		AutoscalingMinReplicas:          2,
+		AutoscalingMaxReplicas:          4,
+		AutoscalingTargetCPUUtilization: 80,
+		MinAvailable:                    1,
+		MaxReplicas:                     10,
+		MaxCPU:                          resource.MustParse("1"),
+		MaxMemory:                       resource.MustParse("256Mi"),
+	}
+	expectNil := func(name string, modify func(c *env.GatewayScalingConfig)) {
+		config := valid
+		modify(&config)
+		if err := validateGatewayScaling(config); err != nil {
+			t.Errorf("%v: expected nil, got %v", name, err)
+		}
+	}
+	expectErr := func(name string, modify func(c *env.GatewayScalingConfig)) {
+		config := valid
+		modify(&config)
+		if err := validateGatewayScaling(config); err == nil {
+			t.Errorf("%v: expected error, got nil", name)
+		}
+	}
+
+	expectNil("valid", func(c *env.GatewayScalingConfig) {})
+	expectNil("autoscaling", func(c *env.GatewayScalingConfig) { c.AutoscalingEnabled = true })
+	expectNil("no disruption budget", func(c *env.GatewayScalingConfig) { c.Replicas = 1; c.MinAvailable = 0 })
+	expectNil("autoscaling ignores replicas", func(c *env.GatewayScalingConfig) {
+		c.AutoscalingEnabled = true
+		c.Replicas = 0
+	})
+	expectErr("cpu request above limit", func(c *env.GatewayScalingConfig) { c.CPURequest = resource.MustParse("1") })
+	expectErr("memory request above limit", func(c *env.GatewayScalingConfig) {
+		c.MemoryRequest = resource.MustParse("1Gi")
+	})
+	expectErr("cpu limit above maximum", func(c *env.GatewayScalingConfig) { c.CPULimit = resource.MustParse("2") })
+	expectErr("memory limit above maximum", func(c *env.GatewayScalingConfig) {
+		c.MemoryLimit = resource.MustParse("512Mi")
+	})
+	expectErr("no replicas", func(c *env.GatewayScalingConfig) { c.Replicas = 0; c.MinAvailable = 0 })
+	expectErr("too many replicas", func(c *env.GatewayScalingConfig) { c.Replicas = 11 })
+	expectErr("min available equals replicas", func(c *env.GatewayScalingConfig) { c.MinAvailable = 2 })
+	expectErr("autoscaling min above max", func(c *env.GatewayScalingConfig) {
+		c.AutoscalingEnabled = true
+		c.AutoscalingMinReplicas = 5
+	})
+	expectErr("autoscaling max above limit", func(c *env.GatewayScalingConfig) {
+		c.AutoscalingEnabled = true
+		c.AutoscalingMaxReplicas = 11
+	})
+	expectErr("autoscaling target above 100", func(c *env.GatewayScalingConfig) {
+		c.AutoscalingEnabled = true
+		c.AutoscalingTargetCPUUtilization = 101
+	})
+	expectErr("min available equals autoscaling min", func(c *env.GatewayScalingConfig) {
+		c.AutoscalingEnabled = true
+		c.MinAvailable = 2
+	})
+}
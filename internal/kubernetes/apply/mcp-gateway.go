@@ -2,8 +2,11 @@ package apply
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/hyprmcp/jetski/internal/apierrors"
 	internalctx "github.com/hyprmcp/jetski/internal/context"
 	"github.com/hyprmcp/jetski/internal/db"
 	"github.com/hyprmcp/jetski/internal/env"
@@ -11,8 +14,12 @@ import (
 	applyconfig "github.com/hyprmcp/jetski/internal/kubernetes/applyconfiguration/api/v1alpha1"
 	"github.com/hyprmcp/jetski/internal/types"
 	"github.com/hyprmcp/jetski/internal/util"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -24,7 +31,26 @@ func MCPGateway(client client.Client) *mcpGatewayApplier {
 	return &mcpGatewayApplier{client: client}
 }
 
+var errApplyInTx = errors.New("gateway must not be applied inside a transaction")
+
+// Apply creates or updates the MCPGateway of the organization, or deletes it if the organization has no projects. The
+// result is recorded as the sync status of the organization. Apply must be called after the transaction that changed
+// the organization has been committed, otherwise the sync hook could see the old state and the sync status would be
+// lost on a rollback.
 func (a *mcpGatewayApplier) Apply(ctx context.Context, org types.Organization) error {
+	if _, ok := internalctx.GetDb(ctx).(pgx.Tx); ok {
+		return errApplyInTx
+	}
+
+	attemptedAt := time.Now()
+	err := a.apply(ctx, org)
+	if recordErr := db.SetGatewaySyncStatus(ctx, org.ID, attemptedAt, err); recordErr != nil {
+		internalctx.GetLogger(ctx).Error("failed to record gateway sync status", zap.Error(recordErr))
+	}
+	return err
+}
+
+func (a *mcpGatewayApplier) apply(ctx context.Context, org types.Organization) error {
 	log := internalctx.GetLogger(ctx)
 	var gatewayProjects []*applyconfig.ProjectSpecApplyConfiguration
 	if pss, err := db.GetProjectSummaries(ctx, org.ID); err != nil {
@@ -37,7 +63,7 @@ func (a *mcpGatewayApplier) Apply(ctx context.Context, org types.Organization) e
 			&api.MCPGateway{ObjectMeta: metav1.ObjectMeta{Name: org.Name, Namespace: env.GatewayNamespace()}},
 			client.PropagationPolicy(metav1.DeletePropagationBackground),
 		)
-		if err != nil && !errors.IsNotFound(err) {
+		if err != nil && !k8serrors.IsNotFound(err) {
 			return fmt.Errorf("failed to delete gateway: %w", err)
 		} else {
 			return nil
@@ -54,14 +80,69 @@ func (a *mcpGatewayApplier) Apply(ctx context.Context, org types.Organization) e
 				WithAuthenticated(ps.LatestDeploymentRevision.Authenticated).
 				WithTelemetry(ps.LatestDeploymentRevision.Telemetry)
 
+			// the gateway uses the default prompt argument name if none is set
+			if env.GatewayPromptArgumentNameEnabled() &&
+				ps.Settings.Analytics.PromptArgumentName != types.DefaultPromptArgumentName {
+				spec.WithPromptArgumentName(ps.Settings.Analytics.PromptArgumentName)
+			}
+
 			if ps.LatestDeploymentRevision.ProxyURL != nil {
 				spec.WithProxyURL(*ps.LatestDeploymentRevision.ProxyURL)
 			}
 
+			if ps.LatestDeploymentRevision.OCIURL != nil && ps.LatestDeploymentRevision.Port != nil {
+				spec.WithOCIURL(*ps.LatestDeploymentRevision.OCIURL).
+					WithPort(int32(*ps.LatestDeploymentRevision.Port))
+			}
+
+			if policies, err := db.GetToolPoliciesForProject(ctx, ps.ID); err != nil {
+				return err
+			} else {
+				for _, policy := range policies {
+					spec.WithToolPolicies(
+						applyconfig.ToolPolicySpec().
+							WithEffect(string(policy.Effect)).
+							WithTools(policy.Tools...).
+							WithEmails(policy.Emails...).
+							WithDomains(policy.Domains...).
+							WithClaims(policy.Claims),
+					)
+				}
+			}
+
+			if auth, err := db.GetUpstreamAuth(ctx, ps.ID); errors.Is(err, apierrors.ErrNotFound) {
+				// the upstream of the project does not require authentication
+			} else if err != nil {
+				return err
+			} else {
+				authSpec := applyconfig.UpstreamAuthSpec().
+					WithType(string(auth.Type)).
+					WithScopes(auth.Scopes...).
+					WithUpdatedAt(auth.UpdatedAt.Format(time.RFC3339Nano))
+				if auth.HeaderName != nil {
+					authSpec.WithHeader(*auth.HeaderName)
+				}
+				if auth.TokenURL != nil {
+					authSpec.WithTokenURL(*auth.TokenURL)
+				}
+				if auth.ClientID != nil {
+					authSpec.WithClientID(*auth.ClientID)
+				}
+				spec.WithUpstreamAuth(authSpec)
+			}
+
 			gatewayProjects = append(gatewayProjects, spec)
 		}
 	}
 
+	// stored settings can become invalid when the platform limits change. The gateway must still be updated in this
+	// case, so the defaults are used until the organization updates its settings.
+	scaling, err := GatewayScaling(org.Settings.Gateway)
+	if err != nil {
+		log.Warn("gateway settings are invalid, using defaults", zap.Error(err), zap.Stringer("orgId", org.ID))
+		scaling = util.PtrTo(env.GatewayScaling())
+	}
+
 	spec := applyconfig.MCPGatewaySpec().
 		WithOrganizationID(org.ID.String()).
 		WithOrganizationName(org.Name).
@@ -72,13 +153,39 @@ func (a *mcpGatewayApplier) Apply(ctx context.Context, org types.Organization) e
 						WithPublicClient(org.Settings.Authorization.DCRPublicClient),
 				),
 		).
-		WithProjects(gatewayProjects...)
+		WithProjects(gatewayProjects...).
+		WithResources(
+			corev1apply.ResourceRequirements().
+				WithRequests(corev1.ResourceList{
+					corev1.ResourceCPU:    scaling.CPURequest,
+					corev1.ResourceMemory: scaling.MemoryRequest,
+				}).
+				WithLimits(corev1.ResourceList{
+					corev1.ResourceCPU:    scaling.CPULimit,
+					corev1.ResourceMemory: scaling.MemoryLimit,
+				}),
+		)
+
+	if scaling.AutoscalingEnabled {
+		spec.WithAutoscaling(
+			applyconfig.AutoscalingSpec().
+				WithMinReplicas(scaling.AutoscalingMinReplicas).
+				WithMaxReplicas(scaling.AutoscalingMaxReplicas).
+				WithTargetCPUUtilizationPercentage(scaling.AutoscalingTargetCPUUtilization),
+		)
+	} else {
+		spec.WithReplicas(scaling.Replicas)
+	}
+
+	if scaling.MinAvailable > 0 {
+		spec.WithPodDisruptionBudget(applyconfig.PodDisruptionBudgetSpec().WithMinAvailable(scaling.MinAvailable))
+	}
 
-	if org.Settings.CustomDomain != nil {
+	if org.Settings.HasVerifiedCustomDomain() {
 		spec.WithCustomDomain(*org.Settings.CustomDomain)
 	}
 
-	err := a.client.Apply(
+	err = a.client.Apply(
 		ctx,
 		applyconfig.MCPGateway(org.Name, env.GatewayNamespace()).WithSpec(spec),
 		&client.ApplyOptions{Force: util.PtrTo(true), FieldManager: "jetski"},
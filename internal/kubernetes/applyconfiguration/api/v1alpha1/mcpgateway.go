@@ -3,7 +3,6 @@
 package v1alpha1
 
 import (
-	apiv1alpha1 "github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
@@ -14,8 +13,8 @@ import (
 type MCPGatewayApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *MCPGatewaySpecApplyConfiguration `json:"spec,omitempty"`
-	Status                           *apiv1alpha1.MCPGatewayStatus     `json:"status,omitempty"`
+	Spec                             *MCPGatewaySpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *MCPGatewayStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // MCPGateway constructs a declarative configuration of the MCPGateway type for use with
@@ -199,8 +198,8 @@ func (b *MCPGatewayApplyConfiguration) WithSpec(value *MCPGatewaySpecApplyConfig
 // WithStatus sets the Status field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Status field is set to the value of the last call.
-func (b *MCPGatewayApplyConfiguration) WithStatus(value apiv1alpha1.MCPGatewayStatus) *MCPGatewayApplyConfiguration {
-	b.Status = &value
+func (b *MCPGatewayApplyConfiguration) WithStatus(value *MCPGatewayStatusApplyConfiguration) *MCPGatewayApplyConfiguration {
+	b.Status = value
 	return b
 }
 
@@ -2,14 +2,22 @@
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
 // MCPGatewaySpecApplyConfiguration represents a declarative configuration of the MCPGatewaySpec type for use
 // with apply.
 type MCPGatewaySpecApplyConfiguration struct {
-	OrganizationID   *string                              `json:"organizationId,omitempty"`
-	OrganizationName *string                              `json:"organizationName,omitempty"`
-	CustomDomain     *string                              `json:"customDomain,omitempty"`
-	Authorization    *AuthorizationSpecApplyConfiguration `json:"authorization,omitempty"`
-	Projects         []ProjectSpecApplyConfiguration      `json:"projects,omitempty"`
+	OrganizationID      *string                                    `json:"organizationId,omitempty"`
+	OrganizationName    *string                                    `json:"organizationName,omitempty"`
+	CustomDomain        *string                                    `json:"customDomain,omitempty"`
+	Authorization       *AuthorizationSpecApplyConfiguration       `json:"authorization,omitempty"`
+	Projects            []ProjectSpecApplyConfiguration            `json:"projects,omitempty"`
+	Replicas            *int32                                     `json:"replicas,omitempty"`
+	Resources           *v1.ResourceRequirementsApplyConfiguration `json:"resources,omitempty"`
+	Autoscaling         *AutoscalingSpecApplyConfiguration         `json:"autoscaling,omitempty"`
+	PodDisruptionBudget *PodDisruptionBudgetSpecApplyConfiguration `json:"podDisruptionBudget,omitempty"`
 }
 
 // MCPGatewaySpecApplyConfiguration constructs a declarative configuration of the MCPGatewaySpec type for use with
@@ -62,3 +70,35 @@ func (b *MCPGatewaySpecApplyConfiguration) WithProjects(values ...*ProjectSpecAp
 	}
 	return b
 }
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *MCPGatewaySpecApplyConfiguration) WithReplicas(value int32) *MCPGatewaySpecApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *MCPGatewaySpecApplyConfiguration) WithResources(value *v1.ResourceRequirementsApplyConfiguration) *MCPGatewaySpecApplyConfiguration {
+	b.Resources = value
+	return b
+}
+
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *MCPGatewaySpecApplyConfiguration) WithAutoscaling(value *AutoscalingSpecApplyConfiguration) *MCPGatewaySpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithPodDisruptionBudget sets the PodDisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodDisruptionBudget field is set to the value of the last call.
+func (b *MCPGatewaySpecApplyConfiguration) WithPodDisruptionBudget(value *PodDisruptionBudgetSpecApplyConfiguration) *MCPGatewaySpecApplyConfiguration {
+	b.PodDisruptionBudget = value
+	return b
+}
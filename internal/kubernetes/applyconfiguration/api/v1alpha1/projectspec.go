@@ -5,12 +5,17 @@ package v1alpha1
 // ProjectSpecApplyConfiguration represents a declarative configuration of the ProjectSpec type for use
 // with apply.
 type ProjectSpecApplyConfiguration struct {
-	ProjectID            *string `json:"projectId,omitempty"`
-	ProjectName          *string `json:"projectName,omitempty"`
-	DeploymentRevisionID *string `json:"deploymentRevisionId,omitempty"`
-	Authenticated        *bool   `json:"authenticationEnabled,omitempty"`
-	Telemetry            *bool   `json:"telemetryEnabled,omitempty"`
-	ProxyURL             *string `json:"proxyUrl,omitempty"`
+	ProjectID            *string                             `json:"projectId,omitempty"`
+	ProjectName          *string                             `json:"projectName,omitempty"`
+	DeploymentRevisionID *string                             `json:"deploymentRevisionId,omitempty"`
+	Authenticated        *bool                               `json:"authenticationEnabled,omitempty"`
+	Telemetry            *bool                               `json:"telemetryEnabled,omitempty"`
+	PromptArgumentName   *string                             `json:"promptArgumentName,omitempty"`
+	ProxyURL             *string                             `json:"proxyUrl,omitempty"`
+	OCIURL               *string                             `json:"ociUrl,omitempty"`
+	Port                 *int32                              `json:"port,omitempty"`
+	ToolPolicies         []ToolPolicySpecApplyConfiguration  `json:"toolPolicies,omitempty"`
+	UpstreamAuth         *UpstreamAuthSpecApplyConfiguration `json:"upstreamAuth,omitempty"`
 }
 
 // ProjectSpecApplyConfiguration constructs a declarative configuration of the ProjectSpec type for use with
@@ -59,6 +64,14 @@ func (b *ProjectSpecApplyConfiguration) WithTelemetry(value bool) *ProjectSpecAp
 	return b
 }
 
+// WithPromptArgumentName sets the PromptArgumentName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PromptArgumentName field is set to the value of the last call.
+func (b *ProjectSpecApplyConfiguration) WithPromptArgumentName(value string) *ProjectSpecApplyConfiguration {
+	b.PromptArgumentName = &value
+	return b
+}
+
 // WithProxyURL sets the ProxyURL field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ProxyURL field is set to the value of the last call.
@@ -66,3 +79,40 @@ func (b *ProjectSpecApplyConfiguration) WithProxyURL(value string) *ProjectSpecA
 	b.ProxyURL = &value
 	return b
 }
+
+// WithOCIURL sets the OCIURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OCIURL field is set to the value of the last call.
+func (b *ProjectSpecApplyConfiguration) WithOCIURL(value string) *ProjectSpecApplyConfiguration {
+	b.OCIURL = &value
+	return b
+}
+
+// WithPort sets the Port field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Port field is set to the value of the last call.
+func (b *ProjectSpecApplyConfiguration) WithPort(value int32) *ProjectSpecApplyConfiguration {
+	b.Port = &value
+	return b
+}
+
+// WithToolPolicies adds the given value to the ToolPolicies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ToolPolicies field.
+func (b *ProjectSpecApplyConfiguration) WithToolPolicies(values ...*ToolPolicySpecApplyConfiguration) *ProjectSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithToolPolicies")
+		}
+		b.ToolPolicies = append(b.ToolPolicies, *values[i])
+	}
+	return b
+}
+
+// WithUpstreamAuth sets the UpstreamAuth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpstreamAuth field is set to the value of the last call.
+func (b *ProjectSpecApplyConfiguration) WithUpstreamAuth(value *UpstreamAuthSpecApplyConfiguration) *ProjectSpecApplyConfiguration {
+	b.UpstreamAuth = value
+	return b
+}
@@ -18,14 +18,26 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=jetski.sh, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithKind("AuthorizationSpec"):
 		return &apiv1alpha1.AuthorizationSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoscalingSpec"):
+		return &apiv1alpha1.AutoscalingSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("DynamicClientRegistrationSpec"):
 		return &apiv1alpha1.DynamicClientRegistrationSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("MCPGateway"):
 		return &apiv1alpha1.MCPGatewayApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("MCPGatewaySpec"):
 		return &apiv1alpha1.MCPGatewaySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MCPGatewayStatus"):
+		return &apiv1alpha1.MCPGatewayStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PodDisruptionBudgetSpec"):
+		return &apiv1alpha1.PodDisruptionBudgetSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ProjectSpec"):
 		return &apiv1alpha1.ProjectSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ServerStatus"):
+		return &apiv1alpha1.ServerStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ToolPolicySpec"):
+		return &apiv1alpha1.ToolPolicySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UpstreamAuthSpec"):
+		return &apiv1alpha1.UpstreamAuthSpecApplyConfiguration{}
 
 	}
 	return nil
@@ -46,7 +46,7 @@ func Install(ctx context.Context, logger *zap.Logger, client ctrlclient.Client)
 }
 
 func ControllerConfig() *metactrl.CompositeController {
-	return &metactrl.CompositeController{
+	config := &metactrl.CompositeController{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "metacontroller.k8s.io/v1alpha1",
 			Kind:       "CompositeController",
@@ -64,6 +64,10 @@ func ControllerConfig() *metactrl.CompositeController {
 					ResourceRule:   metactrl.ResourceRule{APIVersion: "v1", Resource: "configmaps"},
 					UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
 				},
+				{
+					ResourceRule:   metactrl.ResourceRule{APIVersion: "v1", Resource: "secrets"},
+					UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
+				},
 				{
 					ResourceRule:   metactrl.ResourceRule{APIVersion: "apps/v1", Resource: "deployments"},
 					UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
@@ -76,6 +80,18 @@ func ControllerConfig() *metactrl.CompositeController {
 					ResourceRule:   metactrl.ResourceRule{APIVersion: "networking.k8s.io/v1", Resource: "ingresses"},
 					UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
 				},
+				{
+					ResourceRule:   metactrl.ResourceRule{APIVersion: "networking.k8s.io/v1", Resource: "networkpolicies"},
+					UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
+				},
+				{
+					ResourceRule:   metactrl.ResourceRule{APIVersion: "autoscaling/v2", Resource: "horizontalpodautoscalers"},
+					UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
+				},
+				{
+					ResourceRule:   metactrl.ResourceRule{APIVersion: "policy/v1", Resource: "poddisruptionbudgets"},
+					UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
+				},
 			},
 			Hooks: &metactrl.CompositeControllerHooks{
 				Sync: &metactrl.Hook{
@@ -84,6 +100,24 @@ func ControllerConfig() *metactrl.CompositeController {
 			},
 		},
 	}
+
+	// HTTPRoutes and Certificates are only registered if they are used, because metacontroller fails for resources that are not
+	// installed in the cluster. Ingresses are always registered, so that they are deleted after switching modes.
+	if env.GatewayRoutingMode() == env.GatewayRoutingModeHTTPRoute {
+		config.Spec.ChildResources = append(config.Spec.ChildResources, metactrl.CompositeControllerChildResourceRule{
+			ResourceRule:   metactrl.ResourceRule{APIVersion: "gateway.networking.k8s.io/v1", Resource: "httproutes"},
+			UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
+		})
+	}
+
+	if env.GatewayCertManagerIssuer() != nil {
+		config.Spec.ChildResources = append(config.Spec.ChildResources, metactrl.CompositeControllerChildResourceRule{
+			ResourceRule:   metactrl.ResourceRule{APIVersion: "cert-manager.io/v1", Resource: "certificates"},
+			UpdateStrategy: &metactrl.CompositeControllerChildUpdateStrategy{Method: metactrl.ChildUpdateInPlace},
+		})
+	}
+
+	return config
 }
 
 func DecodeResourceYamlDir() ([]ctrlclient.Object, error) {
@@ -0,0 +1,145 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/db/queryable"
+	"github.com/hyprmcp/jetski/internal/env"
+	api "github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
+	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type gatewayApplier interface {
+	Apply(ctx context.Context, org types.Organization) error
+}
+
+// Reconciler periodically applies the gateways of all organizations, so that changes whose apply failed eventually
+// reach the cluster, and deletes gateways that do not belong to an organization anymore.
+type Reconciler struct {
+	logger         *zap.Logger
+	db             queryable.Queryable
+	client         client.Client
+	gatewayApplier gatewayApplier
+	interval       time.Duration
+}
+
+func NewReconciler(logger *zap.Logger, db queryable.Queryable, client client.Client, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		logger:         logger,
+		db:             db,
+		client:         client,
+		gatewayApplier: apply.MCPGateway(client),
+		interval:       interval,
+	}
+}
+
+// Run reconciles all gateways every interval until ctx is canceled
+func (r *Reconciler) Run(ctx context.Context) {
+	ctx = internalctx.WithDb(ctx, r.db)
+	ctx = internalctx.WithLogger(ctx, r.logger)
+
+	r.logger.Info("starting gateway reconciler", zap.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.reconcile(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error("gateway reconciliation failed", zap.Error(err))
+			sentry.CaptureException(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("stopping gateway reconciler")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) error {
+	start := time.Now()
+	orgs, err := db.GetOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, org := range orgs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// the organization is read again, so that changes since the list was loaded are not overwritten
+		current, err := db.GetOrganization(ctx, org.ID)
+		if errors.Is(err, apierrors.ErrNotFound) {
+			continue
+		} else if err != nil {
+			failed++
+			r.logger.Warn("failed to get organization", zap.String("organization", org.Name), zap.Error(err))
+			continue
+		}
+		// the error is recorded in the sync status of the organization by the applier
+		if err := r.gatewayApplier.Apply(ctx, *current); err != nil {
+			failed++
+			r.logger.Warn("failed to apply gateway", zap.String("organization", current.Name), zap.Error(err))
+		}
+	}
+
+	if err := r.deleteOrphans(ctx, orgs, start); err != nil {
+		return err
+	}
+
+	r.logger.Info("gateways reconciled",
+		zap.Int("organizations", len(orgs)), zap.Int("failed", failed), zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// deleteOrphans deletes all gateways that do not belong to one of the organizations. Gateways that have been created
+// after the organizations were loaded are skipped, because their organization might not be part of the list.
+func (r *Reconciler) deleteOrphans(ctx context.Context, orgs []types.Organization, start time.Time) error {
+	var gateways api.MCPGatewayList
+	if err := r.client.List(ctx, &gateways, client.InNamespace(env.GatewayNamespace())); err != nil {
+		return fmt.Errorf("failed to list gateways: %w", err)
+	}
+
+	// creation timestamps only have a precision of seconds
+	for _, gateway := range findOrphans(gateways.Items, orgs, start.Truncate(time.Second)) {
+		r.logger.Info("deleting orphaned gateway", zap.String("name", gateway.Name))
+		err := r.client.Delete(ctx, &gateway, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete gateway %v: %w", gateway.Name, err)
+		}
+	}
+	return nil
+}
+
+// findOrphans returns the gateways whose name and organization ID do not match one of the organizations
+func findOrphans(gateways []api.MCPGateway, orgs []types.Organization, createdBefore time.Time) []api.MCPGateway {
+	orgIDs := make(map[string]string, len(orgs))
+	for _, org := range orgs {
+		orgIDs[org.Name] = org.ID.String()
+	}
+
+	var result []api.MCPGateway
+	for _, gateway := range gateways {
+		if !gateway.CreationTimestamp.Time.Before(createdBefore) {
+			continue
+		} else if orgID, ok := orgIDs[gateway.Name]; !ok || orgID != gateway.Spec.OrganizationID {
+			result = append(result, gateway)
+		}
+	}
+	return result
+}
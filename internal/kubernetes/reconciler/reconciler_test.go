@@ -0,0 +1,43 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	api "github.com/hyprmcp/jetski/internal/kubernetes/api/v1alpha1"
+	"github.com/hyprmcp/jetski/internal/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFindOrphans(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	org := types.Organization{ID: uuid.New(), Name: "org"}
+	gateway := func(name, orgID string, created time.Time) api.MCPGateway {
+		return api.MCPGateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+			Spec:       api.MCPGatewaySpec{OrganizationID: orgID},
+		}
+	}
+
+	orphans := findOrphans(
+		[]api.MCPGateway{
+			gateway("org", org.ID.String(), start.Add(-time.Hour)),
+			gateway("deleted", uuid.NewString(), start.Add(-time.Hour)),
+			gateway("org", uuid.NewString(), start.Add(-time.Hour)),
+			gateway("new", uuid.NewString(), start),
+		},
+		[]types.Organization{org},
+		start,
+	)
+
+	if len(orphans) != 2 {
+		t.Fatalf("expected 2 orphans but found %v", len(orphans))
+	}
+	if orphans[0].Name != "deleted" {
+		t.Errorf("expected gateway of deleted organization to be an orphan, got %v", orphans[0].Name)
+	}
+	if orphans[1].Spec.OrganizationID == org.ID.String() {
+		t.Error("expected gateway of the organization not to be an orphan")
+	}
+}
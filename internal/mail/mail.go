@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"html/template"
 	"net/mail"
+	texttemplate "text/template"
 )
 
 type Mail struct {
@@ -70,6 +71,16 @@ func TextBody(body string) MailOpt {
 	}
 }
 
+func TextBodyTemplate(tmpl *texttemplate.Template, data any) MailOpt {
+	return func(mail *Mail) {
+		mail.TextBodyFunc = func() (string, error) {
+			var b bytes.Buffer
+			err := tmpl.Execute(&b, data)
+			return b.String(), err
+		}
+	}
+}
+
 type mailOpts []MailOpt
 
 func (opts mailOpts) Apply(mail *Mail) {
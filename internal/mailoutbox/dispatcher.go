@@ -0,0 +1,102 @@
+package mailoutbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/db/queryable"
+	"github.com/hyprmcp/jetski/internal/mail"
+	"github.com/hyprmcp/jetski/internal/outbox"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+)
+
+const sendTimeout = 30 * time.Second
+
+var retryPolicy = outbox.RetryPolicy{MaxAttempts: 8, BaseDelay: 1 * time.Minute, MaxDelay: 1 * time.Hour}
+
+// Dispatcher periodically sends pending mails from the outbox and retries failed attempts with exponential backoff.
+type Dispatcher struct {
+	logger   *zap.Logger
+	db       queryable.Queryable
+	mailer   mail.Mailer
+	interval time.Duration
+}
+
+func NewDispatcher(logger *zap.Logger, db queryable.Queryable, mailer mail.Mailer, interval time.Duration) *Dispatcher {
+	return &Dispatcher{logger: logger, db: db, mailer: mailer, interval: interval}
+}
+
+// Run sends all due mails every interval until ctx is canceled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ctx = internalctx.WithDb(ctx, d.db)
+	ctx = internalctx.WithLogger(ctx, d.logger)
+
+	d.logger.Info("starting mail dispatcher", zap.Duration("interval", d.interval))
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatchPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("stopping mail dispatcher")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchPending(ctx context.Context) {
+	if err := outbox.DispatchPending(ctx, d.dispatchNext); err != nil {
+		d.logger.Error("mail dispatch failed", zap.Error(err))
+		sentry.CaptureException(err)
+	}
+}
+
+// dispatchNext locks and sends the next due mail. It returns apierrors.ErrNotFound if there is no such mail.
+func (d *Dispatcher) dispatchNext(ctx context.Context) error {
+	now := time.Now()
+	outboxMail, err := db.LockNextPendingOutboxMail(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	err = d.mailer.Send(sendCtx, toMail(*outboxMail))
+	cancel()
+	recordAttempt(outboxMail, now, err)
+
+	log := d.logger.With(
+		zap.Stringer("mail", outboxMail.ID),
+		zap.Strings("recipients", outboxMail.Recipients),
+		zap.Int("attempts", outboxMail.Attempts),
+	)
+	switch outboxMail.Status {
+	case types.MailStatusSent:
+		log.Info("mail sent")
+	case types.MailStatusFailed:
+		log.Warn("mail sending failed permanently", zap.Error(err))
+		sentry.CaptureException(err)
+	default:
+		log.Info("mail sending failed, will retry", zap.Error(err), zap.Time("nextAttemptAt", outboxMail.NextAttemptAt))
+	}
+
+	return db.UpdateOutboxMailAttempt(ctx, outboxMail)
+}
+
+// recordAttempt updates the mail with the result of an attempt and schedules the next attempt if necessary
+func recordAttempt(outboxMail *types.OutboxMail, now time.Time, err error) {
+	switch retryPolicy.RecordAttempt(&outboxMail.OutboxAttempts, now, err) {
+	case outbox.ResultSucceeded:
+		outboxMail.Status = types.MailStatusSent
+		outboxMail.SentAt = outboxMail.LastAttemptAt
+	case outbox.ResultFailed:
+		outboxMail.Status = types.MailStatusFailed
+	}
+}
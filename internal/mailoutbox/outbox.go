@@ -0,0 +1,56 @@
+package mailoutbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/mail"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// Enqueue renders the mail and stores it in the outbox, from where it is sent by the Dispatcher. It should be called
+// in the same transaction as the change that caused the mail, so that mails are only sent for committed changes.
+// If orgID is not nil, the mail is visible to the members of that organization.
+func Enqueue(ctx context.Context, orgID *uuid.UUID, m mail.Mail) error {
+	if outboxMail, err := newOutboxMail(orgID, m); err != nil {
+		return err
+	} else if err := db.CreateOutboxMail(ctx, outboxMail); err != nil {
+		return fmt.Errorf("failed to create outbox mail: %w", err)
+	}
+	return nil
+}
+
+func newOutboxMail(orgID *uuid.UUID, m mail.Mail) (*types.OutboxMail, error) {
+	outboxMail := types.OutboxMail{OrganizationID: orgID, Recipients: m.To, Subject: m.Subject}
+
+	if m.HtmlBodyFunc != nil {
+		if body, err := m.HtmlBodyFunc(); err != nil {
+			return nil, fmt.Errorf("failed to render html body: %w", err)
+		} else {
+			outboxMail.HtmlBody = &body
+		}
+	}
+
+	if m.TextBodyFunc != nil {
+		if body, err := m.TextBodyFunc(); err != nil {
+			return nil, fmt.Errorf("failed to render text body: %w", err)
+		} else {
+			outboxMail.TextBody = &body
+		}
+	}
+
+	return &outboxMail, nil
+}
+
+func toMail(outboxMail types.OutboxMail) mail.Mail {
+	opts := []mail.MailOpt{mail.To(outboxMail.Recipients...), mail.Subject(outboxMail.Subject)}
+	if outboxMail.HtmlBody != nil {
+		opts = append(opts, mail.HtmlBody(*outboxMail.HtmlBody))
+	}
+	if outboxMail.TextBody != nil {
+		opts = append(opts, mail.TextBody(*outboxMail.TextBody))
+	}
+	return mail.New(opts...)
+}
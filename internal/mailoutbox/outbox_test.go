@@ -0,0 +1,71 @@
+package mailoutbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/mail"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+func TestOutboxMailRoundTrip(t *testing.T) {
+	original := mail.New(
+		mail.To("test@example.com"),
+		mail.Subject("Welcome"),
+		mail.HtmlBody("<p>Hi</p>"),
+	)
+
+	outboxMail, err := newOutboxMail(nil, original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outboxMail.TextBody != nil {
+		t.Errorf("expected no text body, got %v", *outboxMail.TextBody)
+	}
+
+	restored := toMail(*outboxMail)
+	if len(restored.To) != 1 || restored.To[0] != "test@example.com" || restored.Subject != "Welcome" {
+		t.Errorf("unexpected mail: %+v", restored)
+	}
+	if body, err := restored.HtmlBodyFunc(); err != nil || body != "<p>Hi</p>" {
+		t.Errorf("unexpected html body: %v, %v", body, err)
+	}
+	if restored.TextBodyFunc != nil {
+		t.Error("expected no text body")
+	}
+
+	failing := mail.New(mail.To("test@example.com"), func(m *mail.Mail) {
+		m.TextBodyFunc = func() (string, error) { return "", errors.New("template error") }
+	})
+	if _, err := newOutboxMail(nil, failing); err == nil {
+		t.Error("expected error for failing template")
+	}
+}
+
+func TestRecordAttempt(t *testing.T) {
+	now := time.Now()
+	outboxMail := types.OutboxMail{Status: types.MailStatusPending}
+
+	recordAttempt(&outboxMail, now, errors.New("throttled"))
+	if outboxMail.Status != types.MailStatusPending || outboxMail.Attempts != 1 {
+		t.Errorf("expected pending mail after first failure: %+v", outboxMail)
+	}
+	if !outboxMail.NextAttemptAt.Equal(now.Add(retryPolicy.BaseDelay)) {
+		t.Errorf("unexpected next attempt: %v", outboxMail.NextAttemptAt)
+	}
+
+	recordAttempt(&outboxMail, now, nil)
+	if outboxMail.Status != types.MailStatusSent || outboxMail.SentAt == nil || outboxMail.LastError != nil {
+		t.Errorf("expected sent mail: %+v", outboxMail)
+	}
+
+	outboxMail = types.OutboxMail{
+		Status:         types.MailStatusPending,
+		OutboxAttempts: types.OutboxAttempts{Attempts: retryPolicy.MaxAttempts - 1},
+	}
+	recordAttempt(&outboxMail, now, errors.New("connection refused"))
+	if outboxMail.Status != types.MailStatusFailed {
+		t.Errorf("expected failed mail after max attempts: %+v", outboxMail)
+	}
+}
@@ -0,0 +1,55 @@
+package mailsending
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/mail"
+	"github.com/hyprmcp/jetski/internal/mailoutbox"
+	"github.com/hyprmcp/jetski/internal/mailtemplates"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// SendAlertMail enqueues a mail for every member of the organization of the project about a fired or resolved alert
+// incident
+func SendAlertMail(
+	ctx context.Context,
+	incident types.AlertIncident,
+	message string,
+	project types.ProjectSummary,
+) error {
+	members, err := db.GetOrganizationMembers(ctx, project.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("could not get organization members: %w", err)
+	}
+
+	subject := fmt.Sprintf("[%v] %v: %v", incident.Status, project.Name, incident.RuleName)
+	projectURL := url.URL{
+		Scheme: env.HostScheme(),
+		Host:   env.Host(),
+		Path:   fmt.Sprintf("/%v/project/%v", project.Organization.Name, project.Name),
+	}
+
+	for _, member := range members {
+		email := mail.New(
+			mail.To(member.Email),
+			mail.Subject(subject),
+			mail.HtmlBodyTemplate(mailtemplates.Alert(
+				incident,
+				message,
+				project.Project,
+				project.Organization,
+				projectURL.String(),
+			)),
+		)
+
+		if err := mailoutbox.Enqueue(ctx, &project.OrganizationID, email); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
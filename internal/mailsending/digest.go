@@ -0,0 +1,33 @@
+package mailsending
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/mail"
+	"github.com/hyprmcp/jetski/internal/mailoutbox"
+	"github.com/hyprmcp/jetski/internal/mailtemplates"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// SendDigestMail enqueues the usage digest of an organization for a single member
+func SendDigestMail(
+	ctx context.Context,
+	user types.UserAccount,
+	organization types.Organization,
+	digest types.OrganizationDigest,
+) error {
+	dashboardURL := url.URL{Scheme: env.HostScheme(), Host: env.Host(), Path: fmt.Sprintf("/%v", organization.Name)}
+	settingsURL := url.URL{Scheme: env.HostScheme(), Host: env.Host(), Path: fmt.Sprintf("/%v/settings", organization.Name)}
+
+	email := mail.New(
+		mail.To(user.Email),
+		mail.Subject(fmt.Sprintf("Your usage summary for %v", organization.Name)),
+		mail.HtmlBodyTemplate(mailtemplates.Digest(digest, organization, dashboardURL.String(), settingsURL.String())),
+		mail.TextBodyTemplate(mailtemplates.DigestText(digest, organization, dashboardURL.String(), settingsURL.String())),
+	)
+
+	return mailoutbox.Enqueue(ctx, &organization.ID, email)
+}
@@ -2,38 +2,37 @@ package mailsending
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 
-	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/invitations"
 	"github.com/hyprmcp/jetski/internal/mail"
+	"github.com/hyprmcp/jetski/internal/mailoutbox"
 	"github.com/hyprmcp/jetski/internal/mailtemplates"
 	"github.com/hyprmcp/jetski/internal/types"
-	"go.uber.org/zap"
 )
 
+// SendUserInviteMail enqueues the mail with the signed acceptance link of the invitation. It must be called in the
+// same transaction that creates or renews the invitation.
 func SendUserInviteMail(
 	ctx context.Context,
-	userAccount types.UserAccount,
+	invitation types.Invitation,
 	organization types.Organization,
 ) error {
-	mailer := internalctx.GetMailer(ctx)
-	log := internalctx.GetLogger(ctx)
+	inviteURL := url.URL{
+		Scheme:   env.HostScheme(),
+		Host:     env.Host(),
+		Path:     "/invitations/accept",
+		RawQuery: url.Values{"token": {invitations.NewToken(invitation)}}.Encode(),
+	}
 
 	email := mail.New(
-		mail.To(userAccount.Email),
+		mail.To(invitation.Email),
 		// mail.From(*from),
-		mail.Subject("Welcome to Jetski"),
-		mail.HtmlBodyTemplate(mailtemplates.InviteUser(userAccount, organization)),
+		mail.Subject(fmt.Sprintf("You have been invited to %v on Jetski", organization.Name)),
+		mail.HtmlBodyTemplate(mailtemplates.InviteUser(invitation, organization, inviteURL.String())),
 	)
 
-	if err := mailer.Send(ctx, email); err != nil {
-		log.Error(
-			"could not send invite mail",
-			zap.Error(err),
-			zap.String("user", userAccount.Email),
-		)
-		return err
-	} else {
-		log.Info("invite mail has been sent", zap.String("user", userAccount.Email))
-		return nil
-	}
+	return mailoutbox.Enqueue(ctx, &organization.ID, email)
 }
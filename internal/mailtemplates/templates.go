@@ -2,10 +2,12 @@ package mailtemplates
 
 import (
 	"embed"
+	"fmt"
 	"html/template"
 	"io/fs"
 	"net/url"
 	"path"
+	texttemplate "text/template"
 
 	"github.com/hyprmcp/jetski/internal/types"
 )
@@ -14,12 +16,19 @@ var (
 	//go:embed templates/*
 	embeddedFS embed.FS
 
-	templates *template.Template
-	funcMap   = template.FuncMap{
+	templates     *template.Template
+	textTemplates *texttemplate.Template
+	funcMap       = template.FuncMap{
 		"QueryEscape":    url.QueryEscape,
 		"UnsafeHTMLAttr": func(value string) template.HTMLAttr { return template.HTMLAttr(value) },
 		"UnsafeHTML":     func(value string) template.HTML { return template.HTML(value) },
 		"UnsafeURL":      func(value string) template.URL { return template.URL(value) },
+		"Percent":        percent,
+		"Change":         change,
+	}
+	textFuncMap = texttemplate.FuncMap{
+		"Percent": percent,
+		"Change":  change,
 	}
 )
 
@@ -28,9 +37,20 @@ func init() {
 		panic(err)
 	} else {
 		templates = template.Must(parse(fsys, "*.html", "fragments/*.html"))
+		textTemplates = texttemplate.Must(texttemplate.New("").Funcs(textFuncMap).ParseFS(fsys, "*.txt"))
 	}
 }
 
+// percent formats a ratio (e.g. 0.125) as a percentage (e.g. "12.5%")
+func percent(value float64) string {
+	return fmt.Sprintf("%.1f%%", value*100)
+}
+
+// change formats a relative change (e.g. -0.125) as a signed percentage (e.g. "-12.5%")
+func change(value float64) string {
+	return fmt.Sprintf("%+.1f%%", value*100)
+}
+
 func parse(fsys fs.FS, patterns ...string) (*template.Template, error) {
 	t := template.New("").Funcs(funcMap)
 	for _, p := range patterns {
@@ -51,12 +71,63 @@ func parse(fsys fs.FS, patterns ...string) (*template.Template, error) {
 }
 
 func InviteUser(
-	userAccount types.UserAccount,
+	invitation types.Invitation,
 	organization types.Organization,
+	inviteURL string,
 ) (*template.Template, any) {
 	return templates.Lookup("invite-user.html"),
 		map[string]any{
-			"UserAccount":  userAccount,
+			"Invitation":   invitation,
+			"Organization": organization,
+			"InviteURL":    inviteURL,
+		}
+}
+
+func Alert(
+	incident types.AlertIncident,
+	message string,
+	project types.Project,
+	organization types.Organization,
+	projectURL string,
+) (*template.Template, any) {
+	return templates.Lookup("alert.html"),
+		map[string]any{
+			"Incident":     incident,
+			"Message":      message,
+			"Project":      project,
 			"Organization": organization,
+			"ProjectURL":   projectURL,
 		}
 }
+
+func Digest(
+	digest types.OrganizationDigest,
+	organization types.Organization,
+	dashboardURL string,
+	settingsURL string,
+) (*template.Template, any) {
+	return templates.Lookup("digest.html"), digestData(digest, organization, dashboardURL, settingsURL)
+}
+
+func DigestText(
+	digest types.OrganizationDigest,
+	organization types.Organization,
+	dashboardURL string,
+	settingsURL string,
+) (*texttemplate.Template, any) {
+	return textTemplates.Lookup("digest.txt"), digestData(digest, organization, dashboardURL, settingsURL)
+}
+
+func digestData(
+	digest types.OrganizationDigest,
+	organization types.Organization,
+	dashboardURL string,
+	settingsURL string,
+) map[string]any {
+	return map[string]any{
+		"Digest":       digest,
+		"Organization": organization,
+		"DashboardURL": dashboardURL,
+		"SettingsURL":  settingsURL,
+	}
+}
@@ -14,11 +14,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/apitokens"
 	internalctx "github.com/hyprmcp/jetski/internal/context"
 	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/oidc"
 	"github.com/hyprmcp/jetski/internal/types"
-	"github.com/lestrrat-go/jwx/v3/jwk"
-	"github.com/lestrrat-go/jwx/v3/jwt"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -65,7 +65,7 @@ func LoggingMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(fn)
 }
 
-func AuthMiddleware(jwkSet jwk.Set) func(next http.Handler) http.Handler {
+func AuthMiddleware(verifier *oidc.Verifier) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
@@ -76,16 +76,17 @@ func AuthMiddleware(jwkSet jwk.Set) func(next http.Handler) http.Handler {
 			if len(parts) == 2 {
 				rawAccessToken = parts[1]
 			}
-			parsedAccessToken, err := jwt.ParseString(rawAccessToken, jwt.WithKeySet(jwkSet))
-			if err != nil {
-				logger.Info("failed to parse token", zap.Error(err))
-				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			if apitokens.IsToken(rawAccessToken) {
+				authenticateAPIToken(w, r, next, rawAccessToken)
 				return
 			}
-			var email string
-			err = parsedAccessToken.Get("email", &email)
+			parsedAccessToken, email, err := verifier.Verify(ctx, rawAccessToken)
 			if err != nil {
-				logger.Error("no email in token", zap.Error(err))
+				logger.Info("failed to verify token", zap.Error(err))
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			} else if strings.HasSuffix(strings.ToLower(email), "@"+types.ServiceAccountEmailDomain) {
+				logger.Warn("token for service account email rejected", zap.String("email", email))
 				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 				return
 			}
@@ -108,32 +109,51 @@ func AuthMiddleware(jwkSet jwk.Set) func(next http.Handler) http.Handler {
 	}
 }
 
+// authenticateAPIToken authenticates the request with a personal access token or a service account token
+func authenticateAPIToken(w http.ResponseWriter, r *http.Request, next http.Handler, rawToken string) {
+	ctx := r.Context()
+	logger := internalctx.GetLogger(ctx)
+	token, err := db.UseAPIToken(ctx, apitokens.Hash(rawToken), time.Now())
+	if errors.Is(err, apierrors.ErrNotFound) {
+		logger.Info("unknown, expired or revoked API token")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		logger.Error("failed to get API token", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		sentry.GetHubFromContext(ctx).CaptureException(err)
+		return
+	}
+	user, err := db.GetUserByID(ctx, token.UserAccountID)
+	if err != nil {
+		logger.Error("failed to get user of API token", zap.Error(err), zap.Stringer("tokenId", token.ID))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		sentry.GetHubFromContext(ctx).CaptureException(err)
+		return
+	}
+	ctx = internalctx.WithAPIToken(ctx, token)
+	ctx = internalctx.WithUser(ctx, user)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 var Sentry = sentryhttp.New(sentryhttp.Options{Repanic: true}).Handle
 
 func SentryUser(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		if hub := sentry.GetHubFromContext(ctx); hub != nil {
-			token := internalctx.GetAccessToken(ctx)
-			if sub, ok := token.Subject(); ok {
-				var email string
-				_ = token.Get("email", &email)
-				hub.Scope().SetUser(sentry.User{
-					ID:    sub,
-					Email: email,
-				})
-			}
+			user := internalctx.GetUser(ctx)
+			hub.Scope().SetUser(sentry.User{
+				ID:    user.ID.String(),
+				Email: user.Email,
+			})
 		}
 		h.ServeHTTP(w, r)
 	})
 }
 
 func RateLimitUserIDKey(r *http.Request) (string, error) {
-	token := internalctx.GetAccessToken(r.Context())
-	if sub, ok := token.Subject(); ok {
-		return sub, nil
-	}
-	return "", nil
+	return internalctx.GetUser(r.Context()).ID.String(), nil
 }
 
 func SetRequestPattern(next http.Handler) http.Handler {
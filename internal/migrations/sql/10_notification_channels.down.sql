@@ -0,0 +1,4 @@
+DROP TABLE NotificationDelivery;
+DROP TYPE NOTIFICATION_DELIVERY_STATUS;
+DROP TABLE NotificationChannel;
+DROP TYPE NOTIFICATION_CHANNEL_TYPE;
@@ -0,0 +1,34 @@
+CREATE TYPE NOTIFICATION_CHANNEL_TYPE AS ENUM ('webhook', 'slack');
+
+CREATE TABLE NotificationChannel (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  organization_id UUID NOT NULL REFERENCES Organization (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  type NOTIFICATION_CHANNEL_TYPE NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  -- an empty array subscribes the channel to all event types
+  event_types TEXT[] NOT NULL DEFAULT '{}',
+  enabled BOOLEAN NOT NULL DEFAULT true
+);
+CREATE INDEX fk_NotificationChannel_organization_id ON NotificationChannel (organization_id);
+
+CREATE TYPE NOTIFICATION_DELIVERY_STATUS AS ENUM ('pending', 'delivered', 'failed');
+
+CREATE TABLE NotificationDelivery (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  notification_channel_id UUID NOT NULL REFERENCES NotificationChannel (id) ON DELETE CASCADE,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  status NOTIFICATION_DELIVERY_STATUS NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  last_attempt_at TIMESTAMP,
+  last_status_code INT,
+  last_error TEXT,
+  delivered_at TIMESTAMP
+);
+CREATE INDEX fk_NotificationDelivery_notification_channel_id ON NotificationDelivery (notification_channel_id, created_at);
+CREATE INDEX NotificationDelivery_pending ON NotificationDelivery (next_attempt_at) WHERE status = 'pending';
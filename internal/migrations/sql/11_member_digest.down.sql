@@ -0,0 +1,5 @@
+ALTER TABLE Organization_UserAccount
+  DROP COLUMN last_digest_sent_at,
+  DROP COLUMN digest_frequency;
+
+DROP TYPE DIGEST_FREQUENCY;
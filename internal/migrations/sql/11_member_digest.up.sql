@@ -0,0 +1,5 @@
+CREATE TYPE DIGEST_FREQUENCY AS ENUM ('never', 'daily', 'weekly');
+
+ALTER TABLE Organization_UserAccount
+  ADD COLUMN digest_frequency DIGEST_FREQUENCY NOT NULL DEFAULT 'weekly',
+  ADD COLUMN last_digest_sent_at TIMESTAMP;
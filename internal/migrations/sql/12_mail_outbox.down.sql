@@ -0,0 +1,2 @@
+DROP TABLE Mail;
+DROP TYPE MAIL_STATUS;
@@ -0,0 +1,20 @@
+CREATE TYPE MAIL_STATUS AS ENUM ('pending', 'sent', 'failed');
+
+CREATE TABLE Mail (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  -- the organization the mail was sent on behalf of, used to show failed mails to its members
+  organization_id UUID REFERENCES Organization (id) ON DELETE CASCADE,
+  recipients TEXT[] NOT NULL,
+  subject TEXT NOT NULL,
+  html_body TEXT,
+  text_body TEXT,
+  status MAIL_STATUS NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  last_attempt_at TIMESTAMP,
+  last_error TEXT,
+  sent_at TIMESTAMP
+);
+CREATE INDEX fk_Mail_organization_id ON Mail (organization_id, created_at);
+CREATE INDEX Mail_pending ON Mail (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,4 @@
+ALTER TABLE Organization_UserAccount
+  DROP COLUMN role;
+
+DROP TYPE ORGANIZATION_ROLE;
@@ -0,0 +1,8 @@
+CREATE TYPE ORGANIZATION_ROLE AS ENUM ('owner', 'admin', 'developer', 'viewer');
+
+-- existing members keep full access to their organizations
+ALTER TABLE Organization_UserAccount
+  ADD COLUMN role ORGANIZATION_ROLE NOT NULL DEFAULT 'owner';
+
+ALTER TABLE Organization_UserAccount
+  ALTER COLUMN role SET DEFAULT 'developer';
@@ -0,0 +1,2 @@
+DROP TABLE Invitation;
+DROP TYPE INVITATION_STATUS;
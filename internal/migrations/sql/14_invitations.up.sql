@@ -0,0 +1,17 @@
+CREATE TYPE INVITATION_STATUS AS ENUM ('pending', 'accepted', 'revoked');
+
+CREATE TABLE Invitation (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  created_by UUID REFERENCES UserAccount (id) ON DELETE SET NULL,
+  organization_id UUID NOT NULL REFERENCES Organization (id) ON DELETE CASCADE,
+  email TEXT NOT NULL,
+  role ORGANIZATION_ROLE NOT NULL,
+  status INVITATION_STATUS NOT NULL DEFAULT 'pending',
+  expires_at TIMESTAMP NOT NULL,
+  last_sent_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  accepted_at TIMESTAMP,
+  accepted_by UUID REFERENCES UserAccount (id) ON DELETE SET NULL
+);
+CREATE INDEX fk_Invitation_organization_id ON Invitation (organization_id);
+CREATE UNIQUE INDEX Invitation_pending_email ON Invitation (organization_id, email) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+DROP TABLE ApiToken;
+DROP TABLE ServiceAccount;
@@ -0,0 +1,22 @@
+CREATE TABLE ServiceAccount (
+  id UUID PRIMARY KEY REFERENCES UserAccount (id),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  created_by UUID REFERENCES UserAccount (id) ON DELETE SET NULL,
+  organization_id UUID NOT NULL REFERENCES Organization (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  CONSTRAINT service_account_name_unique UNIQUE (organization_id, name)
+);
+
+CREATE TABLE ApiToken (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  created_by UUID REFERENCES UserAccount (id) ON DELETE SET NULL,
+  user_account_id UUID NOT NULL REFERENCES UserAccount (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  token_hash BYTEA NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  last_used_at TIMESTAMP,
+  revoked_at TIMESTAMP
+);
+CREATE INDEX fk_ApiToken_user_account_id ON ApiToken (user_account_id);
@@ -0,0 +1,2 @@
+DROP TABLE AuditLogEntry;
+DROP FUNCTION audit_log_entry_append_only;
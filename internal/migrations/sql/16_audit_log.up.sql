@@ -0,0 +1,25 @@
+CREATE TABLE AuditLogEntry (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  organization_id UUID REFERENCES Organization (id),
+  actor_id UUID,
+  actor_email TEXT NOT NULL,
+  api_token_id UUID,
+  action TEXT NOT NULL,
+  target_type TEXT NOT NULL,
+  target_id UUID NOT NULL,
+  changes JSONB NOT NULL,
+  request_id TEXT NOT NULL,
+  ip_address TEXT NOT NULL
+);
+CREATE INDEX AuditLogEntry_organization_id_created_at ON AuditLogEntry (organization_id, created_at);
+
+CREATE FUNCTION audit_log_entry_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit log entries can not be modified or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER AuditLogEntry_append_only
+  BEFORE UPDATE OR DELETE ON AuditLogEntry
+  FOR EACH ROW EXECUTE FUNCTION audit_log_entry_append_only();
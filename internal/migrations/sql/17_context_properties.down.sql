@@ -0,0 +1,7 @@
+ALTER TABLE Context DROP CONSTRAINT IF EXISTS context_user_account_id_fkey;
+ALTER TABLE Context ADD CONSTRAINT context_user_account_id_fkey
+  FOREIGN KEY (user_account_id) REFERENCES UserAccount (id);
+
+ALTER TABLE Context DROP CONSTRAINT context_auth_token_digest_unique;
+
+ALTER TABLE ContextProperty DROP CONSTRAINT context_property_name_unique;
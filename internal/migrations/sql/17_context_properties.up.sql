@@ -0,0 +1,10 @@
+ALTER TABLE ContextProperty
+  ADD CONSTRAINT context_property_name_unique UNIQUE (project_id, name);
+
+-- values are set per auth token, so there must be at most one value for each property and token
+ALTER TABLE Context
+  ADD CONSTRAINT context_auth_token_digest_unique UNIQUE (auth_token_digest, context_property_id);
+
+ALTER TABLE Context DROP CONSTRAINT IF EXISTS context_user_account_id_fkey;
+ALTER TABLE Context ADD CONSTRAINT context_user_account_id_fkey
+  FOREIGN KEY (user_account_id) REFERENCES UserAccount (id) ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+DROP TABLE ToolPolicy;
+DROP TYPE TOOL_POLICY_EFFECT;
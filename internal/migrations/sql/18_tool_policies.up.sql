@@ -0,0 +1,18 @@
+CREATE TYPE TOOL_POLICY_EFFECT AS ENUM ('allow', 'deny');
+
+-- ToolPolicy restricts which end users may call which tools of a project. Policies are evaluated in the order of
+-- their priority and the first policy that matches the user and the tool decides.
+CREATE TABLE ToolPolicy (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  created_by UUID REFERENCES UserAccount (id) ON DELETE SET NULL,
+  project_id UUID NOT NULL REFERENCES Project (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  priority INT NOT NULL,
+  effect TOOL_POLICY_EFFECT NOT NULL,
+  tools TEXT[] NOT NULL,
+  emails TEXT[] NOT NULL DEFAULT '{}',
+  domains TEXT[] NOT NULL DEFAULT '{}',
+  claims JSONB NOT NULL DEFAULT '{}'
+);
+CREATE INDEX fk_ToolPolicy_project_id ON ToolPolicy (project_id);
@@ -0,0 +1,12 @@
+-- EnvironmentVariable is set in the container of the hosted MCP server of a deployment revision. Every change of the
+-- variables creates a new revision, so the variables of a revision never change. Values are encrypted by the
+-- application before they are stored.
+CREATE TABLE EnvironmentVariable (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  deployment_revision_id UUID NOT NULL REFERENCES DeploymentRevision (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  secret BOOLEAN NOT NULL DEFAULT false,
+  value_encrypted BYTEA NOT NULL,
+  CONSTRAINT environment_variable_name_unique UNIQUE (deployment_revision_id, name)
+);
@@ -0,0 +1,2 @@
+DROP TABLE UpstreamAuth;
+DROP TYPE UPSTREAM_AUTH_TYPE;
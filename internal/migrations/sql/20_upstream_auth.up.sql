@@ -0,0 +1,16 @@
+CREATE TYPE UPSTREAM_AUTH_TYPE AS ENUM ('header', 'bearer', 'oauth2ClientCredentials');
+
+-- UpstreamAuth contains the credentials the gateway uses to authenticate to the upstream server of a proxied project.
+-- The secret is the header value, the bearer token or the OAuth2 client secret depending on the type. It is
+-- encrypted by the application before it is stored.
+CREATE TABLE UpstreamAuth (
+  project_id UUID PRIMARY KEY REFERENCES Project (id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  type UPSTREAM_AUTH_TYPE NOT NULL,
+  header_name TEXT,
+  token_url TEXT,
+  client_id TEXT,
+  scopes TEXT[] NOT NULL DEFAULT '{}',
+  secret_encrypted BYTEA NOT NULL
+);
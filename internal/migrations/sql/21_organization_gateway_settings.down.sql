@@ -0,0 +1,11 @@
+ALTER TABLE Organization
+  DROP COLUMN settings_gateway_replicas,
+  DROP COLUMN settings_gateway_cpu_request,
+  DROP COLUMN settings_gateway_cpu_limit,
+  DROP COLUMN settings_gateway_memory_request,
+  DROP COLUMN settings_gateway_memory_limit,
+  DROP COLUMN settings_gateway_autoscaling_enabled,
+  DROP COLUMN settings_gateway_autoscaling_min_replicas,
+  DROP COLUMN settings_gateway_autoscaling_max_replicas,
+  DROP COLUMN settings_gateway_autoscaling_target_cpu_utilization,
+  DROP COLUMN settings_gateway_min_available;
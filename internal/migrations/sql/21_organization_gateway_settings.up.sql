@@ -0,0 +1,12 @@
+-- The gateway settings override the platform defaults, NULL values use the default.
+ALTER TABLE Organization
+  ADD COLUMN settings_gateway_replicas INT,
+  ADD COLUMN settings_gateway_cpu_request TEXT,
+  ADD COLUMN settings_gateway_cpu_limit TEXT,
+  ADD COLUMN settings_gateway_memory_request TEXT,
+  ADD COLUMN settings_gateway_memory_limit TEXT,
+  ADD COLUMN settings_gateway_autoscaling_enabled BOOLEAN,
+  ADD COLUMN settings_gateway_autoscaling_min_replicas INT,
+  ADD COLUMN settings_gateway_autoscaling_max_replicas INT,
+  ADD COLUMN settings_gateway_autoscaling_target_cpu_utilization INT,
+  ADD COLUMN settings_gateway_min_available INT;
@@ -0,0 +1,5 @@
+ALTER TABLE Organization
+  DROP COLUMN settings_custom_domain_verification_token,
+  DROP COLUMN settings_custom_domain_verified,
+  DROP COLUMN settings_custom_domain_verified_at,
+  DROP COLUMN settings_custom_domain_checked_at;
@@ -0,0 +1,14 @@
+-- The verification token must be published in a TXT record of the custom domain. A verified domain is re-checked
+-- periodically and only loses its verification if the check fails for longer than the grace period.
+ALTER TABLE Organization
+  ADD COLUMN settings_custom_domain_verification_token TEXT,
+  ADD COLUMN settings_custom_domain_verified BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN settings_custom_domain_verified_at TIMESTAMP,
+  ADD COLUMN settings_custom_domain_checked_at TIMESTAMP;
+
+-- Existing custom domains stay verified, so that their owners have the grace period to publish the TXT record.
+UPDATE Organization
+  SET settings_custom_domain_verification_token = replace(gen_random_uuid()::TEXT, '-', ''),
+    settings_custom_domain_verified = true,
+    settings_custom_domain_verified_at = current_timestamp
+  WHERE settings_custom_domain IS NOT NULL;
@@ -0,0 +1,8 @@
+-- GatewaySyncStatus is the result of the last attempt to apply the MCPGateway of an organization. synced_at is the
+-- time of the last successful attempt and error is only set if the last attempt failed.
+CREATE TABLE GatewaySyncStatus (
+  organization_id UUID PRIMARY KEY REFERENCES Organization (id) ON DELETE CASCADE,
+  attempted_at TIMESTAMP NOT NULL,
+  synced_at TIMESTAMP,
+  error TEXT
+);
@@ -0,0 +1,6 @@
+-- Encrypted secrets cannot be decrypted by the database. Their channels keep working, but webhook receivers have to
+-- be updated with a new secret.
+UPDATE NotificationChannel SET secret = replace(gen_random_uuid()::TEXT, '-', '') WHERE secret IS NULL;
+ALTER TABLE NotificationChannel
+  DROP COLUMN secret_encrypted,
+  ALTER COLUMN secret SET NOT NULL;
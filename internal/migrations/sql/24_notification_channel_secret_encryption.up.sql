@@ -0,0 +1,6 @@
+-- Webhook signing secrets are encrypted by the application before they are stored. The key is not available to the
+-- database, so existing plaintext secrets are encrypted by the notification dispatcher when it starts. Afterwards,
+-- the secret column is always NULL.
+ALTER TABLE NotificationChannel
+  ADD COLUMN secret_encrypted BYTEA,
+  ALTER COLUMN secret DROP NOT NULL;
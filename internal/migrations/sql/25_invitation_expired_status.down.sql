@@ -0,0 +1,2 @@
+-- Values cannot be removed from an enum type, so expired invitations are revoked instead and the value is kept.
+UPDATE Invitation SET status = 'revoked' WHERE status = 'expired';
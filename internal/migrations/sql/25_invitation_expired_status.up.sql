@@ -0,0 +1,3 @@
+-- Pending invitations that have expired are set to expired when the same email address is invited again, so that
+-- they do not conflict with the new invitation in Invitation_pending_email.
+ALTER TYPE INVITATION_STATUS ADD VALUE 'expired';
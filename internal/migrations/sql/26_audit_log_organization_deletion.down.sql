@@ -0,0 +1,10 @@
+CREATE OR REPLACE FUNCTION audit_log_entry_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit log entries can not be modified or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Entries of deleted organizations can not be assigned to an organization again, they are kept with NULL
+ALTER TABLE AuditLogEntry
+  DROP CONSTRAINT auditlogentry_organization_id_fkey,
+  ADD CONSTRAINT auditlogentry_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES Organization (id);
@@ -0,0 +1,16 @@
+-- Audit log entries are kept when their organization is deleted, so that the deletion itself stays traceable. The
+-- append-only trigger allows exactly this update: setting organization_id to NULL without changing anything else.
+ALTER TABLE AuditLogEntry
+  DROP CONSTRAINT auditlogentry_organization_id_fkey,
+  ADD CONSTRAINT auditlogentry_organization_id_fkey
+    FOREIGN KEY (organization_id) REFERENCES Organization (id) ON DELETE SET NULL;
+
+CREATE OR REPLACE FUNCTION audit_log_entry_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' AND NEW.organization_id IS NULL
+    AND to_jsonb(NEW) - 'organization_id' = to_jsonb(OLD) - 'organization_id' THEN
+    RETURN NEW;
+  END IF;
+  RAISE EXCEPTION 'audit log entries can not be modified or deleted';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,3 @@
+ALTER TABLE Context
+  DROP CONSTRAINT context_auth_token_digest_unique,
+  ADD CONSTRAINT context_auth_token_digest_unique UNIQUE (auth_token_digest, context_property_id);
@@ -0,0 +1,5 @@
+-- Values are set per user and auth token. The digest alone must not be unique, otherwise a user could block the
+-- values of another user by setting values for the digest of their token first.
+ALTER TABLE Context
+  DROP CONSTRAINT context_auth_token_digest_unique,
+  ADD CONSTRAINT context_auth_token_digest_unique UNIQUE (user_account_id, auth_token_digest, context_property_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS Project_prompts_reindex_requested_at;
+
+ALTER TABLE Project
+  DROP COLUMN prompts_reindex_requested_at,
+  DROP COLUMN prompts_reindex_started_at;
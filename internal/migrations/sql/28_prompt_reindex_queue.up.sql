@@ -0,0 +1,8 @@
+-- Prompts are reindexed by a background worker after the prompt argument name of a project has been changed. A started
+-- reindex acts as a lease, so that at most one reindex runs per project at a time.
+ALTER TABLE Project
+  ADD COLUMN prompts_reindex_requested_at TIMESTAMP,
+  ADD COLUMN prompts_reindex_started_at TIMESTAMP;
+
+CREATE INDEX Project_prompts_reindex_requested_at ON Project (prompts_reindex_requested_at)
+  WHERE prompts_reindex_requested_at IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE Project DROP COLUMN settings_analytics_excluded_arguments;
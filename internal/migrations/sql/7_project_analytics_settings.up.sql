@@ -0,0 +1,2 @@
+ALTER TABLE Project
+  ADD COLUMN settings_analytics_excluded_arguments TEXT[] NOT NULL DEFAULT '{}';
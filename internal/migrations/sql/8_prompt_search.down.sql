@@ -0,0 +1,9 @@
+DROP INDEX MCPServerLog_prompt_tsv;
+DROP INDEX MCPServerLog_tool_name;
+
+ALTER TABLE MCPServerLog
+  DROP COLUMN prompt_tsv,
+  DROP COLUMN prompt,
+  DROP COLUMN tool_name;
+
+ALTER TABLE Project DROP COLUMN settings_analytics_prompt_argument_name;
@@ -0,0 +1,14 @@
+ALTER TABLE Project
+  ADD COLUMN settings_analytics_prompt_argument_name TEXT NOT NULL DEFAULT 'hyprmcpPromptAnalytics';
+
+ALTER TABLE MCPServerLog
+  ADD COLUMN tool_name TEXT GENERATED ALWAYS AS (
+    CASE WHEN mcp_request ->> 'method' = 'tools/call' THEN mcp_request #>> '{params,name}' END
+  ) STORED,
+  ADD COLUMN prompt TEXT,
+  ADD COLUMN prompt_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, coalesce(prompt, ''))) STORED;
+
+UPDATE MCPServerLog SET prompt = mcp_request #>> '{params,arguments,hyprmcpPromptAnalytics}';
+
+CREATE INDEX MCPServerLog_tool_name ON MCPServerLog (project_id, tool_name);
+CREATE INDEX MCPServerLog_prompt_tsv ON MCPServerLog USING GIN (prompt_tsv);
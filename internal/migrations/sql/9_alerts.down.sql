@@ -0,0 +1,4 @@
+DROP TABLE AlertIncident;
+DROP TYPE ALERT_INCIDENT_STATUS;
+DROP TABLE AlertRule;
+DROP TYPE ALERT_METRIC;
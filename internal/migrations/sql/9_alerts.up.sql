@@ -0,0 +1,39 @@
+CREATE TYPE ALERT_METRIC AS ENUM ('error_rate', 'p95_latency', 'request_volume_drop', 'new_error_group');
+
+CREATE TABLE AlertRule (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  created_by UUID NOT NULL REFERENCES UserAccount (id),
+  project_id UUID NOT NULL REFERENCES Project (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  metric ALERT_METRIC NOT NULL,
+  threshold DOUBLE PRECISION NOT NULL,
+  evaluation_window INTERVAL NOT NULL,
+  min_request_count INT NOT NULL DEFAULT 0,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  last_evaluated_at TIMESTAMP
+);
+CREATE INDEX fk_AlertRule_project_id ON AlertRule (project_id);
+CREATE INDEX AlertRule_last_evaluated_at ON AlertRule (last_evaluated_at) WHERE enabled;
+
+CREATE TYPE ALERT_INCIDENT_STATUS AS ENUM ('firing', 'resolved');
+
+-- AlertIncident contains a snapshot of the rule at the time the incident fired, so that the alert history is retained
+-- when a rule is changed or deleted.
+CREATE TABLE AlertIncident (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+  alert_rule_id UUID REFERENCES AlertRule (id) ON DELETE SET NULL,
+  project_id UUID NOT NULL REFERENCES Project (id) ON DELETE CASCADE,
+  status ALERT_INCIDENT_STATUS NOT NULL DEFAULT 'firing',
+  rule_name TEXT NOT NULL,
+  metric ALERT_METRIC NOT NULL,
+  threshold DOUBLE PRECISION NOT NULL,
+  value DOUBLE PRECISION NOT NULL,
+  message TEXT NOT NULL,
+  resolved_at TIMESTAMP
+);
+CREATE INDEX fk_AlertIncident_project_id ON AlertIncident (project_id, created_at);
+CREATE INDEX fk_AlertIncident_alert_rule_id ON AlertIncident (alert_rule_id);
+-- a rule can only have a single open incident at a time
+CREATE UNIQUE INDEX AlertIncident_firing ON AlertIncident (alert_rule_id) WHERE status = 'firing';
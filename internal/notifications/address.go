@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned if the URL of a channel resolves to an address that must not be reached from the
+// server, like loopback, private or link-local addresses (which include the cloud metadata endpoint).
+var ErrForbiddenAddress = errors.New("address is not allowed")
+
+// forbiddenPrefixes contains special purpose ranges that are not covered by the netip.Addr methods used in
+// isAllowedAddress. Shared address space is commonly used for cluster internal networks.
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// AddressResolver looks up the IP addresses of a host. It is implemented by net.Resolver.
+type AddressResolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+func isAllowedAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsLinkLocalUnicast() ||
+		addr.IsMulticast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL resolves the host of the URL and returns ErrForbiddenAddress if any of its addresses is not allowed. The
+// result can change at any time, so deliveries are checked again when the connection is established.
+func CheckURL(ctx context.Context, resolver AddressResolver, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil {
+		addrs = []netip.Addr{addr}
+	} else if addrs, err = resolver.LookupNetIP(ctx, "ip", u.Hostname()); err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !isAllowedAddress(addr) {
+			return fmt.Errorf("%w: %v resolves to %v", ErrForbiddenAddress, u.Hostname(), addr)
+		}
+	}
+	return nil
+}
+
+// checkDialAddress is used as net.Dialer.Control, so the address is checked after it has been resolved and right
+// before the connection is established. This also covers redirects and DNS records that changed after the channel
+// was saved.
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	if addrPort, err := netip.ParseAddrPort(address); err != nil {
+		return err
+	} else if !isAllowedAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// newDeliveryClient returns an HTTP client that refuses to connect to addresses that are not allowed. Proxies from the
+// environment are not used, because the address check would only apply to the proxy.
+func newDeliveryClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkDialAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,64 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+type fakeResolver map[string][]netip.Addr
+
+func (r fakeResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	if addrs, ok := r[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestCheckURL(t *testing.T) {
+	resolver := fakeResolver{
+		"hooks.example.com":    {netip.MustParseAddr("93.184.215.14")},
+		"internal.example.com": {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("10.0.0.1")},
+	}
+	expectNil := func(url string) {
+		if err := CheckURL(context.Background(), resolver, url); err != nil {
+			t.Errorf("%v: expected nil, got %v", url, err)
+		}
+	}
+	expectForbidden := func(url string) {
+		if err := CheckURL(context.Background(), resolver, url); !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("%v: expected ErrForbiddenAddress, got %v", url, err)
+		}
+	}
+
+	expectNil("https://hooks.example.com/webhook")
+	expectNil("https://93.184.215.14/webhook")
+	expectForbidden("https://internal.example.com/webhook")
+	expectForbidden("https://127.0.0.1/webhook")
+	expectForbidden("https://[::1]:8443/webhook")
+	expectForbidden("https://169.254.169.254/latest/meta-data")
+	expectForbidden("https://[::ffff:192.168.0.1]/webhook")
+	expectForbidden("https://100.64.0.1/webhook")
+	expectForbidden("https://0.0.0.0/webhook")
+	if err := CheckURL(context.Background(), resolver, "https://unknown.example.com"); err == nil ||
+		errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("expected lookup error, got %v", err)
+	}
+}
+
+func TestDeliveryClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	resp, err := newDeliveryClient(time.Second).Get(server.URL)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("expected ErrForbiddenAddress, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+const (
+	SignatureHeader = "X-Jetski-Signature"
+	TimestampHeader = "X-Jetski-Timestamp"
+	EventHeader     = "X-Jetski-Event"
+	DeliveryHeader  = "X-Jetski-Delivery"
+)
+
+// Sign computes the signature of a webhook payload. Receivers should compute the HMAC-SHA256 of
+// "<timestamp>.<body>" with the channel secret and compare it to the signature header.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newDeliveryRequest creates the HTTP request for a delivery in the format of the channel type
+func newDeliveryRequest(
+	ctx context.Context,
+	delivery types.NotificationDeliveryWithChannel,
+	now time.Time,
+) (*http.Request, error) {
+	var body []byte
+	switch delivery.Channel.Type {
+	case types.NotificationChannelTypeWebhook:
+		body = delivery.Payload
+	case types.NotificationChannelTypeSlack:
+		var event types.NotificationEvent
+		if err := json.Unmarshal(delivery.Payload, &event); err != nil {
+			return nil, fmt.Errorf("invalid payload: %w", err)
+		} else if body, err = json.Marshal(map[string]string{"text": event.Text}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown channel type: %v", delivery.Channel.Type)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Channel.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if delivery.Channel.Type == types.NotificationChannelTypeWebhook {
+		timestamp := now.Unix()
+		req.Header.Set(EventHeader, string(delivery.EventType))
+		req.Header.Set(DeliveryHeader, delivery.ID.String())
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, Sign(delivery.Channel.Secret, timestamp, body))
+	}
+
+	return req, nil
+}
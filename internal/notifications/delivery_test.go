@@ -0,0 +1,101 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+)
+
+func testDelivery(t *testing.T, channelType types.NotificationChannelType) types.NotificationDeliveryWithChannel {
+	payload, err := json.Marshal(types.NotificationEvent{
+		ID:   uuid.New(),
+		Type: types.NotificationEventTypeMemberAdded,
+		Text: "test@example.com has been added to the organization test.",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.NotificationDeliveryWithChannel{
+		NotificationDelivery: types.NotificationDelivery{
+			ID:        uuid.New(),
+			EventType: types.NotificationEventTypeMemberAdded,
+			Payload:   payload,
+		},
+		Channel: types.NotificationChannel{Type: channelType, URL: "https://example.com/hook", Secret: "secret"},
+	}
+}
+
+func TestNewDeliveryRequestWebhook(t *testing.T) {
+	delivery := testDelivery(t, types.NotificationChannelTypeWebhook)
+	now := time.Unix(1700000000, 0)
+
+	req, err := newDeliveryRequest(context.Background(), delivery, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("expected payload as body, got %s", body)
+	}
+	if req.Header.Get(TimestampHeader) != "1700000000" {
+		t.Errorf("unexpected timestamp header: %v", req.Header.Get(TimestampHeader))
+	}
+	if signature := req.Header.Get(SignatureHeader); signature != Sign("secret", now.Unix(), body) {
+		t.Errorf("unexpected signature header: %v", signature)
+	}
+	if Sign("secret", now.Unix(), body) == Sign("other", now.Unix(), body) {
+		t.Error("expected signature to depend on the secret")
+	}
+}
+
+func TestNewDeliveryRequestSlack(t *testing.T) {
+	delivery := testDelivery(t, types.NotificationChannelTypeSlack)
+
+	req, err := newDeliveryRequest(context.Background(), delivery, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != `{"text":"test@example.com has been added to the organization test."}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+	if req.Header.Get(SignatureHeader) != "" {
+		t.Error("expected no signature header for slack channels")
+	}
+}
+
+func TestRecordAttempt(t *testing.T) {
+	now := time.Now()
+	delivery := types.NotificationDelivery{Status: types.NotificationDeliveryStatusPending}
+
+	recordAttempt(&delivery, now, util.PtrTo(500), errors.New("unexpected status"))
+	if delivery.Status != types.NotificationDeliveryStatusPending || delivery.Attempts != 1 {
+		t.Errorf("expected pending delivery after first failure: %+v", delivery)
+	}
+	if !delivery.NextAttemptAt.Equal(now.Add(retryPolicy.BaseDelay)) {
+		t.Errorf("unexpected next attempt: %v", delivery.NextAttemptAt)
+	}
+
+	recordAttempt(&delivery, now, util.PtrTo(200), nil)
+	if delivery.Status != types.NotificationDeliveryStatusDelivered || delivery.DeliveredAt == nil || delivery.LastError != nil {
+		t.Errorf("expected delivered delivery: %+v", delivery)
+	}
+
+	delivery = types.NotificationDelivery{
+		Status:         types.NotificationDeliveryStatusPending,
+		OutboxAttempts: types.OutboxAttempts{Attempts: retryPolicy.MaxAttempts - 1},
+	}
+	recordAttempt(&delivery, now, nil, errors.New("connection refused"))
+	if delivery.Status != types.NotificationDeliveryStatusFailed {
+		t.Errorf("expected failed delivery after max attempts: %+v", delivery)
+	}
+}
@@ -0,0 +1,138 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/db/queryable"
+	"github.com/hyprmcp/jetski/internal/outbox"
+	"github.com/hyprmcp/jetski/internal/types"
+	"go.uber.org/zap"
+)
+
+const deliveryTimeout = 10 * time.Second
+
+var retryPolicy = outbox.RetryPolicy{MaxAttempts: 10, BaseDelay: 30 * time.Second, MaxDelay: 1 * time.Hour}
+
+// Dispatcher periodically sends pending notification deliveries and retries failed attempts with exponential
+// backoff.
+type Dispatcher struct {
+	logger   *zap.Logger
+	db       queryable.Queryable
+	client   *http.Client
+	interval time.Duration
+}
+
+func NewDispatcher(logger *zap.Logger, db queryable.Queryable, interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		logger:   logger,
+		db:       db,
+		client:   newDeliveryClient(deliveryTimeout),
+		interval: interval,
+	}
+}
+
+// Run sends all due deliveries every interval until ctx is canceled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ctx = internalctx.WithDb(ctx, d.db)
+	ctx = internalctx.WithLogger(ctx, d.logger)
+
+	d.logger.Info("starting notification dispatcher", zap.Duration("interval", d.interval))
+
+	if count, err := db.EncryptNotificationChannelSecrets(ctx); err != nil {
+		d.logger.Error("failed to encrypt notification channel secrets", zap.Error(err))
+		sentry.CaptureException(err)
+	} else if count > 0 {
+		d.logger.Info("encrypted notification channel secrets", zap.Int("count", count))
+	}
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatchPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("stopping notification dispatcher")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchPending(ctx context.Context) {
+	if err := outbox.DispatchPending(ctx, d.dispatchNext); err != nil {
+		d.logger.Error("notification dispatch failed", zap.Error(err))
+		sentry.CaptureException(err)
+	}
+}
+
+// dispatchNext locks and sends the next due delivery. It returns apierrors.ErrNotFound if there is no such delivery.
+func (d *Dispatcher) dispatchNext(ctx context.Context) error {
+	now := time.Now()
+	delivery, err := db.LockNextPendingNotificationDelivery(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	statusCode, err := d.send(ctx, *delivery, now)
+	recordAttempt(&delivery.NotificationDelivery, now, statusCode, err)
+
+	log := d.logger.With(
+		zap.Stringer("delivery", delivery.ID),
+		zap.Stringer("channel", delivery.Channel.ID),
+		zap.Int("attempts", delivery.Attempts),
+	)
+	switch delivery.Status {
+	case types.NotificationDeliveryStatusDelivered:
+		log.Info("notification delivered")
+	case types.NotificationDeliveryStatusFailed:
+		log.Warn("notification delivery failed permanently", zap.Error(err))
+	default:
+		log.Info("notification delivery failed, will retry", zap.Error(err), zap.Time("nextAttemptAt", delivery.NextAttemptAt))
+	}
+
+	return db.UpdateNotificationDeliveryAttempt(ctx, &delivery.NotificationDelivery)
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery types.NotificationDeliveryWithChannel, now time.Time) (*int, error) {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	req, err := newDeliveryRequest(ctx, delivery, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, outbox.MaxErrorLength))
+		return &resp.StatusCode, fmt.Errorf("unexpected status %v: %s", resp.Status, body)
+	}
+
+	return &resp.StatusCode, nil
+}
+
+// recordAttempt updates the delivery with the result of an attempt and schedules the next attempt if necessary
+func recordAttempt(delivery *types.NotificationDelivery, now time.Time, statusCode *int, err error) {
+	delivery.LastStatusCode = statusCode
+	switch retryPolicy.RecordAttempt(&delivery.OutboxAttempts, now, err) {
+	case outbox.ResultSucceeded:
+		delivery.Status = types.NotificationDeliveryStatusDelivered
+		delivery.DeliveredAt = delivery.LastAttemptAt
+	case outbox.ResultFailed:
+		delivery.Status = types.NotificationDeliveryStatusFailed
+	}
+}
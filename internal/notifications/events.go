@@ -0,0 +1,101 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+// Publish enqueues the event for all channels of the organization that are subscribed to it. It should be called in
+// the same transaction as the change that caused the event, so that notifications are only sent for committed
+// changes.
+func Publish(ctx context.Context, event types.NotificationEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	if payload, err := json.Marshal(event); err != nil {
+		return fmt.Errorf("failed to marshal notification event: %w", err)
+	} else if err := db.CreateNotificationDeliveries(ctx, event.OrganizationID, event.Type, payload); err != nil {
+		return fmt.Errorf("failed to create notification deliveries: %w", err)
+	}
+
+	return nil
+}
+
+type projectData struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func DeploymentRevisionCreated(
+	org types.Organization,
+	project types.Project,
+	revision types.DeploymentRevision,
+) types.NotificationEvent {
+	return types.NotificationEvent{
+		Type:           types.NotificationEventTypeDeploymentRevisionCreated,
+		OrganizationID: org.ID,
+		Text:           fmt.Sprintf("A new deployment revision has been created for project %v.", project.Name),
+		Data: map[string]any{
+			"project":            projectData{ID: project.ID, Name: project.Name},
+			"deploymentRevision": revision,
+		},
+	}
+}
+
+func DeploymentRevisionEventError(
+	org types.Organization,
+	project types.Project,
+	event types.DeploymentRevisionEvent,
+) types.NotificationEvent {
+	return types.NotificationEvent{
+		Type:           types.NotificationEventTypeDeploymentRevisionEventError,
+		OrganizationID: org.ID,
+		Text:           fmt.Sprintf("The deployment of project %v has failed.", project.Name),
+		Data: map[string]any{
+			"project":                 projectData{ID: project.ID, Name: project.Name},
+			"deploymentRevisionEvent": event,
+		},
+	}
+}
+
+func AlertIncidentChanged(
+	org types.Organization,
+	project types.Project,
+	incident types.AlertIncident,
+	message string,
+) types.NotificationEvent {
+	event := types.NotificationEvent{
+		Type:           types.NotificationEventTypeAlertFired,
+		OrganizationID: org.ID,
+		Text:           fmt.Sprintf("Alert %v for project %v is firing: %v", incident.RuleName, project.Name, message),
+		Data: map[string]any{
+			"project":  projectData{ID: project.ID, Name: project.Name},
+			"incident": incident,
+			"message":  message,
+		},
+	}
+	if incident.Status == types.AlertIncidentStatusResolved {
+		event.Type = types.NotificationEventTypeAlertResolved
+		event.Text = fmt.Sprintf("Alert %v for project %v has been resolved: %v", incident.RuleName, project.Name, message)
+	}
+	return event
+}
+
+func MemberAdded(org types.Organization, user types.UserAccount) types.NotificationEvent {
+	return types.NotificationEvent{
+		Type:           types.NotificationEventTypeMemberAdded,
+		OrganizationID: org.ID,
+		Text:           fmt.Sprintf("%v has been added to the organization %v.", user.Email, org.Name),
+		Data:           map[string]any{"user": user},
+	}
+}
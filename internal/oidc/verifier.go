@@ -0,0 +1,113 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+)
+
+var (
+	ErrUntrustedIssuer  = errors.New("token issuer is not trusted")
+	ErrInvalidAudience  = errors.New("token audience is not allowed")
+	ErrEmailMissing     = errors.New("token has no email claim")
+	ErrEmailNotVerified = errors.New("token email is not verified")
+)
+
+// Issuer is an identity provider whose tokens are accepted if they are signed with one of the keys in KeySet
+type Issuer struct {
+	// Issuer must match the "iss" claim of the token exactly
+	Issuer string
+	KeySet jwk.Set
+}
+
+type VerifierOptions struct {
+	// AllowedAudiences contains the client IDs that tokens must be issued for. At least one of them must be contained
+	// in the "aud" claim.
+	AllowedAudiences []string
+	// AllowAnyAudience skips the validation of the "aud" claim if AllowedAudiences is empty. It is used for tokens of
+	// clients that are registered dynamically, whose client IDs are not known in advance.
+	AllowAnyAudience bool
+	// ClockSkew is the tolerance when validating the "exp", "nbf" and "iat" claims
+	ClockSkew time.Duration
+	// RequireEmailVerified rejects tokens whose "email_verified" claim is missing or false
+	RequireEmailVerified bool
+	// Clock is used to validate the time based claims. Defaults to the system clock.
+	Clock jwt.Clock
+}
+
+// Verifier validates access tokens of the trusted identity providers
+type Verifier struct {
+	issuers map[string]jwk.Set
+	options VerifierOptions
+}
+
+func NewVerifier(issuers []Issuer, options VerifierOptions) *Verifier {
+	v := Verifier{issuers: make(map[string]jwk.Set, len(issuers)), options: options}
+	for _, issuer := range issuers {
+		v.issuers[issuer.Issuer] = issuer.KeySet
+	}
+	if v.options.Clock == nil {
+		v.options.Clock = jwt.ClockFunc(time.Now)
+	}
+	return &v
+}
+
+// Verify parses the token and returns it together with its email claim if the token has been signed by a trusted
+// issuer, has been issued for an allowed audience and is currently valid.
+func (v *Verifier) Verify(ctx context.Context, raw string) (jwt.Token, string, error) {
+	// the issuer is read from the unverified token only to select the key set, the signature is verified below
+	unverified, err := jwt.ParseInsecure([]byte(raw))
+	if err != nil {
+		return nil, "", err
+	}
+	issuer, _ := unverified.Issuer()
+	keySet, ok := v.issuers[issuer]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %q", ErrUntrustedIssuer, issuer)
+	}
+
+	token, err := jwt.ParseString(
+		raw,
+		jwt.WithContext(ctx),
+		jwt.WithKeySet(keySet),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(issuer),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithAcceptableSkew(v.options.ClockSkew),
+		jwt.WithClock(v.options.Clock),
+		jwt.WithValidator(jwt.ValidatorFunc(v.validateAudience)),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var email string
+	if err := token.Get("email", &email); err != nil || email == "" {
+		return nil, "", ErrEmailMissing
+	}
+	if v.options.RequireEmailVerified {
+		var verified bool
+		if err := token.Get("email_verified", &verified); err != nil || !verified {
+			return nil, "", ErrEmailNotVerified
+		}
+	}
+	return token, email, nil
+}
+
+func (v *Verifier) validateAudience(_ context.Context, token jwt.Token) error {
+	if v.options.AllowAnyAudience && len(v.options.AllowedAudiences) == 0 {
+		return nil
+	}
+	audience, _ := token.Audience()
+	for _, aud := range audience {
+		if slices.Contains(v.options.AllowedAudiences, aud) {
+			return nil
+		}
+	}
+	return ErrInvalidAudience
+}
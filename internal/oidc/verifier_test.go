@@ -0,0 +1,171 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+)
+
+func TestVerifier(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	key, keySet := newTestKey(t)
+	otherKey, _ := newTestKey(t)
+
+	verifier := NewVerifier(
+		[]Issuer{{Issuer: "https://dex.example.com", KeySet: keySet}},
+		VerifierOptions{
+			AllowedAudiences:     []string{"ui"},
+			ClockSkew:            30 * time.Second,
+			RequireEmailVerified: true,
+			Clock:                jwt.ClockFunc(func() time.Time { return now }),
+		},
+	)
+
+	sign := func(key jwk.Key, modify func(b *jwt.Builder)) string {
+		b := jwt.NewBuilder().
+			Issuer("https://dex.example.com").
+			Audience([]string{"ui"}).
+			Expiration(now.Add(time.Minute)).
+			IssuedAt(now).
+			Claim("email", "test@example.com").
+			Claim("email_verified", true)
+		if modify != nil {
+			modify(b)
+		}
+		token, err := b.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES256(), key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(signed)
+	}
+
+	expectNil := func(name, raw string) {
+		if _, email, err := verifier.Verify(context.Background(), raw); err != nil {
+			t.Errorf("%v: expected nil but found error: %v", name, err)
+		} else if email != "test@example.com" {
+			t.Errorf("%v: expected email test@example.com but found %v", name, email)
+		}
+	}
+
+	expectErr := func(name, raw string, target error) {
+		if _, _, err := verifier.Verify(context.Background(), raw); err == nil {
+			t.Errorf("%v: expected error but found nil", name)
+		} else if target != nil && !errors.Is(err, target) {
+			t.Errorf("%v: expected %v but found %v", name, target, err)
+		}
+	}
+
+	expectNil("valid", sign(key, nil))
+	expectNil("multiple audiences", sign(key, func(b *jwt.Builder) { b.Audience([]string{"other", "ui"}) }))
+	expectNil("expired within skew", sign(key, func(b *jwt.Builder) { b.Expiration(now.Add(-10 * time.Second)) }))
+
+	expectErr("wrong key", sign(otherKey, nil), nil)
+	expectErr("untrusted issuer", sign(key, func(b *jwt.Builder) { b.Issuer("https://evil.example.com") }),
+		ErrUntrustedIssuer)
+	expectErr("wrong audience", sign(key, func(b *jwt.Builder) { b.Audience([]string{"gateway-client"}) }),
+		ErrInvalidAudience)
+	expectErr("expired", sign(key, func(b *jwt.Builder) { b.Expiration(now.Add(-time.Minute)) }), nil)
+	expectErr("not yet valid", sign(key, func(b *jwt.Builder) { b.NotBefore(now.Add(time.Minute)) }), nil)
+	expectErr("email not verified", sign(key, func(b *jwt.Builder) { b.Claim("email_verified", false) }),
+		ErrEmailNotVerified)
+	expectErr("email missing", sign(key, func(b *jwt.Builder) { b.Claim("email", "") }), ErrEmailMissing)
+	expectErr("malformed", "not-a-token", nil)
+}
+
+func TestVerifierGatewayAudience(t *testing.T) {
+	now := time.Now()
+	key, keySet := newTestKey(t)
+	issuers := []Issuer{{Issuer: "https://dex.example.com", KeySet: keySet}}
+
+	token, err := jwt.NewBuilder().
+		Issuer("https://dex.example.com").
+		Audience([]string{"dynamically-registered-client"}).
+		Expiration(now.Add(time.Minute)).
+		Claim("email", "test@example.com").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES256(), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectAudience := func(name string, options VerifierOptions, target error) {
+		_, _, err := NewVerifier(issuers, options).Verify(context.Background(), string(signed))
+		if target == nil && err != nil {
+			t.Errorf("%v: expected nil but found error: %v", name, err)
+		} else if target != nil && !errors.Is(err, target) {
+			t.Errorf("%v: expected %v but found %v", name, target, err)
+		}
+	}
+
+	expectAudience("ui audience", VerifierOptions{AllowedAudiences: []string{"ui"}}, ErrInvalidAudience)
+	expectAudience("any audience", VerifierOptions{AllowAnyAudience: true}, nil)
+	expectAudience("gateway audience",
+		VerifierOptions{AllowedAudiences: []string{"dynamically-registered-client"}, AllowAnyAudience: true}, nil)
+	expectAudience("other gateway audience",
+		VerifierOptions{AllowedAudiences: []string{"gateway"}, AllowAnyAudience: true}, ErrInvalidAudience)
+}
+
+func newTestKey(t *testing.T) (jwk.Key, jwk.Set) {
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwk.Import(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = key.Set(jwk.KeyIDKey, rand.Text())
+	_ = key.Set(jwk.AlgorithmKey, jwa.ES256())
+	public, err := key.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := jwk.NewSet()
+	if err := set.AddKey(public); err != nil {
+		t.Fatal(err)
+	}
+	return key, set
+}
+
+func TestDevToken(t *testing.T) {
+	now := time.Now()
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	issuer, err := DevIssuer(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := NewVerifier(
+		[]Issuer{*issuer},
+		VerifierOptions{AllowedAudiences: []string{"ui"}, RequireEmailVerified: true},
+	)
+
+	if raw, err := NewDevToken(secret, "test@example.com", "ui", now, time.Hour); err != nil {
+		t.Fatal(err)
+	} else if _, email, err := verifier.Verify(context.Background(), raw); err != nil {
+		t.Errorf("expected nil but found error: %v", err)
+	} else if email != "test@example.com" {
+		t.Errorf("expected email test@example.com but found %v", email)
+	}
+
+	otherSecret := []byte("fedcba9876543210fedcba9876543210")
+	if raw, err := NewDevToken(otherSecret, "test@example.com", "ui", now, time.Hour); err != nil {
+		t.Fatal(err)
+	} else if _, _, err := verifier.Verify(context.Background(), raw); err == nil {
+		t.Error("expected error for token signed with another secret but found nil")
+	}
+}
@@ -0,0 +1,83 @@
+// Package outbox contains the parts that are shared by the dispatchers of entries that are stored in the database and
+// sent asynchronously with retries, like mails and notification deliveries.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/types"
+	"github.com/hyprmcp/jetski/internal/util"
+)
+
+// MaxErrorLength is the maximum length of the error that is stored for a failed attempt
+const MaxErrorLength = 1000
+
+// Result is the state of an entry after an attempt
+type Result int
+
+const (
+	// ResultSucceeded means that the entry has been sent
+	ResultSucceeded Result = iota
+	// ResultRetry means that the attempt failed and the next attempt has been scheduled
+	ResultRetry
+	// ResultFailed means that the attempt failed and there are no attempts left
+	ResultFailed
+)
+
+// RetryPolicy schedules the attempts of an entry with exponential backoff
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts after which an entry is failed permanently
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Delay returns the exponential backoff delay after the given number of failed attempts
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// RecordAttempt updates the attempts with the result of an attempt at now and schedules the next attempt if the
+// attempt failed and there are attempts left
+func (p RetryPolicy) RecordAttempt(attempts *types.OutboxAttempts, now time.Time, err error) Result {
+	attempts.Attempts++
+	attempts.LastAttemptAt = util.PtrTo(now.UTC())
+
+	if err == nil {
+		attempts.LastError = nil
+		return ResultSucceeded
+	}
+
+	message := err.Error()
+	if len(message) > MaxErrorLength {
+		message = message[:MaxErrorLength]
+	}
+	attempts.LastError = &message
+
+	if attempts.Attempts >= p.MaxAttempts {
+		return ResultFailed
+	}
+	attempts.NextAttemptAt = now.Add(p.Delay(attempts.Attempts))
+	return ResultRetry
+}
+
+// DispatchPending calls dispatchNext in a new transaction until it returns apierrors.ErrNotFound, which means that
+// there are no due entries left, or ctx is canceled. Any other error stops the dispatching and is returned.
+func DispatchPending(ctx context.Context, dispatchNext func(ctx context.Context) error) error {
+	for ctx.Err() == nil {
+		if err := db.RunTx(ctx, dispatchNext); errors.Is(err, apierrors.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
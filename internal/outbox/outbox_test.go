@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyprmcp/jetski/internal/types"
+)
+
+var policy = RetryPolicy{MaxAttempts: 3, BaseDelay: 30 * time.Second, MaxDelay: time.Hour}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	for attempts, expected := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		4:  4 * time.Minute,
+		9:  time.Hour,
+		20: time.Hour,
+	} {
+		if delay := policy.Delay(attempts); delay != expected {
+			t.Errorf("Delay(%v): expected %v, got %v", attempts, expected, delay)
+		}
+	}
+}
+
+func TestRetryPolicyRecordAttempt(t *testing.T) {
+	now := time.Now()
+	var attempts types.OutboxAttempts
+
+	if result := policy.RecordAttempt(&attempts, now, errors.New(strings.Repeat("x", 2*MaxErrorLength))); result != ResultRetry {
+		t.Errorf("expected retry after first failure, got %v", result)
+	} else if attempts.Attempts != 1 || !attempts.NextAttemptAt.Equal(now.Add(policy.BaseDelay)) {
+		t.Errorf("unexpected attempts after first failure: %+v", attempts)
+	} else if attempts.LastError == nil || len(*attempts.LastError) != MaxErrorLength {
+		t.Errorf("expected truncated error, got %v", attempts.LastError)
+	}
+
+	if result := policy.RecordAttempt(&attempts, now, nil); result != ResultSucceeded {
+		t.Errorf("expected success, got %v", result)
+	} else if attempts.LastError != nil || attempts.LastAttemptAt == nil {
+		t.Errorf("unexpected attempts after success: %+v", attempts)
+	}
+
+	if result := policy.RecordAttempt(&attempts, now, errors.New("connection refused")); result != ResultFailed {
+		t.Errorf("expected failure after max attempts, got %v", result)
+	}
+}
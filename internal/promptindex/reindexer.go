@@ -0,0 +1,92 @@
+package promptindex
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/google/uuid"
+	"github.com/hyprmcp/jetski/internal/apierrors"
+	internalctx "github.com/hyprmcp/jetski/internal/context"
+	"github.com/hyprmcp/jetski/internal/db"
+	"github.com/hyprmcp/jetski/internal/db/queryable"
+	"go.uber.org/zap"
+)
+
+// leaseDuration is the time after which a started reindex is considered to have been aborted, e.g. because the
+// instance running it has crashed, so that it can be claimed again
+const leaseDuration = 1 * time.Hour
+
+// Reindexer periodically reindexes the prompts of all projects whose prompt argument name has been changed. Reindexes
+// are claimed in the database, so that at most one reindex runs per project, even with multiple instances.
+type Reindexer struct {
+	logger   *zap.Logger
+	db       queryable.Queryable
+	interval time.Duration
+}
+
+func NewReindexer(logger *zap.Logger, db queryable.Queryable, interval time.Duration) *Reindexer {
+	return &Reindexer{logger: logger, db: db, interval: interval}
+}
+
+// Run reindexes all requested projects every interval until ctx is canceled
+func (r *Reindexer) Run(ctx context.Context) {
+	ctx = internalctx.WithDb(ctx, r.db)
+	ctx = internalctx.WithLogger(ctx, r.logger)
+
+	r.logger.Info("starting prompt reindexer", zap.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.reindexRequested(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("stopping prompt reindexer")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reindexer) reindexRequested(ctx context.Context) {
+	for ctx.Err() == nil {
+		projectID, err := db.ClaimNextPromptReindex(ctx, time.Now().Add(-leaseDuration))
+		if errors.Is(err, apierrors.ErrNotFound) {
+			return
+		} else if err != nil {
+			r.logger.Error("failed to claim prompt reindex", zap.Error(err))
+			sentry.CaptureException(err)
+			return
+		}
+
+		if err := r.reindex(ctx, projectID); err != nil {
+			return
+		}
+	}
+}
+
+// reindex reindexes the prompts of the project and releases the claim. If the reindex fails or is canceled, it is
+// requested again, so that it is retried in the next run.
+func (r *Reindexer) reindex(ctx context.Context, projectID uuid.UUID) error {
+	start := time.Now()
+	reindexErr := db.ReindexPromptsForProject(ctx, projectID)
+	if reindexErr != nil && ctx.Err() == nil {
+		r.logger.Error("failed to reindex prompts", zap.Stringer("projectId", projectID), zap.Error(reindexErr))
+		sentry.CaptureException(reindexErr)
+	} else if reindexErr == nil {
+		r.logger.Info("prompts reindexed",
+			zap.Stringer("projectId", projectID), zap.Duration("duration", time.Since(start)))
+	}
+
+	// the claim is also released after ctx has been canceled, so that another instance can continue immediately
+	if err := db.FinishPromptReindex(context.WithoutCancel(ctx), projectID, reindexErr != nil); err != nil {
+		r.logger.Error("failed to finish prompt reindex", zap.Stringer("projectId", projectID), zap.Error(err))
+		sentry.CaptureException(err)
+		return err
+	}
+	return reindexErr
+}
@@ -4,8 +4,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/hyprmcp/jetski/internal/domainverification"
 	"github.com/hyprmcp/jetski/internal/mail"
-	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/hyprmcp/jetski/internal/oidc"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/go-chi/chi/v5"
@@ -23,9 +24,11 @@ func NewRouter(
 	logger *zap.Logger,
 	db *pgxpool.Pool,
 	tracers *tracers.Tracers,
-	jwkSet jwk.Set,
+	tokenVerifier *oidc.Verifier,
+	gatewayTokenVerifier *oidc.Verifier,
 	mailer mail.Mailer,
 	k8sClient client.Client,
+	domainResolver domainverification.Resolver,
 ) http.Handler {
 	router := chi.NewRouter()
 	router.Use(
@@ -34,7 +37,10 @@ func NewRouter(
 		// Reject bodies larger than 1MiB
 		chimiddleware.RequestSize(1048576),
 	)
-	router.Mount("/api", ApiRouter(logger, db, tracers, jwkSet, mailer, k8sClient))
+	router.Mount(
+		"/api",
+		ApiRouter(logger, db, tracers, tokenVerifier, gatewayTokenVerifier, mailer, k8sClient, domainResolver),
+	)
 	router.Mount("/internal", InternalRouter())
 	router.Mount("/webhook", WebhookRouter(logger, db))
 	router.Mount("/", FrontendRouter())
@@ -45,9 +51,11 @@ func ApiRouter(
 	logger *zap.Logger,
 	db *pgxpool.Pool,
 	tracers *tracers.Tracers,
-	jwkSet jwk.Set,
+	tokenVerifier *oidc.Verifier,
+	gatewayTokenVerifier *oidc.Verifier,
 	mailer mail.Mailer,
 	k8sClient client.Client,
+	domainResolver domainverification.Resolver,
 ) http.Handler {
 	r := chi.NewRouter()
 	r.Use(
@@ -57,7 +65,7 @@ func ApiRouter(
 		middleware.LoggerCtxMiddleware(logger),
 		middleware.LoggingMiddleware,
 		middleware.ContextInjectorMiddleware(db, mailer),
-		middleware.AuthMiddleware(jwkSet),
+		middleware.AuthMiddleware(tokenVerifier),
 	)
 
 	r.Route("/v1", func(r chi.Router) {
@@ -70,8 +78,10 @@ func ApiRouter(
 		)
 
 		r.Route("/context", handlers.ContextRouter)
-		r.Route("/organizations", handlers.OrganizationsRouter(k8sClient))
-		r.Route("/projects", handlers.ProjectsRouter(k8sClient))
+		r.Route("/organizations", handlers.OrganizationsRouter(k8sClient, domainResolver))
+		r.Route("/invitations", handlers.InvitationsRouter)
+		r.Route("/api-tokens", handlers.APITokensRouter)
+		r.Route("/projects", handlers.ProjectsRouter(k8sClient, gatewayTokenVerifier))
 		r.Route("/dashboard", handlers.DashboardRouter)
 		r.Group(handlers.MiscRouter())
 	})
@@ -87,6 +97,7 @@ func InternalRouter() http.Handler {
 
 func WebhookRouter(logger *zap.Logger, db *pgxpool.Pool) http.Handler {
 	// TODO: Webhooks should either be authenticated or exposed on a separate port that is not publicly accessible.
+	//  The context webhook is authenticated with a signed token in its URL (see webhooktoken), the proxy webhook is not.
 	router := chi.NewRouter()
 	router.Use(
 		chimiddleware.RequestID,
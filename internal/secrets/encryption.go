@@ -0,0 +1,59 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+
+	"github.com/hyprmcp/jetski/internal/env"
+)
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// Encrypt encrypts the value with the secret encryption key using AES-GCM. The random nonce is prepended to the
+// result.
+func Encrypt(plaintext string) ([]byte, error) {
+	return encrypt(env.SecretEncryptionKey(), plaintext)
+}
+
+// Decrypt decrypts a value that has been encrypted with Encrypt
+func Decrypt(ciphertext []byte) (string, error) {
+	return decrypt(env.SecretEncryptionKey(), ciphertext)
+}
+
+func encrypt(key []byte, plaintext string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, []byte(plaintext), nil), nil
+}
+
+func decrypt(key []byte, ciphertext []byte) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	if plaintext, err := aead.Open(nil, nonce, ciphertext, nil); err != nil {
+		return "", ErrInvalidCiphertext
+	} else {
+		return string(plaintext), nil
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if block, err := aes.NewCipher(key); err != nil {
+		return nil, err
+	} else {
+		return cipher.NewGCM(block)
+	}
+}
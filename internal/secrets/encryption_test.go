@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncryption(t *testing.T) {
+	key := bytes.Repeat([]byte("k"), 32)
+	ciphertext, err := encrypt(key, "secret value")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(ciphertext, []byte("secret value")) {
+		t.Error("expected ciphertext not to contain the plaintext")
+	}
+	if plaintext, err := decrypt(key, ciphertext); err != nil || plaintext != "secret value" {
+		t.Errorf("expected %q, got %q (%v)", "secret value", plaintext, err)
+	}
+	if other, err := encrypt(key, "secret value"); err != nil || bytes.Equal(ciphertext, other) {
+		t.Errorf("expected different ciphertexts for the same value (%v)", err)
+	}
+	if _, err := decrypt(bytes.Repeat([]byte("o"), 32), ciphertext); err != ErrInvalidCiphertext {
+		t.Errorf("expected ErrInvalidCiphertext with another key, got %v", err)
+	}
+	if _, err := decrypt(key, ciphertext[:4]); err != ErrInvalidCiphertext {
+		t.Errorf("expected ErrInvalidCiphertext for truncated ciphertext, got %v", err)
+	}
+}
@@ -8,23 +8,80 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/hyprmcp/jetski/internal/buildconfig"
 	"github.com/hyprmcp/jetski/internal/env"
+	"github.com/hyprmcp/jetski/internal/oidc"
 	"github.com/lestrrat-go/httprc/v3"
 	"github.com/lestrrat-go/jwx/v3/jwk"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
-func (r *Registry) GetJwkSet() jwk.Set {
-	return r.jwkSet
+func (r *Registry) GetTokenVerifier() *oidc.Verifier {
+	return r.tokenVerifier
 }
 
-func (r *Registry) createJwkSet(ctx context.Context, logger *zap.Logger) (jwk.Set, error) {
-	var keySet jwk.Set
-	if cache, err := jwk.NewCache(ctx, httprc.NewClient()); err != nil {
+// GetGatewayTokenVerifier returns the verifier for tokens of MCP gateway sessions. Unlike the verifier of the API, it
+// accepts tokens that have been issued for the clients of the gateway.
+func (r *Registry) GetGatewayTokenVerifier() *oidc.Verifier {
+	return r.gatewayTokenVerifier
+}
+
+// createIssuers returns all trusted issuers. The issuer identifier and key set of each issuer are taken from its
+// discovery metadata.
+func (r *Registry) createIssuers(ctx context.Context, logger *zap.Logger) ([]oidc.Issuer, error) {
+	cache, err := jwk.NewCache(ctx, httprc.NewClient())
+	if err != nil {
 		return nil, err
-	} else if meta, err := GetMedatata(env.OIDCUrl()); err != nil {
+	}
+
+	var issuers []oidc.Issuer
+	if secret := env.DevAuthSecret(); secret != nil {
+		if buildconfig.IsRelease() {
+			return nil, errors.New("dev auth mode is not allowed in release builds")
+		} else if issuer, err := oidc.DevIssuer(secret); err != nil {
+			return nil, err
+		} else {
+			// the identity providers are not contacted at all, so that the server can run offline
+			logger.Warn("dev auth mode enabled, only tokens created with \"jetski dev token\" are accepted")
+			return append(issuers, *issuer), nil
+		}
+	}
+
+	for _, issuerURL := range env.OIDCTrustedIssuers() {
+		if issuer, err := createIssuer(ctx, cache, issuerURL); err != nil {
+			return nil, fmt.Errorf("issuer %v: %w", issuerURL, err)
+		} else {
+			logger.Info("got jwk set", zap.String("issuer", issuer.Issuer))
+			issuers = append(issuers, *issuer)
+		}
+	}
+
+	return issuers, nil
+}
+
+func verifierOptions() oidc.VerifierOptions {
+	return oidc.VerifierOptions{
+		AllowedAudiences:     env.OIDCAllowedAudiences(),
+		ClockSkew:            env.OIDCClockSkew(),
+		RequireEmailVerified: env.OIDCRequireEmailVerified(),
+	}
+}
+
+func gatewayVerifierOptions() oidc.VerifierOptions {
+	return oidc.VerifierOptions{
+		AllowedAudiences:     env.OIDCGatewayAudiences(),
+		AllowAnyAudience:     true,
+		ClockSkew:            env.OIDCClockSkew(),
+		RequireEmailVerified: env.OIDCRequireEmailVerified(),
+	}
+}
+
+func createIssuer(ctx context.Context, cache *jwk.Cache, issuerURL string) (*oidc.Issuer, error) {
+	if meta, err := GetMedatata(issuerURL); err != nil {
 		return nil, err
+	} else if issuer, ok := meta["issuer"].(string); !ok {
+		return nil, errors.New("no issuer")
 	} else if jwksURI, ok := meta["jwks_uri"].(string); !ok {
 		return nil, errors.New("no jwks_uri")
 	} else if err := cache.Register(ctx, jwksURI); err != nil {
@@ -34,9 +91,7 @@ func (r *Registry) createJwkSet(ctx context.Context, logger *zap.Logger) (jwk.Se
 	} else if s, err := cache.CachedSet(jwksURI); err != nil {
 		return nil, err
 	} else {
-		keySet = s
-		logger.Info("got jwk set", zap.String("jwks_uri", jwksURI))
-		return keySet, nil
+		return &oidc.Issuer{Issuer: issuer, KeySet: s}, nil
 	}
 }
 
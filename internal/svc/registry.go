@@ -4,31 +4,43 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"syscall"
 
 	"github.com/go-logr/zapr"
+	"github.com/hyprmcp/jetski/internal/alerting"
 	"github.com/hyprmcp/jetski/internal/buildconfig"
+	"github.com/hyprmcp/jetski/internal/digest"
+	"github.com/hyprmcp/jetski/internal/domainverification"
+	"github.com/hyprmcp/jetski/internal/env"
 	"github.com/hyprmcp/jetski/internal/handlers/webhook"
+	"github.com/hyprmcp/jetski/internal/kubernetes/apply"
+	"github.com/hyprmcp/jetski/internal/kubernetes/reconciler"
 	"github.com/hyprmcp/jetski/internal/mail"
+	"github.com/hyprmcp/jetski/internal/mailoutbox"
 	"github.com/hyprmcp/jetski/internal/migrations"
+	"github.com/hyprmcp/jetski/internal/notifications"
+	"github.com/hyprmcp/jetski/internal/oidc"
+	"github.com/hyprmcp/jetski/internal/promptindex"
 	"github.com/hyprmcp/jetski/internal/routing"
 	"github.com/hyprmcp/jetski/internal/server"
 	"github.com/hyprmcp/jetski/internal/tracers"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/lestrrat-go/jwx/v3/jwk"
 	"go.uber.org/zap"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type Registry struct {
-	dbPool           *pgxpool.Pool
-	logger           *zap.Logger
-	execDbMigrations bool
-	tracers          *tracers.Tracers
-	jwkSet           jwk.Set
-	mailer           mail.Mailer
-	k8sClient        ctrlclient.Client
+	dbPool               *pgxpool.Pool
+	logger               *zap.Logger
+	execDbMigrations     bool
+	tracers              *tracers.Tracers
+	tokenVerifier        *oidc.Verifier
+	gatewayTokenVerifier *oidc.Verifier
+	mailer               mail.Mailer
+	k8sClient            ctrlclient.Client
+	domainResolver       domainverification.Resolver
 }
 
 func NewDefault(ctx context.Context) (*Registry, error) {
@@ -70,10 +82,11 @@ func newRegistry(ctx context.Context, reg *Registry) (*Registry, error) {
 		reg.dbPool = db
 	}
 
-	if oidcProvider, err := reg.createJwkSet(ctx, reg.logger); err != nil {
+	if issuers, err := reg.createIssuers(ctx, reg.logger); err != nil {
 		return nil, err
 	} else {
-		reg.jwkSet = oidcProvider
+		reg.tokenVerifier = oidc.NewVerifier(issuers, verifierOptions())
+		reg.gatewayTokenVerifier = oidc.NewVerifier(issuers, gatewayVerifierOptions())
 	}
 
 	if mailer, err := createMailer(ctx); err != nil {
@@ -89,6 +102,8 @@ func newRegistry(ctx context.Context, reg *Registry) (*Registry, error) {
 		reg.k8sClient = client
 	}
 
+	reg.domainResolver = net.DefaultResolver
+
 	return reg, nil
 }
 
@@ -114,9 +129,11 @@ func (r *Registry) GetServer() server.Server {
 			r.GetLogger(),
 			r.GetDbPool(),
 			r.GetTracers(),
-			r.GetJwkSet(),
+			r.GetTokenVerifier(),
+			r.GetGatewayTokenVerifier(),
 			r.GetMailer(),
 			r.GetK8SClient(),
+			r.GetDomainResolver(),
 		),
 		r.GetLogger().With(zap.String("server", "main")),
 	)
@@ -132,3 +149,68 @@ func (r *Registry) GetWebhookServer() server.Server {
 		r.GetLogger().With(zap.String("server", "webhook")),
 	)
 }
+
+func (r *Registry) GetAlertEvaluator() *alerting.Evaluator {
+	return alerting.NewEvaluator(
+		r.GetLogger().With(zap.String("component", "alert-evaluator")),
+		r.GetDbPool(),
+		env.AlertEvaluationInterval(),
+	)
+}
+
+func (r *Registry) GetNotificationDispatcher() *notifications.Dispatcher {
+	return notifications.NewDispatcher(
+		r.GetLogger().With(zap.String("component", "notification-dispatcher")),
+		r.GetDbPool(),
+		env.NotificationDispatchInterval(),
+	)
+}
+
+func (r *Registry) GetDigestSender() *digest.Sender {
+	return digest.NewSender(
+		r.GetLogger().With(zap.String("component", "digest-sender")),
+		r.GetDbPool(),
+		env.DigestCheckInterval(),
+	)
+}
+
+func (r *Registry) GetDomainResolver() domainverification.Resolver {
+	return r.domainResolver
+}
+
+func (r *Registry) GetDomainVerifier() *domainverification.Verifier {
+	return domainverification.NewVerifier(
+		r.GetLogger().With(zap.String("component", "domain-verifier")),
+		r.GetDbPool(),
+		r.GetDomainResolver(),
+		apply.MCPGateway(r.GetK8SClient()),
+		env.DomainVerificationInterval(),
+		env.DomainVerificationGracePeriod(),
+	)
+}
+
+func (r *Registry) GetGatewayReconciler() *reconciler.Reconciler {
+	return reconciler.NewReconciler(
+		r.GetLogger().With(zap.String("component", "gateway-reconciler")),
+		r.GetDbPool(),
+		r.GetK8SClient(),
+		env.GatewayReconcileInterval(),
+	)
+}
+
+func (r *Registry) GetPromptReindexer() *promptindex.Reindexer {
+	return promptindex.NewReindexer(
+		r.GetLogger().With(zap.String("component", "prompt-reindexer")),
+		r.GetDbPool(),
+		env.PromptReindexInterval(),
+	)
+}
+
+func (r *Registry) GetMailDispatcher() *mailoutbox.Dispatcher {
+	return mailoutbox.NewDispatcher(
+		r.GetLogger().With(zap.String("component", "mail-dispatcher")),
+		r.GetDbPool(),
+		r.GetMailer(),
+		env.MailDispatchInterval(),
+	)
+}
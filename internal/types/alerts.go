@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AlertMetric string
+
+const (
+	// AlertMetricErrorRate is the fraction of failed requests in the evaluation window
+	AlertMetricErrorRate AlertMetric = "error_rate"
+	// AlertMetricP95Latency is the 95th percentile of the request duration in milliseconds in the evaluation window
+	AlertMetricP95Latency AlertMetric = "p95_latency"
+	// AlertMetricRequestVolumeDrop is the relative decrease of requests compared to the previous evaluation window
+	AlertMetricRequestVolumeDrop AlertMetric = "request_volume_drop"
+	// AlertMetricNewErrorGroup is the number of error groups (tool and error message) in the evaluation window that
+	// did not occur before
+	AlertMetricNewErrorGroup AlertMetric = "new_error_group"
+)
+
+type AlertRule struct {
+	ID        uuid.UUID   `db:"id" json:"id"`
+	CreatedAt time.Time   `db:"created_at" json:"createdAt"`
+	CreatedBy uuid.UUID   `db:"created_by" json:"createdBy"`
+	ProjectID uuid.UUID   `db:"project_id" json:"projectId"`
+	Name      string      `db:"name" json:"name"`
+	Metric    AlertMetric `db:"metric" json:"metric"`
+	Threshold float64     `db:"threshold" json:"threshold"`
+	// EvaluationWindow is serialized as evaluationWindowMinutes, see MarshalJSON
+	EvaluationWindow time.Duration `db:"evaluation_window" json:"-"`
+	// MinRequestCount is the minimum number of requests in the evaluation window (or the previous window for
+	// request_volume_drop) that is required for the rule to fire
+	MinRequestCount int        `db:"min_request_count" json:"minRequestCount"`
+	Enabled         bool       `db:"enabled" json:"enabled"`
+	LastEvaluatedAt *time.Time `db:"last_evaluated_at" json:"lastEvaluatedAt"`
+}
+
+// MarshalJSON serializes the evaluation window as whole minutes instead of nanoseconds
+func (r AlertRule) MarshalJSON() ([]byte, error) {
+	type alertRule AlertRule
+	return json.Marshal(struct {
+		alertRule
+		EvaluationWindowMinutes int64 `json:"evaluationWindowMinutes"`
+	}{alertRule(r), int64(r.EvaluationWindow / time.Minute)})
+}
+
+type AlertIncidentStatus string
+
+const (
+	AlertIncidentStatusFiring   AlertIncidentStatus = "firing"
+	AlertIncidentStatusResolved AlertIncidentStatus = "resolved"
+)
+
+type AlertIncident struct {
+	ID          uuid.UUID           `db:"id" json:"id"`
+	CreatedAt   time.Time           `db:"created_at" json:"createdAt"`
+	AlertRuleID *uuid.UUID          `db:"alert_rule_id" json:"alertRuleId"`
+	ProjectID   uuid.UUID           `db:"project_id" json:"projectId"`
+	Status      AlertIncidentStatus `db:"status" json:"status"`
+	RuleName    string              `db:"rule_name" json:"ruleName"`
+	Metric      AlertMetric         `db:"metric" json:"metric"`
+	Threshold   float64             `db:"threshold" json:"threshold"`
+	Value       float64             `db:"value" json:"value"`
+	Message     string              `db:"message" json:"message"`
+	ResolvedAt  *time.Time          `db:"resolved_at" json:"resolvedAt"`
+}
+
+// AlertEvaluation is the result of evaluating an alert rule at a specific point in time
+type AlertEvaluation struct {
+	Value        float64
+	RequestCount int
+	Firing       bool
+	Message      string
+}
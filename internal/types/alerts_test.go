@@ -0,0 +1,20 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAlertRuleMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(AlertRule{Name: "High error rate", EvaluationWindow: 90 * time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(data); !strings.Contains(s, `"evaluationWindowMinutes":90`) || !strings.Contains(s, `"name":"High error rate"`) {
+		t.Errorf("unexpected JSON %v", s)
+	} else if strings.Contains(s, `"evaluationWindow":`) {
+		t.Errorf("expected no evaluationWindow in JSON %v", s)
+	}
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceAccountEmailDomain is the domain of the synthetic email addresses of service account users. The ".invalid"
+// TLD is reserved, so no identity provider can issue a token for these addresses.
+const ServiceAccountEmailDomain = "service-accounts.jetski.invalid"
+
+// APIToken is a long-lived bearer token that authenticates API requests on behalf of a user account, which is either
+// a regular user (personal access token) or a service account. Requests authenticated with an APIToken are limited
+// to the permissions in Scopes, in addition to the permissions of the role of the user account.
+type APIToken struct {
+	ID            uuid.UUID    `db:"id" json:"id"`
+	CreatedAt     time.Time    `db:"created_at" json:"createdAt"`
+	CreatedBy     *uuid.UUID   `db:"created_by" json:"createdBy"`
+	UserAccountID uuid.UUID    `db:"user_account_id" json:"userAccountId"`
+	Name          string       `db:"name" json:"name"`
+	Scopes        []Permission `db:"scopes" json:"scopes"`
+	ExpiresAt     time.Time    `db:"expires_at" json:"expiresAt"`
+	LastUsedAt    *time.Time   `db:"last_used_at" json:"lastUsedAt"`
+	RevokedAt     *time.Time   `db:"revoked_at" json:"revokedAt"`
+}
+
+// Allows returns true if the token has been granted the given permission
+func (t APIToken) Allows(permission Permission) bool {
+	return slices.Contains(t.Scopes, permission)
+}
+
+// CreatedAPIToken is returned once when a token is created. The plaintext token is not stored and can not be
+// retrieved again later.
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// ServiceAccount is a user account that is owned by an organization and can only authenticate with API tokens
+type ServiceAccount struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	CreatedAt      time.Time        `db:"created_at" json:"createdAt"`
+	CreatedBy      *uuid.UUID       `db:"created_by" json:"createdBy"`
+	OrganizationID uuid.UUID        `db:"organization_id" json:"organizationId"`
+	Name           string           `db:"name" json:"name"`
+	Role           OrganizationRole `db:"role" json:"role"`
+}
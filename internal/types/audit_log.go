@@ -0,0 +1,81 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionOrganizationCreate        AuditAction = "organization.create"
+	AuditActionOrganizationUpdate        AuditAction = "organization.update"
+	AuditActionMemberAdd                 AuditAction = "member.add"
+	AuditActionMemberRemove              AuditAction = "member.remove"
+	AuditActionMemberRoleUpdate          AuditAction = "member.role.update"
+	AuditActionMemberPreferencesUpdate   AuditAction = "member.preferences.update"
+	AuditActionInvitationCreate          AuditAction = "invitation.create"
+	AuditActionInvitationResend          AuditAction = "invitation.resend"
+	AuditActionInvitationRevoke          AuditAction = "invitation.revoke"
+	AuditActionProjectCreate             AuditAction = "project.create"
+	AuditActionProjectUpdate             AuditAction = "project.update"
+	AuditActionProjectDelete             AuditAction = "project.delete"
+	AuditActionProjectSettingsUpdate     AuditAction = "project.settings.update"
+	AuditActionAlertRuleCreate           AuditAction = "alert_rule.create"
+	AuditActionAlertRuleUpdate           AuditAction = "alert_rule.update"
+	AuditActionAlertRuleDelete           AuditAction = "alert_rule.delete"
+	AuditActionNotificationChannelCreate AuditAction = "notification_channel.create"
+	AuditActionNotificationChannelUpdate AuditAction = "notification_channel.update"
+	AuditActionNotificationChannelDelete AuditAction = "notification_channel.delete"
+	AuditActionServiceAccountCreate      AuditAction = "service_account.create"
+	AuditActionServiceAccountDelete      AuditAction = "service_account.delete"
+	AuditActionAPITokenCreate            AuditAction = "api_token.create"
+	AuditActionAPITokenRevoke            AuditAction = "api_token.revoke"
+	AuditActionContextPropertyCreate     AuditAction = "context_property.create"
+	AuditActionContextPropertyUpdate     AuditAction = "context_property.update"
+	AuditActionContextPropertyDelete     AuditAction = "context_property.delete"
+	AuditActionToolPolicyCreate          AuditAction = "tool_policy.create"
+	AuditActionToolPolicyUpdate          AuditAction = "tool_policy.update"
+	AuditActionToolPolicyDelete          AuditAction = "tool_policy.delete"
+	AuditActionProjectEnvironmentUpdate  AuditAction = "project.environment.update"
+	AuditActionProjectUpstreamAuthUpdate AuditAction = "project.upstream_auth.update"
+	AuditActionProjectUpstreamAuthDelete AuditAction = "project.upstream_auth.delete"
+)
+
+type AuditTargetType string
+
+const (
+	AuditTargetTypeOrganization        AuditTargetType = "organization"
+	AuditTargetTypeMember              AuditTargetType = "member"
+	AuditTargetTypeInvitation          AuditTargetType = "invitation"
+	AuditTargetTypeProject             AuditTargetType = "project"
+	AuditTargetTypeAlertRule           AuditTargetType = "alert_rule"
+	AuditTargetTypeNotificationChannel AuditTargetType = "notification_channel"
+	AuditTargetTypeServiceAccount      AuditTargetType = "service_account"
+	AuditTargetTypeAPIToken            AuditTargetType = "api_token"
+	AuditTargetTypeContextProperty     AuditTargetType = "context_property"
+	AuditTargetTypeToolPolicy          AuditTargetType = "tool_policy"
+)
+
+// AuditLogEntry records a mutation of a resource. Changes contains the fields of the target that have been changed
+// by the action, keyed by their JSON path.
+type AuditLogEntry struct {
+	ID             uuid.UUID              `db:"id" json:"id"`
+	CreatedAt      time.Time              `db:"created_at" json:"createdAt"`
+	OrganizationID *uuid.UUID             `db:"organization_id" json:"organizationId"`
+	ActorID        *uuid.UUID             `db:"actor_id" json:"actorId"`
+	ActorEmail     string                 `db:"actor_email" json:"actorEmail"`
+	APITokenID     *uuid.UUID             `db:"api_token_id" json:"apiTokenId"`
+	Action         AuditAction            `db:"action" json:"action"`
+	TargetType     AuditTargetType        `db:"target_type" json:"targetType"`
+	TargetID       uuid.UUID              `db:"target_id" json:"targetId"`
+	Changes        map[string]AuditChange `db:"changes" json:"changes"`
+	RequestID      string                 `db:"request_id" json:"requestId"`
+	IPAddress      string                 `db:"ip_address" json:"ipAddress"`
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
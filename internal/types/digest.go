@@ -0,0 +1,52 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DigestFrequency string
+
+const (
+	DigestFrequencyNever  DigestFrequency = "never"
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+// Period returns the duration covered by a single digest or 0 if no digest is sent
+func (f DigestFrequency) Period() time.Duration {
+	switch f {
+	case DigestFrequencyDaily:
+		return 24 * time.Hour
+	case DigestFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// MemberPreferences are the preferences of a user for a specific organization
+type MemberPreferences struct {
+	DigestFrequency DigestFrequency `db:"digest_frequency" json:"digestFrequency"`
+}
+
+// DigestRecipient is an organization member that is due to receive a digest
+type DigestRecipient struct {
+	Organization    Organization
+	UserAccount     UserAccount
+	DigestFrequency DigestFrequency
+}
+
+type OrganizationDigest struct {
+	PeriodStart time.Time       `json:"periodStart"`
+	PeriodEnd   time.Time       `json:"periodEnd"`
+	Projects    []ProjectDigest `json:"projects"`
+}
+
+type ProjectDigest struct {
+	ProjectID       uuid.UUID      `json:"projectId"`
+	ProjectName     string         `json:"projectName"`
+	Overview        Overview       `json:"overview"`
+	TopFailingTools []ErrorHotspot `json:"topFailingTools"`
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EnvironmentVariable is set in the container of the hosted MCP server of a deployment revision. The value of a
+// secret is never returned by the API, so Value is nil for secrets in responses.
+type EnvironmentVariable struct {
+	ID                   uuid.UUID `db:"id" json:"id"`
+	CreatedAt            time.Time `db:"created_at" json:"createdAt"`
+	DeploymentRevisionID uuid.UUID `db:"deployment_revision_id" json:"deploymentRevisionId"`
+	Name                 string    `db:"name" json:"name"`
+	Secret               bool      `db:"secret" json:"secret"`
+	Value                *string   `db:"-" json:"value"`
+}
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GatewaySyncStatus is the result of the last attempt to apply the MCPGateway of an organization to the cluster
+type GatewaySyncStatus struct {
+	OrganizationID uuid.UUID  `db:"organization_id" json:"organizationId"`
+	AttemptedAt    time.Time  `db:"attempted_at" json:"attemptedAt"`
+	SyncedAt       *time.Time `db:"synced_at" json:"syncedAt"`
+	Error          *string    `db:"error" json:"error"`
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	// InvitationStatusExpired is set for an expired pending invitation when the same email address is invited again
+	InvitationStatusExpired InvitationStatus = "expired"
+)
+
+type Invitation struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	CreatedAt      time.Time        `db:"created_at" json:"createdAt"`
+	CreatedBy      *uuid.UUID       `db:"created_by" json:"createdBy"`
+	OrganizationID uuid.UUID        `db:"organization_id" json:"organizationId"`
+	Email          string           `db:"email" json:"email"`
+	Role           OrganizationRole `db:"role" json:"role"`
+	Status         InvitationStatus `db:"status" json:"status"`
+	ExpiresAt      time.Time        `db:"expires_at" json:"expiresAt"`
+	LastSentAt     time.Time        `db:"last_sent_at" json:"lastSentAt"`
+	AcceptedAt     *time.Time       `db:"accepted_at" json:"acceptedAt"`
+	AcceptedBy     *uuid.UUID       `db:"accepted_by" json:"acceptedBy"`
+}
+
+func (i Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
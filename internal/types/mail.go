@@ -0,0 +1,29 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MailStatus string
+
+const (
+	MailStatusPending MailStatus = "pending"
+	MailStatusSent    MailStatus = "sent"
+	MailStatusFailed  MailStatus = "failed"
+)
+
+// OutboxMail is a rendered mail that is sent asynchronously by the mail dispatcher
+type OutboxMail struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	CreatedAt      time.Time  `db:"created_at" json:"createdAt"`
+	OrganizationID *uuid.UUID `db:"organization_id" json:"organizationId"`
+	Recipients     []string   `db:"recipients" json:"recipients"`
+	Subject        string     `db:"subject" json:"subject"`
+	HtmlBody       *string    `db:"html_body" json:"-"`
+	TextBody       *string    `db:"text_body" json:"-"`
+	Status         MailStatus `db:"status" json:"status"`
+	OutboxAttempts
+	SentAt *time.Time `db:"sent_at" json:"sentAt"`
+}
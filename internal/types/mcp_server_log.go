@@ -50,9 +50,11 @@ func (log *MCPServerLog) IsError() bool {
 }
 
 type MCPServerLogPromptData struct {
-	ID        uuid.UUID `json:"id"`
-	StartedAt time.Time `json:"startedAt"`
-	Method    string    `json:"method"`
-	ToolName  string    `json:"toolName"`
-	Prompt    string    `json:"prompt"`
+	ID            uuid.UUID  `json:"id"`
+	StartedAt     time.Time  `json:"startedAt"`
+	Method        string     `json:"method"`
+	ToolName      string     `json:"toolName"`
+	Prompt        string     `json:"prompt"`
+	UserAccountID *uuid.UUID `json:"userAccountId"`
+	MCPSessionID  *string    `json:"mcpSessionId"`
 }
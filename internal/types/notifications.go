@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationChannelType string
+
+const (
+	// NotificationChannelTypeWebhook sends the JSON encoded NotificationEvent signed with an HMAC signature
+	NotificationChannelTypeWebhook NotificationChannelType = "webhook"
+	// NotificationChannelTypeSlack sends the text of the event in the Slack incoming webhook format
+	NotificationChannelTypeSlack NotificationChannelType = "slack"
+)
+
+type NotificationEventType string
+
+const (
+	NotificationEventTypeDeploymentRevisionCreated    NotificationEventType = "deployment_revision.created"
+	NotificationEventTypeDeploymentRevisionEventError NotificationEventType = "deployment_revision_event.error"
+	NotificationEventTypeAlertFired                   NotificationEventType = "alert.fired"
+	NotificationEventTypeAlertResolved                NotificationEventType = "alert.resolved"
+	NotificationEventTypeMemberAdded                  NotificationEventType = "member.added"
+)
+
+var NotificationEventTypes = []NotificationEventType{
+	NotificationEventTypeDeploymentRevisionCreated,
+	NotificationEventTypeDeploymentRevisionEventError,
+	NotificationEventTypeAlertFired,
+	NotificationEventTypeAlertResolved,
+	NotificationEventTypeMemberAdded,
+}
+
+type NotificationChannel struct {
+	ID             uuid.UUID               `db:"id" json:"id"`
+	CreatedAt      time.Time               `db:"created_at" json:"createdAt"`
+	OrganizationID uuid.UUID               `db:"organization_id" json:"organizationId"`
+	Name           string                  `db:"name" json:"name"`
+	Type           NotificationChannelType `db:"type" json:"type"`
+	URL            string                  `db:"url" json:"url"`
+	// Secret is used to sign webhook payloads. It is only returned once when the channel is created and stored
+	// encrypted.
+	Secret string `db:"-" json:"secret,omitempty"`
+	// EventTypes the channel is subscribed to. An empty list subscribes to all event types.
+	EventTypes []NotificationEventType `db:"event_types" json:"eventTypes"`
+	Enabled    bool                    `db:"enabled" json:"enabled"`
+}
+
+// NotificationEvent is the payload that is sent to generic webhook channels
+type NotificationEvent struct {
+	ID             uuid.UUID             `json:"id"`
+	Type           NotificationEventType `json:"type"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	OrganizationID uuid.UUID             `json:"organizationId"`
+	// Text is a human-readable summary of the event
+	Text string `json:"text"`
+	Data any    `json:"data"`
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending   NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusDelivered NotificationDeliveryStatus = "delivered"
+	NotificationDeliveryStatusFailed    NotificationDeliveryStatus = "failed"
+)
+
+type NotificationDelivery struct {
+	ID                    uuid.UUID                  `db:"id" json:"id"`
+	CreatedAt             time.Time                  `db:"created_at" json:"createdAt"`
+	NotificationChannelID uuid.UUID                  `db:"notification_channel_id" json:"notificationChannelId"`
+	EventType             NotificationEventType      `db:"event_type" json:"eventType"`
+	Payload               json.RawMessage            `db:"payload" json:"payload"`
+	Status                NotificationDeliveryStatus `db:"status" json:"status"`
+	OutboxAttempts
+	LastStatusCode *int       `db:"last_status_code" json:"lastStatusCode"`
+	DeliveredAt    *time.Time `db:"delivered_at" json:"deliveredAt"`
+}
+
+// NotificationDeliveryWithChannel is a pending delivery together with the channel it has to be delivered to
+type NotificationDeliveryWithChannel struct {
+	NotificationDelivery
+	Channel NotificationChannel
+}
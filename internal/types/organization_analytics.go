@@ -0,0 +1,45 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationAnalytics struct {
+	Overview      Overview           `json:"overview"`
+	Projects      []ProjectRanking   `json:"projects"`
+	TopTools      []OrganizationTool `json:"topTools"`
+	ClientUsage   ClientUsage        `json:"clientUsage"`
+	ErrorHotspots []ErrorHotspot     `json:"errorHotspots"`
+}
+
+// ProjectRanking contains the key metrics of a single project in the selected period
+type ProjectRanking struct {
+	ProjectID            uuid.UUID `json:"projectId"`
+	ProjectName          string    `json:"projectName"`
+	TotalSessionCount    int       `json:"totalSessionCount"`
+	TotalToolCallsCount  int       `json:"totalToolCallsCount"`
+	TotalToolCallsChange float64   `json:"totalToolCallsChange"`
+	UsersCount           int       `json:"usersCount"`
+	AvgLatencyValue      int       `json:"avgLatencyValue"`
+	ErrorRateValue       float64   `json:"errorRateValue"`
+}
+
+type OrganizationTool struct {
+	ProjectID   uuid.UUID `json:"projectId"`
+	ProjectName string    `json:"projectName"`
+	PerformingTool
+}
+
+// ErrorHotspot is a tool that produced errors in the selected period
+type ErrorHotspot struct {
+	ProjectID   uuid.UUID `json:"projectId"`
+	ProjectName string    `json:"projectName"`
+	ToolName    string    `json:"toolName"`
+	ErrorCount  int       `json:"errorCount"`
+	TotalCalls  int       `json:"totalCalls"`
+	ErrorRate   float64   `json:"errorRate"`
+	LastErrorAt time.Time `json:"lastErrorAt"`
+	LastError   string    `json:"lastError"`
+}
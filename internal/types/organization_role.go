@@ -0,0 +1,75 @@
+package types
+
+import "slices"
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner     OrganizationRole = "owner"
+	OrganizationRoleAdmin     OrganizationRole = "admin"
+	OrganizationRoleDeveloper OrganizationRole = "developer"
+	OrganizationRoleViewer    OrganizationRole = "viewer"
+)
+
+var OrganizationRoles = []OrganizationRole{
+	OrganizationRoleOwner,
+	OrganizationRoleAdmin,
+	OrganizationRoleDeveloper,
+	OrganizationRoleViewer,
+}
+
+type Permission string
+
+const (
+	// PermissionRead allows to view the organization, its projects, logs and analytics
+	PermissionRead Permission = "read"
+	// PermissionWriteProjects allows to create projects and to change their settings, deployments and alert rules
+	PermissionWriteProjects Permission = "write_projects"
+	// PermissionDeleteProjects allows to delete projects
+	PermissionDeleteProjects Permission = "delete_projects"
+	// PermissionManageOrganization allows to change the organization settings and notification channels
+	PermissionManageOrganization Permission = "manage_organization"
+	// PermissionManageMembers allows to add and remove members and to change the roles of members that are not owners
+	PermissionManageMembers Permission = "manage_members"
+	// PermissionManageOwners allows to grant and revoke the owner role
+	PermissionManageOwners Permission = "manage_owners"
+)
+
+var Permissions = []Permission{
+	PermissionRead,
+	PermissionWriteProjects,
+	PermissionDeleteProjects,
+	PermissionManageOrganization,
+	PermissionManageMembers,
+	PermissionManageOwners,
+}
+
+var rolePermissions = map[OrganizationRole][]Permission{
+	OrganizationRoleOwner: {
+		PermissionRead,
+		PermissionWriteProjects,
+		PermissionDeleteProjects,
+		PermissionManageOrganization,
+		PermissionManageMembers,
+		PermissionManageOwners,
+	},
+	OrganizationRoleAdmin: {
+		PermissionRead,
+		PermissionWriteProjects,
+		PermissionDeleteProjects,
+		PermissionManageOrganization,
+		PermissionManageMembers,
+	},
+	OrganizationRoleDeveloper: {
+		PermissionRead,
+		PermissionWriteProjects,
+	},
+	OrganizationRoleViewer: {
+		PermissionRead,
+	},
+}
+
+// Can returns true if members with this role have the given permission
+func (r OrganizationRole) Can(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
@@ -0,0 +1,24 @@
+package types
+
+import "testing"
+
+func TestOrganizationRoleCan(t *testing.T) {
+	for _, tc := range []struct {
+		role       OrganizationRole
+		permission Permission
+		expected   bool
+	}{
+		{OrganizationRoleOwner, PermissionManageOwners, true},
+		{OrganizationRoleAdmin, PermissionManageOwners, false},
+		{OrganizationRoleAdmin, PermissionManageMembers, true},
+		{OrganizationRoleDeveloper, PermissionWriteProjects, true},
+		{OrganizationRoleDeveloper, PermissionDeleteProjects, false},
+		{OrganizationRoleViewer, PermissionRead, true},
+		{OrganizationRoleViewer, PermissionWriteProjects, false},
+		{OrganizationRole("unknown"), PermissionRead, false},
+	} {
+		if actual := tc.role.Can(tc.permission); actual != tc.expected {
+			t.Errorf("%v.Can(%v): expected %v, got %v", tc.role, tc.permission, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package types
+
+import "time"
+
+// OutboxAttempts is the retry state of an entry that is sent asynchronously, like an OutboxMail or a
+// NotificationDelivery
+type OutboxAttempts struct {
+	Attempts      int        `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time  `db:"next_attempt_at" json:"nextAttemptAt"`
+	LastAttemptAt *time.Time `db:"last_attempt_at" json:"lastAttemptAt"`
+	LastError     *string    `db:"last_error" json:"lastError"`
+}
@@ -63,15 +63,57 @@ type McpTool struct {
 	Arguments []ToolArgument `json:"arguments"`
 }
 
+type ArgumentType string
+
+const (
+	ArgumentTypeString  ArgumentType = "string"
+	ArgumentTypeNumber  ArgumentType = "number"
+	ArgumentTypeBoolean ArgumentType = "boolean"
+	ArgumentTypeObject  ArgumentType = "object"
+	ArgumentTypeMixed   ArgumentType = "mixed"
+)
+
 type ToolArgument struct {
-	Name       string          `json:"name"`
-	UsageCount int             `json:"usageCount"`
-	Values     []ArgumentValue `json:"values"`
+	Name       string       `json:"name"`
+	Type       ArgumentType `json:"type"`
+	UsageCount int          `json:"usageCount"`
+	// DistinctCount is the number of distinct values seen for this argument. For high cardinality arguments, this
+	// is a lower bound because tracking of new values stops after a fixed limit.
+	DistinctCount int `json:"distinctCount"`
+	// HighCardinality is true if the argument has too many distinct values to list them all. In this case, Values
+	// only contains the most used values and one additional "other" entry.
+	HighCardinality bool                  `json:"highCardinality"`
+	Values          []ArgumentValue       `json:"values"`
+	NumericStats    *NumericArgumentStats `json:"numericStats,omitempty"`
+	BooleanStats    *BooleanArgumentStats `json:"booleanStats,omitempty"`
 }
 
 type ArgumentValue struct {
 	Name  string `json:"name"`
 	Count int    `json:"count"`
+	// Other is true for the entry that aggregates all values not listed individually
+	Other bool `json:"other,omitempty"`
+}
+
+type NumericArgumentStats struct {
+	Min          float64         `json:"min"`
+	Max          float64         `json:"max"`
+	Avg          float64         `json:"avg"`
+	Distribution []NumericBucket `json:"distribution"`
+}
+
+// NumericBucket counts the values in the half-open interval [From, To). The last bucket of a distribution also
+// includes its upper bound.
+type NumericBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+type BooleanArgumentStats struct {
+	TrueCount  int     `json:"trueCount"`
+	FalseCount int     `json:"falseCount"`
+	TrueRatio  float64 `json:"trueRatio"`
 }
 
 // ClientUsage represents client usage analytics
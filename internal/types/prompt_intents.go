@@ -0,0 +1,46 @@
+package types
+
+import "time"
+
+// PromptIntents summarizes what users ask the tools of a project to do
+type PromptIntents struct {
+	PromptCount int `json:"promptCount"`
+	// AnalyzedPromptCount is the number of prompts used for the analysis. It is lower than PromptCount if there were
+	// more prompts in the selected time range than can be analyzed at once.
+	AnalyzedPromptCount int            `json:"analyzedPromptCount"`
+	Intents             []PromptIntent `json:"intents"`
+	// UnclusteredCount is the number of prompts that are not similar enough to any other prompt to form an intent.
+	UnclusteredCount int            `json:"unclusteredCount"`
+	ToolKeywords     []ToolKeywords `json:"toolKeywords"`
+}
+
+// PromptIntent is a cluster of similar prompts
+type PromptIntent struct {
+	Label         string       `json:"label"`
+	Keywords      []string     `json:"keywords"`
+	PromptCount   int          `json:"promptCount"`
+	Share         float64      `json:"share"`
+	Tools         []IntentTool `json:"tools"`
+	SamplePrompts []MCPPrompt  `json:"samplePrompts"`
+	FirstSeenAt   time.Time    `json:"firstSeenAt"`
+	LastSeenAt    time.Time    `json:"lastSeenAt"`
+}
+
+type IntentTool struct {
+	Name        string `json:"name"`
+	PromptCount int    `json:"promptCount"`
+}
+
+type ToolKeywords struct {
+	ToolName    string    `json:"toolName"`
+	PromptCount int       `json:"promptCount"`
+	Keywords    []Keyword `json:"keywords"`
+}
+
+// Keyword is a single word or a bigram
+type Keyword struct {
+	Term string `json:"term"`
+	// Count is the number of prompts containing the term
+	Count int     `json:"count"`
+	Score float64 `json:"score"`
+}
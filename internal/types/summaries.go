@@ -21,8 +21,8 @@ func (ps *ProjectSummary) GetMCPURL() string {
 		Path:   fmt.Sprintf(env.GatewayPathFormat(), ps.Name),
 	}
 
-	if customDomain := ps.Organization.Settings.CustomDomain; customDomain != nil {
-		u.Host = *customDomain
+	if ps.Organization.Settings.HasVerifiedCustomDomain() {
+		u.Host = *ps.Organization.Settings.CustomDomain
 	}
 
 	return u.String()
@@ -23,6 +23,17 @@ func TestGetMCPURL(t *testing.T) {
 			Organization: Organization{Name: "foo", Settings: OrganizationSettings{CustomDomain: util.PtrTo("mcp.foo.company")}},
 			Project:      Project{Name: "bar"},
 		},
+		"https://foo.hyprmcp.cloud/bar/mcp",
+	)
+
+	check(
+		ProjectSummary{
+			Organization: Organization{Name: "foo", Settings: OrganizationSettings{
+				CustomDomain:             util.PtrTo("mcp.foo.company"),
+				CustomDomainVerification: OrganizationCustomDomainVerification{Verified: true},
+			}},
+			Project: Project{Name: "bar"},
+		},
 		"https://mcp.foo.company/bar/mcp",
 	)
 }
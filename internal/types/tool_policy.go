@@ -0,0 +1,31 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ToolPolicyEffect string
+
+const (
+	ToolPolicyEffectAllow ToolPolicyEffect = "allow"
+	ToolPolicyEffectDeny  ToolPolicyEffect = "deny"
+)
+
+// ToolPolicy allows or denies calls of the tools matching one of the Tools patterns. A policy applies to a user if
+// the email address is contained in Emails, the domain of the email address is contained in Domains and all Claims
+// of the access token have the given value. Empty conditions match all users.
+type ToolPolicy struct {
+	ID        uuid.UUID         `db:"id" json:"id"`
+	CreatedAt time.Time         `db:"created_at" json:"createdAt"`
+	CreatedBy *uuid.UUID        `db:"created_by" json:"createdBy"`
+	ProjectID uuid.UUID         `db:"project_id" json:"projectId"`
+	Name      string            `db:"name" json:"name"`
+	Priority  int               `db:"priority" json:"priority"`
+	Effect    ToolPolicyEffect  `db:"effect" json:"effect"`
+	Tools     []string          `db:"tools" json:"tools"`
+	Emails    []string          `db:"emails" json:"emails"`
+	Domains   []string          `db:"domains" json:"domains"`
+	Claims    map[string]string `db:"claims" json:"claims"`
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,20 +15,80 @@ type Organization struct {
 }
 
 type OrganizationSettings struct {
-	CustomDomain  *string                           `json:"customDomain"`
-	Authorization OrganizationAuthorizationSettings `json:"authorization"`
+	CustomDomain             *string                              `json:"customDomain"`
+	CustomDomainVerification OrganizationCustomDomainVerification `json:"customDomainVerification"`
+	Authorization            OrganizationAuthorizationSettings    `json:"authorization"`
+	Gateway                  OrganizationGatewaySettings          `json:"gateway"`
+}
+
+// OrganizationCustomDomainVerification is the state of the verification of the ownership of the custom domain. The
+// domain is only used by the gateway after the token has been found in its TXT record.
+type OrganizationCustomDomainVerification struct {
+	Token      *string    `json:"token"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verifiedAt"`
+	CheckedAt  *time.Time `json:"checkedAt"`
+}
+
+// HasVerifiedCustomDomain returns whether the organization has a custom domain that may be used by the gateway
+func (s OrganizationSettings) HasVerifiedCustomDomain() bool {
+	return s.CustomDomain != nil && s.CustomDomainVerification.Verified
 }
 
 type OrganizationAuthorizationSettings struct {
 	DCRPublicClient bool `json:"dcrPublicClient"`
 }
 
+// OrganizationGatewaySettings override the platform defaults for the scaling of the gateway of the organization. Nil
+// values use the default.
+type OrganizationGatewaySettings struct {
+	Replicas      *int32                                 `json:"replicas"`
+	CPURequest    *string                                `json:"cpuRequest"`
+	CPULimit      *string                                `json:"cpuLimit"`
+	MemoryRequest *string                                `json:"memoryRequest"`
+	MemoryLimit   *string                                `json:"memoryLimit"`
+	Autoscaling   OrganizationGatewayAutoscalingSettings `json:"autoscaling"`
+	// MinAvailable is the minimum number of available replicas during voluntary disruptions. Zero disables the
+	// PodDisruptionBudget.
+	MinAvailable *int32 `json:"minAvailable"`
+}
+
+// OrganizationGatewayAutoscalingSettings configure a HorizontalPodAutoscaler that replaces the fixed number of
+// replicas if enabled
+type OrganizationGatewayAutoscalingSettings struct {
+	Enabled              *bool  `json:"enabled"`
+	MinReplicas          *int32 `json:"minReplicas"`
+	MaxReplicas          *int32 `json:"maxReplicas"`
+	TargetCPUUtilization *int32 `json:"targetCpuUtilization"`
+}
+
+// OrganizationCertificateStatus is the state of the TLS certificate that cert-manager issues for the custom domain of
+// an organization
+type OrganizationCertificateStatus struct {
+	Host    string `json:"host"`
+	Ready   bool   `json:"ready"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
 type UserAccount struct {
 	ID        uuid.UUID `db:"id" json:"id"`
 	CreatedAt time.Time `db:"created_at" json:"createdAt"`
 	Email     string    `db:"email" json:"email"`
 }
 
+// IsServiceAccount returns true if the user account belongs to a service account. Only service accounts have an email
+// address in ServiceAccountEmailDomain.
+func (u UserAccount) IsServiceAccount() bool {
+	return strings.HasSuffix(strings.ToLower(u.Email), "@"+ServiceAccountEmailDomain)
+}
+
+// OrganizationMember is a user account together with its role in an organization
+type OrganizationMember struct {
+	UserAccount
+	Role OrganizationRole `db:"role" json:"role"`
+}
+
 type OrganizationUserAccount struct {
 	OrganizationID uuid.UUID `db:"organization_id" json:"organizationId"`
 	UserAccountID  uuid.UUID `db:"user_account_id" json:"userAccountId"`
@@ -35,13 +96,30 @@ type OrganizationUserAccount struct {
 }
 
 type Project struct {
-	ID                              uuid.UUID  `db:"id" json:"id"`
-	CreatedAt                       time.Time  `db:"created_at" json:"createdAt"`
-	CreatedBy                       uuid.UUID  `db:"created_by" json:"createdBy"`
-	OrganizationID                  uuid.UUID  `db:"organization_id" json:"organizationId"`
-	Name                            string     `db:"name" json:"name"`
-	LatestDeploymentRevisionID      *uuid.UUID `db:"latest_deployment_revision_id" json:"latestDeploymentRevisionId,omitempty"`
-	LatestDeploymentRevisionEventID *uuid.UUID `db:"latest_deployment_revision_event_id" json:"latestDeploymentRevisionEventId,omitempty"`
+	ID                              uuid.UUID       `db:"id" json:"id"`
+	CreatedAt                       time.Time       `db:"created_at" json:"createdAt"`
+	CreatedBy                       uuid.UUID       `db:"created_by" json:"createdBy"`
+	OrganizationID                  uuid.UUID       `db:"organization_id" json:"organizationId"`
+	Name                            string          `db:"name" json:"name"`
+	LatestDeploymentRevisionID      *uuid.UUID      `db:"latest_deployment_revision_id" json:"latestDeploymentRevisionId,omitempty"`
+	LatestDeploymentRevisionEventID *uuid.UUID      `db:"latest_deployment_revision_event_id" json:"latestDeploymentRevisionEventId,omitempty"`
+	Settings                        ProjectSettings `json:"settings"`
+}
+
+type ProjectSettings struct {
+	Analytics ProjectAnalyticsSettings `json:"analytics"`
+}
+
+// DefaultPromptArgumentName is the prompt argument name of new projects. The gateway adds an argument with this name to
+// all tools unless another name is configured.
+const DefaultPromptArgumentName = "hyprmcpPromptAnalytics"
+
+type ProjectAnalyticsSettings struct {
+	// ExcludedArguments contains tool argument names (or glob patterns like "*token*") that are never tracked
+	// in tool analytics.
+	ExcludedArguments []string `json:"excludedArguments"`
+	// PromptArgumentName is the name of the tool argument that is used to capture user prompts.
+	PromptArgumentName string `json:"promptArgumentName"`
 }
 
 type DeploymentRevision struct {
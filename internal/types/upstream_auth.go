@@ -0,0 +1,30 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UpstreamAuthType string
+
+const (
+	UpstreamAuthTypeHeader                  UpstreamAuthType = "header"
+	UpstreamAuthTypeBearer                  UpstreamAuthType = "bearer"
+	UpstreamAuthTypeOAuth2ClientCredentials UpstreamAuthType = "oauth2ClientCredentials"
+)
+
+// UpstreamAuth contains the credentials the gateway uses to authenticate to the upstream server of a proxied project.
+// Secret is the header value, the bearer token or the OAuth2 client secret depending on the type. It is never
+// returned by the API.
+type UpstreamAuth struct {
+	ProjectID  uuid.UUID        `db:"project_id" json:"projectId"`
+	CreatedAt  time.Time        `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time        `db:"updated_at" json:"updatedAt"`
+	Type       UpstreamAuthType `db:"type" json:"type"`
+	HeaderName *string          `db:"header_name" json:"headerName,omitempty"`
+	TokenURL   *string          `db:"token_url" json:"tokenUrl,omitempty"`
+	ClientID   *string          `db:"client_id" json:"clientId,omitempty"`
+	Scopes     []string         `db:"scopes" json:"scopes"`
+	Secret     *string          `db:"-" json:"-"`
+}
@@ -0,0 +1,52 @@
+// Package webhooktoken signs the webhook URLs that are rendered into the gateway configuration, so that the webhooks
+// can only be called by the gateway of the project they have been created for.
+package webhooktoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/hyprmcp/jetski/internal/env"
+)
+
+// QueryParam is the name of the query parameter that contains the token
+const QueryParam = "token"
+
+type Purpose string
+
+const (
+	// PurposeContext is used for the webhook that resolves the context values of a project
+	PurposeContext Purpose = "context"
+)
+
+// New returns a token for the webhook with the purpose for the given ID that is signed with the gateway webhook
+// signing key
+func New(purpose Purpose, id string) string {
+	return newToken(env.GatewayWebhookSigningKey(), purpose, id)
+}
+
+// Verify returns true if the token has been created for the webhook with the purpose for the given ID
+func Verify(token string, purpose Purpose, id string) bool {
+	return verifyToken(env.GatewayWebhookSigningKey(), token, purpose, id)
+}
+
+func newToken(key []byte, purpose Purpose, id string) string {
+	return base64.RawURLEncoding.EncodeToString(sign(key, purpose, id))
+}
+
+func verifyToken(key []byte, token string, purpose Purpose, id string) bool {
+	if signature, err := base64.RawURLEncoding.DecodeString(token); err != nil {
+		return false
+	} else {
+		return hmac.Equal(signature, sign(key, purpose, id))
+	}
+}
+
+func sign(key []byte, purpose Purpose, id string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	mac.Write([]byte("."))
+	mac.Write([]byte(id))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,29 @@
+package webhooktoken
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestToken(t *testing.T) {
+	key := []byte("test-key")
+	id := uuid.NewString()
+	token := newToken(key, PurposeContext, id)
+
+	if !verifyToken(key, token, PurposeContext, id) {
+		t.Error("expected token to be valid")
+	}
+	if verifyToken(key, token, PurposeContext, uuid.NewString()) {
+		t.Error("expected token to be invalid for another ID")
+	}
+	if verifyToken(key, token, Purpose("other"), id) {
+		t.Error("expected token to be invalid for another purpose")
+	}
+	if verifyToken([]byte("other-key"), token, PurposeContext, id) {
+		t.Error("expected token to be invalid for another key")
+	}
+	if verifyToken(key, "", PurposeContext, id) || verifyToken(key, "not base64!", PurposeContext, id) {
+		t.Error("expected malformed tokens to be invalid")
+	}
+}
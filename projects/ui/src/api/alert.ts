@@ -0,0 +1,43 @@
+import { Base } from './base';
+
+export type AlertMetric =
+  | 'error_rate'
+  | 'p95_latency'
+  | 'request_volume_drop'
+  | 'new_error_group';
+
+export interface AlertRule extends Base {
+  createdBy: string;
+  projectId: string;
+  name: string;
+  metric: AlertMetric;
+  threshold: number;
+  evaluationWindowMinutes: number;
+  minRequestCount: number;
+  enabled: boolean;
+  lastEvaluatedAt?: string;
+}
+
+export type AlertRuleRequest = Pick<
+  AlertRule,
+  | 'name'
+  | 'metric'
+  | 'threshold'
+  | 'evaluationWindowMinutes'
+  | 'minRequestCount'
+  | 'enabled'
+>;
+
+export type AlertIncidentStatus = 'firing' | 'resolved';
+
+export interface AlertIncident extends Base {
+  alertRuleId?: string;
+  projectId: string;
+  status: AlertIncidentStatus;
+  ruleName: string;
+  metric: AlertMetric;
+  threshold: number;
+  value: number;
+  message: string;
+  resolvedAt?: string;
+}
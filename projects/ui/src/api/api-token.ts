@@ -0,0 +1,31 @@
+import { Base } from './base';
+import { OrganizationRole } from './organization';
+
+export type Permission =
+  | 'read'
+  | 'write_projects'
+  | 'delete_projects'
+  | 'manage_organization'
+  | 'manage_members'
+  | 'manage_owners';
+
+export interface APIToken extends Base {
+  createdBy?: string;
+  userAccountId: string;
+  name: string;
+  scopes: Permission[];
+  expiresAt: string;
+  lastUsedAt?: string;
+  revokedAt?: string;
+}
+
+export interface CreatedAPIToken extends APIToken {
+  token: string;
+}
+
+export interface ServiceAccount extends Base {
+  createdBy?: string;
+  organizationId: string;
+  name: string;
+  role: OrganizationRole;
+}
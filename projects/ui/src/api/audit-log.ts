@@ -0,0 +1,29 @@
+import { Base } from './base';
+
+export type AuditTargetType =
+  | 'organization'
+  | 'member'
+  | 'invitation'
+  | 'project'
+  | 'alert_rule'
+  | 'notification_channel'
+  | 'service_account'
+  | 'api_token';
+
+export interface AuditChange {
+  before: unknown;
+  after: unknown;
+}
+
+export interface AuditLogEntry extends Base {
+  organizationId?: string;
+  actorId?: string;
+  actorEmail: string;
+  apiTokenId?: string;
+  action: string;
+  targetType: AuditTargetType;
+  targetId: string;
+  changes: Record<string, AuditChange>;
+  requestId: string;
+  ipAddress: string;
+}
@@ -0,0 +1,17 @@
+import { Base } from './base';
+
+export type ContextPropertyType = 'string' | 'number' | 'boolean';
+
+export interface ContextProperty extends Base {
+  projectId: string;
+  type: ContextPropertyType;
+  name: string;
+  required: boolean;
+}
+
+export interface ContextValue extends Base {
+  authTokenDigest: string;
+  userAccountId: string;
+  contextPropertyId: string;
+  contextPropertyValue: string | number | boolean | null;
+}
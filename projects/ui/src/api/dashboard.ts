@@ -5,6 +5,9 @@ import {
   DeploymentRevisionEvent,
 } from './deployment-revision';
 import { Organization } from './organization';
+import { ClientUsage } from '../app/pages/project/dashboard/analytics/client-usage';
+import { Overview } from '../app/pages/project/dashboard/analytics/overview';
+import { PerformingTool } from '../app/pages/project/dashboard/analytics/tools-performance';
 import { Project } from './project';
 import { UserAccount } from './user-account';
 import { Signal } from '@angular/core';
@@ -87,3 +90,68 @@ export function getUsage(org: Signal<Organization | undefined>) {
     },
   );
 }
+
+export interface ProjectRanking {
+  projectId: string;
+  projectName: string;
+  totalSessionCount: number;
+  totalToolCallsCount: number;
+  totalToolCallsChange: number;
+  usersCount: number;
+  avgLatencyValue: number;
+  errorRateValue: number;
+}
+
+export interface OrganizationTool extends PerformingTool {
+  projectId: string;
+  projectName: string;
+}
+
+export interface ErrorHotspot {
+  projectId: string;
+  projectName: string;
+  toolName: string;
+  errorCount: number;
+  totalCalls: number;
+  errorRate: number;
+  lastErrorAt: string;
+  lastError: string;
+}
+
+export interface OrganizationAnalytics {
+  overview: Overview;
+  projects: ProjectRanking[];
+  topTools: OrganizationTool[];
+  clientUsage: ClientUsage;
+  errorHotspots: ErrorHotspot[];
+}
+
+export function getOrganizationAnalytics(
+  org: Signal<Organization | undefined>,
+  startedAt?: Signal<number | undefined>,
+) {
+  return httpResource(
+    () => {
+      const organization = org();
+      if (organization) {
+        const params: Record<string, string> = {
+          organizationId: organization.id,
+        };
+
+        const startAtValue = startedAt?.();
+        if (startAtValue !== undefined) {
+          params['startedAt'] = startAtValue.toString();
+        }
+
+        return {
+          url: '/api/v1/dashboard/analytics',
+          params,
+        };
+      }
+      return undefined;
+    },
+    {
+      parse: (value) => value as OrganizationAnalytics,
+    },
+  );
+}
@@ -0,0 +1,16 @@
+import { Base } from './base';
+
+export interface EnvironmentVariable extends Base {
+  deploymentRevisionId: string;
+  name: string;
+  secret: boolean;
+  /** always null for secrets */
+  value: string | null;
+}
+
+export interface EnvironmentVariableRequest {
+  name: string;
+  secret: boolean;
+  /** may be omitted for an existing secret to keep its value */
+  value?: string;
+}
@@ -0,0 +1,30 @@
+import { inject, Injectable } from '@angular/core';
+import { HttpClient } from '@angular/common/http';
+import { Observable } from 'rxjs';
+import { Base } from './base';
+import { Organization, OrganizationRole } from './organization';
+
+export type InvitationStatus = 'pending' | 'accepted' | 'revoked' | 'expired';
+
+export interface Invitation extends Base {
+  createdBy?: string;
+  organizationId: string;
+  email: string;
+  role: OrganizationRole;
+  status: InvitationStatus;
+  expiresAt: string;
+  lastSentAt: string;
+  acceptedAt?: string;
+  acceptedBy?: string;
+}
+
+@Injectable({ providedIn: 'root' })
+export class InvitationService {
+  private readonly httpClient = inject(HttpClient);
+
+  public accept(token: string): Observable<Organization> {
+    return this.httpClient.post<Organization>('/api/v1/invitations/accept', {
+      token,
+    });
+  }
+}
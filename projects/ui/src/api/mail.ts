@@ -0,0 +1,15 @@
+import { Base } from './base';
+
+export type MailStatus = 'pending' | 'sent' | 'failed';
+
+export interface OutboxMail extends Base {
+  organizationId?: string;
+  recipients: string[];
+  subject: string;
+  status: MailStatus;
+  attempts: number;
+  nextAttemptAt: string;
+  lastAttemptAt?: string;
+  lastError?: string;
+  sentAt?: string;
+}
@@ -19,6 +19,8 @@ export interface MCPServerLogPromptData {
   method: string;
   toolName: string;
   prompt: string;
+  userAccountId?: string;
+  mcpSessionId?: string;
 }
 
 export interface JsonRpcRequest {
@@ -0,0 +1,42 @@
+import { Base } from './base';
+
+export type NotificationChannelType = 'webhook' | 'slack';
+
+export type NotificationEventType =
+  | 'deployment_revision.created'
+  | 'deployment_revision_event.error'
+  | 'alert.fired'
+  | 'alert.resolved'
+  | 'member.added';
+
+export interface NotificationChannel extends Base {
+  organizationId: string;
+  name: string;
+  type: NotificationChannelType;
+  url: string;
+  /** only returned once when the channel is created */
+  secret?: string;
+  /** an empty list subscribes the channel to all event types */
+  eventTypes: NotificationEventType[];
+  enabled: boolean;
+}
+
+export type NotificationChannelRequest = Pick<
+  NotificationChannel,
+  'name' | 'type' | 'url' | 'eventTypes' | 'enabled'
+>;
+
+export type NotificationDeliveryStatus = 'pending' | 'delivered' | 'failed';
+
+export interface NotificationDelivery extends Base {
+  notificationChannelId: string;
+  eventType: NotificationEventType;
+  payload: unknown;
+  status: NotificationDeliveryStatus;
+  attempts: number;
+  nextAttemptAt: string;
+  lastAttemptAt?: string;
+  lastStatusCode?: number;
+  lastError?: string;
+  deliveredAt?: string;
+}
@@ -9,6 +9,11 @@ export interface Organization extends Base {
   createdAt: string;
   name: string;
   settings: OrganizationSettings;
+  /**
+   * Only set if the organization has a verified custom domain and
+   * certificates are managed by cert-manager.
+   */
+  certificate?: OrganizationCertificateStatus;
 }
 
 export interface OrganizationDomainSettings {
@@ -19,13 +24,77 @@ export interface OrganizationAuthSettings {
   authorization: OrganizationSettingsAuthorization;
 }
 
+export interface OrganizationGatewaySettings {
+  gateway: OrganizationSettingsGateway;
+}
+
+export interface OrganizationDomainVerificationSettings {
+  customDomainVerification: OrganizationSettingsCustomDomainVerification;
+}
+
 export type OrganizationSettings = OrganizationDomainSettings &
-  OrganizationAuthSettings;
+  OrganizationDomainVerificationSettings &
+  OrganizationAuthSettings &
+  OrganizationGatewaySettings;
+
+/**
+ * The token must be published in a TXT record named
+ * `_jetski-challenge.<customDomain>` before the domain is used.
+ */
+export interface OrganizationSettingsCustomDomainVerification {
+  token?: string;
+  verified: boolean;
+  verifiedAt?: string;
+  checkedAt?: string;
+}
 
 export interface OrganizationSettingsAuthorization {
   dcrPublicClient: boolean;
 }
 
+export interface OrganizationSettingsGateway {
+  replicas?: number;
+  cpuRequest?: string;
+  cpuLimit?: string;
+  memoryRequest?: string;
+  memoryLimit?: string;
+  autoscaling: OrganizationSettingsGatewayAutoscaling;
+  minAvailable?: number;
+}
+
+export interface OrganizationSettingsGatewayAutoscaling {
+  enabled?: boolean;
+  minReplicas?: number;
+  maxReplicas?: number;
+  targetCpuUtilization?: number;
+}
+
+export interface OrganizationCertificateStatus {
+  host: string;
+  ready: boolean;
+  reason?: string;
+  message?: string;
+}
+
+export interface GatewaySyncStatus {
+  organizationId: string;
+  attemptedAt: string;
+  syncedAt?: string;
+  error?: string;
+}
+
+export type OrganizationRole = 'owner' | 'admin' | 'developer' | 'viewer';
+
+export interface OrganizationMember extends UserAccount {
+  role: OrganizationRole;
+}
+
+export type DigestFrequency = 'never' | 'daily' | 'weekly';
+
+export interface MemberPreferences {
+  digestFrequency: DigestFrequency;
+}
+
 export function getOrganizationMembers(org: Signal<Organization | undefined>) {
   return httpResource(
     () => {
@@ -38,7 +107,7 @@ export function getOrganizationMembers(org: Signal<Organization | undefined>) {
       return undefined;
     },
     {
-      parse: (value) => value as UserAccount[],
+      parse: (value) => value as OrganizationMember[],
     },
   );
 }
@@ -55,6 +124,10 @@ export class OrganizationService {
     id: string,
     settings: OrganizationAuthSettings,
   ): Observable<Organization>;
+  public updateSettings(
+    id: string,
+    settings: OrganizationGatewaySettings,
+  ): Observable<Organization>;
   public updateSettings(
     id: string,
     settings: unknown,
@@ -63,4 +136,44 @@ export class OrganizationService {
       settings,
     });
   }
+
+  public verifyCustomDomain(id: string): Observable<Organization> {
+    return this.httpClient.post<Organization>(
+      `/api/v1/organizations/${id}/custom-domain/verify`,
+      {},
+    );
+  }
+
+  public getGatewaySyncStatus(id: string): Observable<GatewaySyncStatus> {
+    return this.httpClient.get<GatewaySyncStatus>(
+      `/api/v1/organizations/${id}/gateway-sync`,
+    );
+  }
+
+  public updateMemberRole(
+    id: string,
+    userId: string,
+    role: OrganizationRole,
+  ): Observable<OrganizationMember> {
+    return this.httpClient.put<OrganizationMember>(
+      `/api/v1/organizations/${id}/members/${userId}/role`,
+      { role },
+    );
+  }
+
+  public getPreferences(id: string): Observable<MemberPreferences> {
+    return this.httpClient.get<MemberPreferences>(
+      `/api/v1/organizations/${id}/preferences`,
+    );
+  }
+
+  public updatePreferences(
+    id: string,
+    preferences: MemberPreferences,
+  ): Observable<MemberPreferences> {
+    return this.httpClient.put<MemberPreferences>(
+      `/api/v1/organizations/${id}/preferences`,
+      preferences,
+    );
+  }
 }
@@ -12,6 +12,16 @@ export interface Project extends Base {
   createdBy: string;
   latestDeploymentRevisionId: string;
   latestDeploymentRevisionEventId: string | undefined;
+  settings: ProjectSettings;
+}
+
+export interface ProjectSettings {
+  analytics: ProjectAnalyticsSettings;
+}
+
+export interface ProjectAnalyticsSettings {
+  excludedArguments: string[];
+  promptArgumentName: string;
 }
 
 export interface ProjectSettingsRequest {
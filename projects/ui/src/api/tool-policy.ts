@@ -0,0 +1,22 @@
+import { Base } from './base';
+
+export type ToolPolicyEffect = 'allow' | 'deny';
+
+export interface ToolPolicy extends Base {
+  createdBy?: string;
+  projectId: string;
+  name: string;
+  priority: number;
+  effect: ToolPolicyEffect;
+  tools: string[];
+  emails: string[];
+  domains: string[];
+  claims: Record<string, string>;
+  enforced: boolean;
+}
+
+export interface ToolPolicyEvaluation {
+  allowed: boolean;
+  enforced: boolean;
+  policy?: ToolPolicy;
+}
@@ -0,0 +1,23 @@
+export type UpstreamAuthType = 'header' | 'bearer' | 'oauth2ClientCredentials';
+
+export interface UpstreamAuth {
+  projectId: string;
+  createdAt: string;
+  updatedAt: string;
+  type: UpstreamAuthType;
+  headerName?: string;
+  tokenUrl?: string;
+  clientId?: string;
+  scopes: string[];
+  enforced: boolean;
+}
+
+export interface UpstreamAuthRequest {
+  type: UpstreamAuthType;
+  headerName?: string;
+  tokenUrl?: string;
+  clientId?: string;
+  scopes?: string[];
+  /** may be omitted to keep the existing secret if the type does not change */
+  secret?: string;
+}
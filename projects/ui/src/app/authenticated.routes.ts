@@ -3,6 +3,7 @@ import { toObservable } from '@angular/core/rxjs-interop';
 import { CanActivateFn, Router, Routes } from '@angular/router';
 import { filter, firstValueFrom } from 'rxjs';
 import { AppShellComponent } from './app-shell.component';
+import { AcceptInvitationComponent } from './pages/accept-invitation/accept-invitation.component';
 import { ProjectDeploymentsComponent } from './components/deployments/project-deployments.component';
 import { HomeComponent } from './pages/home/home.component';
 import { MonitoringComponent } from './pages/monitoring/monitoring.component';
@@ -76,7 +77,10 @@ export const contextGuard: CanActivateFn = async (route, state) => {
   await resourceDone(contextRes.status);
   if (contextRes.hasValue()) {
     if ((contextRes.value()?.organizations ?? []).length === 0) {
-      if (state.url === '/onboarding') {
+      if (
+        state.url === '/onboarding' ||
+        state.url.startsWith('/invitations/accept')
+      ) {
         return true;
       }
       return router.createUrlTree(['/onboarding']);
@@ -117,6 +121,10 @@ export const authenticatedRoutes: Routes = [
           flow: 'onboarding',
         },
       },
+      {
+        path: 'invitations/accept',
+        component: AcceptInvitationComponent,
+      },
       {
         path: 'organizations/new',
         component: OnboardingComponent,
@@ -0,0 +1,51 @@
+import { Component, inject, OnInit, signal } from '@angular/core';
+import { ActivatedRoute, Router } from '@angular/router';
+import { InvitationService } from '../../../api/invitation';
+import { ContextService } from '../../services/context.service';
+
+@Component({
+  selector: 'app-accept-invitation',
+  template: `
+    <div class="flex justify-center items-center">
+      <div class="w-full max-w-2xl md:w-1/2 space-y-6">
+        @if (error(); as error) {
+          <h1 class="text-2xl font-semibold text-foreground">
+            Invitation could not be accepted
+          </h1>
+          <p class="text-muted-foreground">{{ error }}</p>
+        } @else {
+          <p class="text-muted-foreground">Accepting invitation…</p>
+        }
+      </div>
+    </div>
+  `,
+})
+export class AcceptInvitationComponent implements OnInit {
+  private readonly route = inject(ActivatedRoute);
+  private readonly router = inject(Router);
+  private readonly invitationService = inject(InvitationService);
+  private readonly contextService = inject(ContextService);
+  readonly error = signal<string | undefined>(undefined);
+
+  ngOnInit() {
+    const token = this.route.snapshot.queryParamMap.get('token');
+    if (!token) {
+      this.error.set('The invitation link is invalid.');
+      return;
+    }
+
+    this.invitationService.accept(token).subscribe({
+      next: (org) => {
+        this.contextService.context.reload();
+        this.router.navigate(['/', org.name]);
+      },
+      error: (err) => {
+        this.error.set(
+          typeof err?.error === 'string'
+            ? err.error
+            : 'The invitation could not be accepted.',
+        );
+      },
+    });
+  }
+}
@@ -187,19 +187,18 @@ export class OrganizationSettingsMembersComponent {
     this.error.set(undefined);
     const email = this.form.value.email;
     this.http
-      .put(
-        `/api/v1/organizations/${this.contextService.selectedOrg()!.id}/members`,
+      .post(
+        `/api/v1/organizations/${this.contextService.selectedOrg()!.id}/invitations`,
         { email },
         { responseType: 'text' },
       )
       .subscribe({
         next: () => {
-          this.members.reload();
           this.loading.set(false);
           this.inviteDialogRef()?.close();
           this.form.reset();
           toast.success('User invited successfully', {
-            description: `${email} is now part of the ${this.contextService.selectedOrg()?.name} organization`,
+            description: `${email} has been invited to the ${this.contextService.selectedOrg()?.name} organization`,
           });
         },
         error: (err) => {
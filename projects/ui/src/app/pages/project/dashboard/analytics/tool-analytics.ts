@@ -1,12 +1,39 @@
 export interface ArgumentValue {
   name: string;
   count: number;
+  other?: boolean;
+}
+
+export type ArgumentType = 'string' | 'number' | 'boolean' | 'object' | 'mixed';
+
+export interface NumericBucket {
+  from: number;
+  to: number;
+  count: number;
+}
+
+export interface NumericArgumentStats {
+  min: number;
+  max: number;
+  avg: number;
+  distribution: NumericBucket[];
+}
+
+export interface BooleanArgumentStats {
+  trueCount: number;
+  falseCount: number;
+  trueRatio: number;
 }
 
 export interface ToolArgument {
   name: string;
+  type: ArgumentType;
   usageCount: number;
+  distinctCount: number;
+  highCardinality: boolean;
   values: ArgumentValue[];
+  numericStats?: NumericArgumentStats;
+  booleanStats?: BooleanArgumentStats;
 }
 
 export interface McpTool {